@@ -0,0 +1,144 @@
+package bitmask
+
+import "sync/atomic"
+
+// AtomicMask is an int bitmask that many goroutines can update at once without
+// a mutex. Every method is a single atomic operation on the whole word, so
+// concurrent Set and Clear calls on different bits never lose each other's
+// changes the way a plain read-modify-write of an int would.
+//
+// The zero value is an empty mask ready to use. An AtomicMask must not be
+// copied after first use.
+//
+// Example:
+//
+//	var features bitmask.AtomicMask
+//	go features.Set(FeatureBeta)
+//	go features.Set(FeatureDarkMode)
+//
+//	if features.SetIfUnset(FeatureWarmedUp) {
+//		warmUp() // runs once, however many goroutines race here
+//	}
+type AtomicMask struct {
+	v atomic.Int64
+}
+
+// NewAtomicMask returns an AtomicMask holding mask.
+func NewAtomicMask(mask int) *AtomicMask {
+	m := &AtomicMask{}
+	m.Store(mask)
+	return m
+}
+
+// Load returns the current mask.
+func (m *AtomicMask) Load() int {
+	return int(m.v.Load())
+}
+
+// Store replaces the mask.
+func (m *AtomicMask) Store(mask int) {
+	m.v.Store(int64(mask))
+}
+
+// HasBit reports whether the bit at position id is currently set.
+func (m *AtomicMask) HasBit(id int) bool {
+	return HasBit(m.Load(), id)
+}
+
+// Set turns on the bit at position id and returns the mask as it was before.
+func (m *AtomicMask) Set(id int) (old int) {
+	return int(m.v.Or(1 << id))
+}
+
+// Clear turns off the bit at position id and returns the mask as it was before.
+func (m *AtomicMask) Clear(id int) (old int) {
+	return int(m.v.And(^(1 << id)))
+}
+
+// Toggle flips the bit at position id and returns the mask as it was before.
+func (m *AtomicMask) Toggle(id int) (old int) {
+	for {
+		old := m.v.Load()
+		if m.v.CompareAndSwap(old, old^(1<<id)) {
+			return int(old)
+		}
+	}
+}
+
+// CompareAndSwap replaces the mask with new only if it is still old, and reports
+// whether it did. Use it to apply changes to several bits as one step.
+//
+// Example:
+//
+//	for {
+//		old := m.Load()
+//		if m.CompareAndSwap(old, ClearBits(old, 1, 2)|1<<3) {
+//			break
+//		}
+//	}
+func (m *AtomicMask) CompareAndSwap(old, new int) bool {
+	return m.v.CompareAndSwap(int64(old), int64(new))
+}
+
+// SetIfUnset turns on the bit at position id and reports whether this call was
+// the one that turned it on. Of several goroutines racing to set the same bit,
+// exactly one gets true.
+func (m *AtomicMask) SetIfUnset(id int) bool {
+	return !HasBit(m.Set(id), id)
+}
+
+// OpKind is what an Op does to its bit.
+type OpKind int
+
+const (
+	OpSet OpKind = iota
+	OpClear
+	OpToggle
+)
+
+// Op is one bit change in an AtomicMask.ApplyOps batch.
+type Op struct {
+	Kind OpKind
+	ID   int
+}
+
+// apply returns mask with the op applied. It panics on an unknown Kind.
+func (o Op) apply(mask int) int {
+	switch o.Kind {
+	case OpSet:
+		return SetBit(mask, o.ID)
+	case OpClear:
+		return ClearBit(mask, o.ID)
+	case OpToggle:
+		return ToggleBit(mask, o.ID)
+	}
+	panic("bitmask: unknown op kind")
+}
+
+// ApplyOps applies ops in order as a single atomic step and returns the mask
+// before and after. Other goroutines see either none of the changes or all of
+// them, never a state in between.
+//
+// Example:
+//
+//	// Move a job from queued to running in one step.
+//	before, after := state.ApplyOps([]bitmask.Op{
+//		{Kind: bitmask.OpClear, ID: StateQueued},
+//		{Kind: bitmask.OpSet, ID: StateRunning},
+//	})
+//
+// Under contention the ops may be evaluated more than once before the swap
+// succeeds, but only one result is ever stored. ApplyOps panics on an Op with
+// an unknown Kind, before changing anything.
+func (m *AtomicMask) ApplyOps(ops []Op) (before, after int) {
+	for {
+		before = m.Load()
+		after = before
+		for _, op := range ops {
+			after = op.apply(after)
+		}
+		if m.CompareAndSwap(before, after) {
+			return before, after
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package bitmask
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicMask(t *testing.T) {
+	var m AtomicMask
+	assert.Equal(t, 0, m.Load())
+
+	assert.Equal(t, 0, m.Set(1))
+	assert.Equal(t, 2, m.Set(3))
+	assert.Equal(t, 10, m.Set(3), "setting again is a no-op")
+	assert.True(t, m.HasBit(3))
+
+	assert.Equal(t, 10, m.Clear(1))
+	assert.Equal(t, 8, m.Clear(1))
+	assert.Equal(t, 8, m.Toggle(5))
+	assert.Equal(t, 40, m.Toggle(3))
+	assert.Equal(t, 32, m.Load())
+
+	assert.False(t, m.CompareAndSwap(0, 7))
+	assert.True(t, m.CompareAndSwap(32, 7))
+	assert.Equal(t, 7, m.Load())
+
+	assert.True(t, m.SetIfUnset(4))
+	assert.False(t, m.SetIfUnset(4))
+
+	m.Store(-1)
+	assert.Equal(t, -1, m.Load())
+	assert.Equal(t, 42, NewAtomicMask(42).Load())
+}
+
+func TestAtomicMaskConcurrent(t *testing.T) {
+	var (
+		m   AtomicMask
+		won atomic.Int32
+		wg  sync.WaitGroup
+	)
+	for id := range 62 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.Set(id)
+			m.Toggle(62)
+		}()
+		go func() {
+			defer wg.Done()
+			if m.SetIfUnset(63) {
+				won.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), won.Load(), "exactly one SetIfUnset wins")
+	assert.Equal(t, 62+1, Count(m.Load()), "62 toggles of bit 62 cancel out")
+	assert.False(t, m.HasBit(62))
+
+	for id := range 62 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Clear(id)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []int{63}, slices.Collect(Bits(m.Load())))
+}
+
+func TestAtomicMaskApplyOps(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int
+		ops   []Op
+		after int
+	}{
+		{
+			name:  "no ops",
+			start: 5,
+			after: 5,
+		},
+		{
+			name:  "move a bit",
+			start: 0b001,
+			ops:   []Op{{Kind: OpClear, ID: 0}, {Kind: OpSet, ID: 2}},
+			after: 0b100,
+		},
+		{
+			name:  "applied in order",
+			start: 0,
+			ops:   []Op{{Kind: OpSet, ID: 1}, {Kind: OpClear, ID: 1}, {Kind: OpToggle, ID: 3}},
+			after: 0b1000,
+		},
+		{
+			name:  "toggle twice",
+			start: 0b10,
+			ops:   []Op{{Kind: OpToggle, ID: 1}, {Kind: OpToggle, ID: 1}},
+			after: 0b10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAtomicMask(tt.start)
+			before, after := m.ApplyOps(tt.ops)
+			assert.Equal(t, tt.start, before, tt.name)
+			assert.Equal(t, tt.after, after, tt.name)
+			assert.Equal(t, tt.after, m.Load(), tt.name)
+		})
+	}
+
+	m := NewAtomicMask(1)
+	assert.PanicsWithValue(t, "bitmask: unknown op kind", func() {
+		m.ApplyOps([]Op{{Kind: OpSet, ID: 4}, {Kind: OpKind(9), ID: 0}})
+	})
+	assert.Equal(t, 1, m.Load(), "unchanged after panic")
+}
+
+func TestAtomicMaskApplyOpsConcurrent(t *testing.T) {
+	var (
+		m    = NewAtomicMask(0b01)
+		wg   sync.WaitGroup
+		done atomic.Bool
+		torn atomic.Int32
+	)
+	swap := []Op{{Kind: OpToggle, ID: 0}, {Kind: OpToggle, ID: 1}}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !done.Load() {
+			if m.Load()&0b11 == 0b00 || m.Load()&0b11 == 0b11 {
+				torn.Add(1)
+			}
+		}
+	}()
+
+	var writers sync.WaitGroup
+	for range 8 {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for range 1000 {
+				m.ApplyOps(swap)
+			}
+		}()
+	}
+	writers.Wait()
+	done.Store(true)
+	wg.Wait()
+
+	assert.Zero(t, torn.Load(), "a half-applied swap was observed")
+	assert.Equal(t, 0b01, m.Load(), "an even number of swaps")
+}
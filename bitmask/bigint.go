@@ -0,0 +1,34 @@
+package bitmask
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// ToBigInt returns the set as a non-negative integer in which bit n is set for
+// each ID n, for databases and languages that store wide flag sets as
+// arbitrary-precision numbers.
+//
+// Example:
+//
+//	{1, 3, 100}.ToBigInt() => 2¹ + 2³ + 2¹⁰⁰ = 1267650600228229401496703205386
+func (b *BitSet) ToBigInt() *big.Int {
+	le := b.bytes()
+	slices.Reverse(le)
+	return new(big.Int).SetBytes(le)
+}
+
+// FromBigInt replaces the contents of b with the bits of x. It returns an error,
+// leaving b unchanged, if x is negative.
+func (b *BitSet) FromBigInt(x *big.Int) error {
+	if x.Sign() < 0 {
+		return fmt.Errorf("bitmask: %w: negative big.Int %s", ErrInvalidEncoding, x)
+	}
+
+	be := x.Bytes()
+	slices.Reverse(be)
+	b.fromBytes(be)
+
+	return nil
+}
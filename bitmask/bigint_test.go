@@ -0,0 +1,60 @@
+package bitmask
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSetBigInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		expected string
+	}{
+		{
+			name:     "empty",
+			ids:      nil,
+			expected: "0",
+		},
+		{
+			name:     "small",
+			ids:      []int{1, 3, 5},
+			expected: "42",
+		},
+		{
+			name:     "top bit of a word",
+			ids:      []int{63},
+			expected: "9223372036854775808",
+		},
+		{
+			name:     "wide",
+			ids:      []int{1, 3, 100},
+			expected: "1267650600228229401496703205386",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bitSetOf(tt.ids...)
+			x := b.ToBigInt()
+			assert.Equal(t, tt.expected, x.String(), tt.name)
+
+			got := bitSetOf(7, 500)
+			assert.NoError(t, got.FromBigInt(x), tt.name)
+			assert.True(t, b.Equal(got), tt.name)
+		})
+	}
+
+	x, _ := new(big.Int).SetString("10000000000000000000000000000000000000000", 16)
+	var b BitSet
+	assert.NoError(t, b.FromBigInt(x))
+	assert.Equal(t, []int{160}, setBits(&b))
+}
+
+func TestBitSetFromBigIntNegative(t *testing.T) {
+	b := bitSetOf(3)
+	err := b.FromBigInt(big.NewInt(-5))
+	assert.EqualError(t, err, "bitmask: invalid encoding: negative big.Int -5")
+	assert.Equal(t, []int{3}, setBits(b))
+}
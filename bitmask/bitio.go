@@ -0,0 +1,172 @@
+package bitmask
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Bits within each byte are read and written most significant first, the order
+// used by most network and media formats: writing the 3-bit value 0b101 and then
+// the 5-bit value 0b00011 produces the single byte 0b101_00011.
+
+// errBitCount is returned for a bit count outside [0, 64].
+var errBitCount = errors.New("bitmask: bit count out of range [0, 64]")
+
+// BitReader reads values of any width from 0 to 64 bits from a byte stream,
+// most significant bit first. It reads from the underlying reader through a
+// buffer, so it may read past the bits it returns.
+//
+// Example:
+//
+//	r := bitmask.NewBitReader(conn)
+//	version, _ := r.ReadBits(4)
+//	flags, _ := r.ReadBits(3)
+//	more, _ := r.ReadBit()
+type BitReader struct {
+	r   io.ByteReader
+	cur byte
+	n   int // unread bits left in cur, taken from the top
+}
+
+// NewBitReader returns a BitReader reading from r. If r is already an
+// io.ByteReader it is used directly; otherwise it is wrapped in a bufio.Reader.
+func NewBitReader(r io.Reader) *BitReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return &BitReader{r: br}
+}
+
+// ReadBits reads the next n bits and returns them in the low n bits of the
+// result. It returns io.EOF if the stream ended before any of them, and
+// io.ErrUnexpectedEOF if it ended part way.
+//
+// Example:
+//
+//	input byte: 1011_0010
+//	ReadBits(3) => 0b101
+//	ReadBits(5) => 0b10010
+func (r *BitReader) ReadBits(n int) (uint64, error) {
+	if n < 0 || n > 64 {
+		return 0, errBitCount
+	}
+
+	var v uint64
+	for read := 0; read < n; {
+		if r.n == 0 {
+			b, err := r.r.ReadByte()
+			if err != nil {
+				if err == io.EOF && read > 0 {
+					err = io.ErrUnexpectedEOF
+				}
+				return 0, err
+			}
+			r.cur, r.n = b, 8
+		}
+
+		take := min(n-read, r.n)
+		v = v<<take | uint64(r.cur>>(r.n-take))&(1<<take-1)
+		r.n -= take
+		read += take
+	}
+
+	return v, nil
+}
+
+// ReadBit reads a single bit.
+func (r *BitReader) ReadBit() (bool, error) {
+	v, err := r.ReadBits(1)
+	return v == 1, err
+}
+
+// Align discards the rest of the current byte, so the next read starts at a
+// byte boundary. It does nothing if the reader is already aligned.
+func (r *BitReader) Align() {
+	r.n = 0
+}
+
+// BitWriter writes values of any width from 0 to 64 bits to a byte stream, most
+// significant bit first. Output is buffered: call Flush when done to write the
+// last, partly filled byte and any buffered data.
+//
+// Example:
+//
+//	w := bitmask.NewBitWriter(conn)
+//	w.WriteBits(version, 4)
+//	w.WriteBits(flags, 3)
+//	w.WriteBit(more)
+//	if err := w.Flush(); err != nil {
+//		return err
+//	}
+//
+// Once a write fails, every later call returns the same error.
+type BitWriter struct {
+	w   *bufio.Writer
+	cur byte
+	n   int // bits filled in cur, from the top
+	err error
+}
+
+// NewBitWriter returns a BitWriter writing to w.
+func NewBitWriter(w io.Writer) *BitWriter {
+	return &BitWriter{w: bufio.NewWriter(w)}
+}
+
+// WriteBits writes the low n bits of v. Bits of v above n are ignored.
+func (w *BitWriter) WriteBits(v uint64, n int) error {
+	if n < 0 || n > 64 {
+		return errBitCount
+	}
+
+	for n > 0 && w.err == nil {
+		take := min(n, 8-w.n)
+		chunk := byte(v>>(n-take)) & (1<<take - 1)
+		w.cur |= chunk << (8 - w.n - take)
+		w.n += take
+		n -= take
+
+		if w.n == 8 {
+			w.err = w.w.WriteByte(w.cur)
+			w.cur, w.n = 0, 0
+		}
+	}
+
+	return w.err
+}
+
+// WriteBit writes a single bit: 1 for true, 0 for false.
+func (w *BitWriter) WriteBit(bit bool) error {
+	var v uint64
+	if bit {
+		v = 1
+	}
+
+	return w.WriteBits(v, 1)
+}
+
+// Buffered returns the number of bits written but not yet flushed.
+func (w *BitWriter) Buffered() int {
+	return 8*w.w.Buffered() + w.n
+}
+
+// Flush pads the current byte with zero bits, if it is partly filled, and
+// writes all buffered data to the underlying writer. Writing can continue
+// afterwards, starting on a new byte.
+func (w *BitWriter) Flush() error {
+	if w.err == nil && w.n > 0 {
+		w.err = w.w.WriteByte(w.cur)
+		w.cur, w.n = 0, 0
+	}
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	if w.err != nil {
+		return fmt.Errorf("bitmask: flush: %w", w.err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package bitmask
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitReader(t *testing.T) {
+	r := NewBitReader(bytes.NewReader([]byte{0b1011_0010, 0xff, 0x01}))
+
+	v, err := r.ReadBits(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0b101), v)
+
+	v, err = r.ReadBits(5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0b10010), v)
+
+	bit, err := r.ReadBit()
+	assert.NoError(t, err)
+	assert.True(t, bit)
+
+	r.Align()
+	v, err = r.ReadBits(0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), v)
+	v, err = r.ReadBits(8)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), v, "Align skipped the rest of 0xff")
+
+	_, err = r.ReadBits(1)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = r.ReadBits(65)
+	assert.EqualError(t, err, "bitmask: bit count out of range [0, 64]")
+}
+
+func TestBitReaderUnexpectedEOF(t *testing.T) {
+	r := NewBitReader(iotest.OneByteReader(strings.NewReader("\x01")))
+	_, err := r.ReadBits(12)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestBitWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewBitWriter(&buf)
+
+	assert.NoError(t, w.WriteBits(0b101, 3))
+	assert.NoError(t, w.WriteBits(0b1111_0010, 5), "bits above n are ignored")
+	assert.NoError(t, w.WriteBit(true))
+	assert.NoError(t, w.WriteBits(0b01, 2))
+	assert.Equal(t, 11, w.Buffered())
+	assert.Equal(t, 0, buf.Len(), "nothing written before Flush")
+
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, []byte{0b1011_0010, 0b1010_0000}, buf.Bytes())
+	assert.Equal(t, 0, w.Buffered())
+
+	assert.NoError(t, w.WriteBits(0xab, 8))
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, []byte{0b1011_0010, 0b1010_0000, 0xab}, buf.Bytes(), "Flush starts a new byte")
+
+	assert.EqualError(t, w.WriteBits(1, -1), "bitmask: bit count out of range [0, 64]")
+}
+
+func TestBitWriterError(t *testing.T) {
+	failing := errors.New("disk full")
+	w := NewBitWriter(errWriter{failing})
+
+	assert.NoError(t, w.WriteBits(1, 3), "buffered")
+	assert.ErrorIs(t, w.Flush(), failing)
+	assert.ErrorIs(t, w.WriteBits(1, 8), failing, "errors are sticky")
+}
+
+type errWriter struct{ err error }
+
+func (w errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestBitReaderWriterRoundTrip(t *testing.T) {
+	fields := []struct {
+		v uint64
+		n int
+	}{
+		{v: 1, n: 1},
+		{v: 0x5, n: 3},
+		{v: 0x1234, n: 13},
+		{v: math.MaxUint64, n: 64},
+		{v: 0, n: 0},
+		{v: 0x2a, n: 7},
+		{v: 1 << 40, n: 41},
+	}
+
+	var buf bytes.Buffer
+	w := NewBitWriter(&buf)
+	for _, f := range fields {
+		assert.NoError(t, w.WriteBits(f.v, f.n))
+	}
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, (1+3+13+64+7+41+7)/8, buf.Len())
+
+	r := NewBitReader(&buf)
+	for _, f := range fields {
+		v, err := r.ReadBits(f.n)
+		assert.NoError(t, err)
+		assert.Equal(t, f.v, v)
+	}
+}
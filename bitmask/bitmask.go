@@ -13,6 +13,8 @@
 //	- ...
 package bitmask
 
+import "math/bits"
+
 // Encode returns a bitmask with bits set for each ID in the input slice.
 //
 // Each ID corresponds to a bit position. For example, ID 3 sets bit 3 using `1 << 3`.
@@ -37,6 +39,17 @@ func Encode(ids []int) int {
 	return mask
 }
 
+// Of returns a bitmask with bits set for each of the given IDs. It is Encode
+// for IDs written out at the call site, without building a slice first.
+//
+// Example:
+//
+//	Of(RoleAdmin, RoleAuditor)
+//	Of(1, 3, 5) => 42
+func Of(ids ...int) int {
+	return Encode(ids)
+}
+
 // Decode returns the list of IDs (bit positions) that are set in the given bitmask.
 //
 // It loops through the bits of the mask from right to left.
@@ -91,3 +104,82 @@ func HasBit(mask int, id int) bool {
 func ToggleBit(mask int, id int) int {
 	return mask ^ (1 << id)
 }
+
+// SetBit turns on the bit at position `id` using OR (`|`).
+//
+// Unlike ToggleBit, it is idempotent: setting a bit that is already set leaves
+// the mask unchanged.
+//   - 0 | 1 = 1 (turns on the bit)
+//   - 1 | 1 = 1 (stays on)
+//
+// Example:
+//
+//	mask = 00100010 (decimal 34)
+//	id = 3 → 1 << 3 = 00001000
+//	00100010 | 00001000 = 00101010 (decimal 42)
+func SetBit(mask int, id int) int {
+	return mask | (1 << id)
+}
+
+// ClearBit turns off the bit at position `id` using AND NOT (`&^`).
+//
+// Like SetBit, it is idempotent: clearing a bit that is already clear leaves
+// the mask unchanged.
+//   - 1 &^ 1 = 0 (turns off the bit)
+//   - 0 &^ 1 = 0 (stays off)
+//
+// Example:
+//
+//	mask = 00101010 (decimal 42)
+//	id = 3 → 1 << 3 = 00001000
+//	00101010 &^ 00001000 = 00100010 (decimal 34)
+func ClearBit(mask int, id int) int {
+	return mask &^ (1 << id)
+}
+
+// SetBits turns on the bits at all the given positions.
+//
+// Example:
+//
+//	SetBits(0, 1, 3, 5) => 42 (binary: 00101010)
+func SetBits(mask int, ids ...int) int {
+	for _, id := range ids {
+		mask |= 1 << id
+	}
+
+	return mask
+}
+
+// ClearBits turns off the bits at all the given positions.
+//
+// Example:
+//
+//	ClearBits(42, 1, 5) => 8 (binary: 00001000)
+func ClearBits(mask int, ids ...int) int {
+	for _, id := range ids {
+		mask &^= 1 << id
+	}
+
+	return mask
+}
+
+// Count returns the number of bits set in the mask (its population count),
+// without decoding it into a slice. It compiles to a single POPCNT instruction
+// on CPUs that have one.
+//
+// Example:
+//
+//	Count(42) => 3 (binary: 00101010)
+func Count(mask int) int {
+	return bits.OnesCount(uint(mask))
+}
+
+// IsEmpty reports whether no bits are set in the mask.
+func IsEmpty(mask int) bool {
+	return mask == 0
+}
+
+// Equal reports whether two masks have exactly the same bits set.
+func Equal(a, b int) bool {
+	return a == b
+}
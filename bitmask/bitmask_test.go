@@ -1,6 +1,7 @@
 package bitmask
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -170,3 +171,173 @@ func TestToggleBit(t *testing.T) {
 		})
 	}
 }
+
+func TestSetBit(t *testing.T) {
+	tests := []struct {
+		name         string
+		existingMask int
+		id           int
+		expectedMask int
+	}{
+		{
+			name:         "set",
+			existingMask: 34,
+			id:           3,
+			expectedMask: 42,
+		},
+		{
+			name:         "already set",
+			existingMask: 42,
+			id:           3,
+			expectedMask: 42,
+		},
+		{
+			name:         "set high bit",
+			existingMask: 0,
+			id:           31,
+			expectedMask: 1 << 31,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedMask, SetBit(tt.existingMask, tt.id), tt.name)
+		})
+	}
+}
+
+func TestClearBit(t *testing.T) {
+	tests := []struct {
+		name         string
+		existingMask int
+		id           int
+		expectedMask int
+	}{
+		{
+			name:         "clear",
+			existingMask: 42,
+			id:           3,
+			expectedMask: 34,
+		},
+		{
+			name:         "already clear",
+			existingMask: 34,
+			id:           3,
+			expectedMask: 34,
+		},
+		{
+			name:         "clear high bit",
+			existingMask: 1<<31 | 1,
+			id:           31,
+			expectedMask: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedMask, ClearBit(tt.existingMask, tt.id), tt.name)
+		})
+	}
+}
+
+func TestSetBits(t *testing.T) {
+	tests := []struct {
+		name         string
+		existingMask int
+		ids          []int
+		expectedMask int
+	}{
+		{
+			name:         "none",
+			existingMask: 8,
+			ids:          nil,
+			expectedMask: 8,
+		},
+		{
+			name:         "several",
+			existingMask: 0,
+			ids:          []int{1, 3, 5},
+			expectedMask: 42,
+		},
+		{
+			name:         "overlapping",
+			existingMask: 8,
+			ids:          []int{3, 5, 5},
+			expectedMask: 40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedMask, SetBits(tt.existingMask, tt.ids...), tt.name)
+		})
+	}
+}
+
+func TestClearBits(t *testing.T) {
+	tests := []struct {
+		name         string
+		existingMask int
+		ids          []int
+		expectedMask int
+	}{
+		{
+			name:         "none",
+			existingMask: 42,
+			ids:          nil,
+			expectedMask: 42,
+		},
+		{
+			name:         "several",
+			existingMask: 42,
+			ids:          []int{1, 5},
+			expectedMask: 8,
+		},
+		{
+			name:         "already clear",
+			existingMask: 42,
+			ids:          []int{0, 2, 3},
+			expectedMask: 34,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedMask, ClearBits(tt.existingMask, tt.ids...), tt.name)
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		expected int
+	}{
+		{
+			name:     "zero mask",
+			mask:     0,
+			expected: 0,
+		},
+		{
+			name:     "multiple bits",
+			mask:     42,
+			expected: 3,
+		},
+		{
+			name:     "all bits",
+			mask:     -1,
+			expected: strconv.IntSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Count(tt.mask), tt.name)
+		})
+	}
+}
+
+func TestIsEmptyAndEqual(t *testing.T) {
+	assert.True(t, IsEmpty(0))
+	assert.False(t, IsEmpty(1))
+	assert.False(t, IsEmpty(-1))
+
+	assert.True(t, Equal(42, Encode([]int{1, 3, 5})))
+	assert.False(t, Equal(42, 43))
+}
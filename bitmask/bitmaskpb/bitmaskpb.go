@@ -0,0 +1,239 @@
+// Package bitmaskpb converts bitmask.Mask and bitmask.BitSet to and from the
+// Bits protocol buffer message defined in bitmask.proto, so masks can be part of
+// gRPC APIs.
+//
+// Bits is written by hand rather than generated, to keep the protobuf runtime
+// out of goutils. Marshal and Unmarshal produce and accept the standard wire
+// format, so the bytes interoperate with code generated from bitmask.proto in
+// any language. To nest Bits in a message of your own, declare the field as
+// bytes, or import bitmask.proto and convert through Marshal and Unmarshal.
+package bitmaskpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/vk4s/goutils/bitmask"
+)
+
+// ErrInvalid is returned (wrapped) when a message can't be decoded or doesn't
+// fit the requested type.
+var ErrInvalid = errors.New("invalid Bits message")
+
+// Field numbers and wire types from bitmask.proto.
+const (
+	fieldWords  = 1
+	fieldLength = 2
+
+	wireVarint = 0
+	wireI64    = 1
+	wireLen    = 2
+	wireI32    = 5
+)
+
+// Bits mirrors the Bits message: bit n is bit n%64 of Words[n/64], and every set
+// bit is below Length.
+type Bits struct {
+	Words  []uint64
+	Length uint32
+}
+
+// FromMask returns the message for m. A negative mask uses all 64 bits.
+//
+// Example:
+//
+//	FromMask(42) => &Bits{Words: []uint64{42}, Length: 6}
+func FromMask(m bitmask.Mask) *Bits {
+	if m == 0 {
+		return &Bits{}
+	}
+
+	w := uint64(uint(m))
+	return &Bits{Words: []uint64{w}, Length: uint32(bits.Len64(w))}
+}
+
+// ToMask returns the mask held by b. It returns an error wrapping ErrInvalid if
+// b is inconsistent or has bits too high for an int.
+func ToMask(b *Bits) (bitmask.Mask, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	if b.Length > bits.UintSize {
+		return 0, fmt.Errorf("bitmaskpb: %w: %d bits don't fit in a Mask", ErrInvalid, b.Length)
+	}
+	if len(b.Words) == 0 {
+		return 0, nil
+	}
+
+	return bitmask.Mask(uint(b.Words[0])), nil
+}
+
+// FromBitSet returns the message for s, without trailing empty words.
+func FromBitSet(s *bitmask.BitSet) *Bits {
+	n := s.Len()
+	b := &Bits{Words: make([]uint64, (n+63)/64), Length: uint32(n)}
+	for id := range s.Bits() {
+		b.Words[id/64] |= 1 << (id % 64)
+	}
+
+	return b
+}
+
+// ToBitSet returns a new BitSet holding the bits of b. It returns an error
+// wrapping ErrInvalid if b is inconsistent.
+func ToBitSet(b *Bits) (*bitmask.BitSet, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Length is untrusted, so size the set from the words actually present.
+	s := bitmask.NewBitSet(min(int(b.Length), 64*len(b.Words)))
+	for i, w := range b.Words {
+		for ; w != 0; w &= w - 1 {
+			s.Set(i*64 + bits.TrailingZeros64(w))
+		}
+	}
+
+	return s, nil
+}
+
+// Validate reports an error wrapping ErrInvalid if a bit is set at or above
+// Length.
+func (b *Bits) Validate() error {
+	n := 0
+	for i := len(b.Words) - 1; i >= 0; i-- {
+		if b.Words[i] != 0 {
+			n = i*64 + bits.Len64(b.Words[i])
+			break
+		}
+	}
+	if uint64(n) > uint64(b.Length) {
+		return fmt.Errorf("bitmaskpb: %w: bit %d set but length is %d", ErrInvalid, n-1, b.Length)
+	}
+
+	return nil
+}
+
+// Marshal encodes b in the protocol buffer wire format, with Words packed as
+// proto3 does by default. Fields with zero values are left out.
+func (b *Bits) Marshal() ([]byte, error) {
+	var out []byte
+	if len(b.Words) > 0 {
+		size := 0
+		for _, w := range b.Words {
+			size += varintLen(w)
+		}
+		out = binary.AppendUvarint(out, fieldWords<<3|wireLen)
+		out = binary.AppendUvarint(out, uint64(size))
+		for _, w := range b.Words {
+			out = binary.AppendUvarint(out, w)
+		}
+	}
+	if b.Length != 0 {
+		out = binary.AppendUvarint(out, fieldLength<<3|wireVarint)
+		out = binary.AppendUvarint(out, uint64(b.Length))
+	}
+
+	return out, nil
+}
+
+// Unmarshal decodes the protocol buffer wire format into b, replacing its
+// contents. Like generated code, it accepts Words packed or unpacked, lets a
+// repeated Length take the last value, and skips unknown fields.
+func (b *Bits) Unmarshal(data []byte) error {
+	var out Bits
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("bitmaskpb: %w: bad field tag", ErrInvalid)
+		}
+		data = data[n:]
+		field, wire := tag>>3, tag&7
+
+		var err error
+		switch {
+		case field == fieldWords && wire == wireLen:
+			var packed []byte
+			if packed, data, err = readBytes(data); err != nil {
+				return err
+			}
+			for len(packed) > 0 {
+				var w uint64
+				if w, packed, err = readVarint(packed); err != nil {
+					return err
+				}
+				out.Words = append(out.Words, w)
+			}
+		case field == fieldWords && wire == wireVarint:
+			var w uint64
+			if w, data, err = readVarint(data); err != nil {
+				return err
+			}
+			out.Words = append(out.Words, w)
+		case field == fieldLength && wire == wireVarint:
+			var v uint64
+			if v, data, err = readVarint(data); err != nil {
+				return err
+			}
+			out.Length = uint32(v)
+		default:
+			if data, err = skipField(data, wire); err != nil {
+				return err
+			}
+		}
+	}
+	*b = out
+
+	return nil
+}
+
+func readVarint(data []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("bitmaskpb: %w: bad varint", ErrInvalid)
+	}
+
+	return v, data[n:], nil
+}
+
+func readBytes(data []byte) ([]byte, []byte, error) {
+	size, data, err := readVarint(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if size > uint64(len(data)) || size > math.MaxInt {
+		return nil, nil, fmt.Errorf("bitmaskpb: %w: field runs past the end", ErrInvalid)
+	}
+
+	return data[:size], data[size:], nil
+}
+
+func skipField(data []byte, wire uint64) ([]byte, error) {
+	var err error
+	switch wire {
+	case wireVarint:
+		_, data, err = readVarint(data)
+	case wireLen:
+		_, data, err = readBytes(data)
+	case wireI64, wireI32:
+		size := 8
+		if wire == wireI32 {
+			size = 4
+		}
+		if len(data) < size {
+			return nil, fmt.Errorf("bitmaskpb: %w: field runs past the end", ErrInvalid)
+		}
+		data = data[size:]
+	default:
+		return nil, fmt.Errorf("bitmaskpb: %w: unsupported wire type %d", ErrInvalid, wire)
+	}
+
+	return data, err
+}
+
+func varintLen(v uint64) int {
+	return (bits.Len64(v|1) + 6) / 7
+}
@@ -0,0 +1,170 @@
+package bitmaskpb
+
+import (
+	"math"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vk4s/goutils/bitmask"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     bitmask.Mask
+		expected *Bits
+	}{
+		{
+			name:     "empty",
+			mask:     0,
+			expected: &Bits{},
+		},
+		{
+			name:     "small",
+			mask:     42,
+			expected: &Bits{Words: []uint64{42}, Length: 6},
+		},
+		{
+			name:     "negative",
+			mask:     -1,
+			expected: &Bits{Words: []uint64{1<<64 - 1}, Length: 64},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := FromMask(tt.mask)
+			assert.Equal(t, tt.expected, b, tt.name)
+
+			m, err := ToMask(b)
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.mask, m, tt.name)
+		})
+	}
+
+	_, err := ToMask(&Bits{Words: []uint64{0, 1}, Length: 65})
+	assert.EqualError(t, err, "bitmaskpb: invalid Bits message: 65 bits don't fit in a Mask")
+	_, err = ToMask(&Bits{Words: []uint64{8}, Length: 2})
+	assert.EqualError(t, err, "bitmaskpb: invalid Bits message: bit 3 set but length is 2")
+}
+
+func TestBitSet(t *testing.T) {
+	var s bitmask.BitSet
+	for _, id := range []int{1, 70, 300} {
+		s.Set(id)
+	}
+
+	b := FromBitSet(&s)
+	assert.Equal(t, uint32(301), b.Length)
+	assert.Len(t, b.Words, 5)
+
+	got, err := ToBitSet(b)
+	assert.NoError(t, err)
+	assert.True(t, s.Equal(got))
+
+	empty, err := ToBitSet(&Bits{Words: []uint64{0, 0}})
+	assert.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+
+	_, err = ToBitSet(&Bits{Words: []uint64{0, 1}, Length: 64})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	huge, err := ToBitSet(&Bits{Length: math.MaxUint32})
+	runtime.ReadMemStats(&after)
+	assert.NoError(t, err)
+	assert.True(t, huge.IsEmpty())
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20), "allocation sized by Length")
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		bits     *Bits
+		expected []byte
+	}{
+		{
+			name:     "empty",
+			bits:     &Bits{},
+			expected: nil,
+		},
+		{
+			name:     "small",
+			bits:     &Bits{Words: []uint64{42}, Length: 6},
+			expected: []byte{0x0a, 0x01, 0x2a, 0x10, 0x06},
+		},
+		{
+			name:     "multi-byte varints",
+			bits:     &Bits{Words: []uint64{0, 300}, Length: 73},
+			expected: []byte{0x0a, 0x03, 0x00, 0xac, 0x02, 0x10, 0x49},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.bits.Marshal()
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, data, tt.name)
+
+			var got Bits
+			assert.NoError(t, got.Unmarshal(data), tt.name)
+			assert.Equal(t, tt.bits.Length, got.Length, tt.name)
+			assert.True(t, slices.Equal(tt.bits.Words, got.Words), tt.name)
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected Bits
+		err      string
+	}{
+		{
+			name:     "unpacked words",
+			data:     []byte{0x08, 0x05, 0x08, 0x07, 0x10, 0x43},
+			expected: Bits{Words: []uint64{5, 7}, Length: 67},
+		},
+		{
+			name:     "unknown fields are skipped",
+			data:     []byte{0x18, 0x01, 0x22, 0x02, 0xff, 0xff, 0x29, 1, 2, 3, 4, 5, 6, 7, 8, 0x35, 1, 2, 3, 4, 0x0a, 0x01, 0x03},
+			expected: Bits{Words: []uint64{3}},
+		},
+		{
+			name:     "last length wins",
+			data:     []byte{0x10, 0x01, 0x10, 0x02},
+			expected: Bits{Length: 2},
+		},
+		{
+			name: "truncated packed field",
+			data: []byte{0x0a, 0x05, 0x01},
+			err:  "bitmaskpb: invalid Bits message: field runs past the end",
+		},
+		{
+			name: "bad varint",
+			data: []byte{0x10, 0xff},
+			err:  "bitmaskpb: invalid Bits message: bad varint",
+		},
+		{
+			name: "group wire type",
+			data: []byte{0x1b},
+			err:  "bitmaskpb: invalid Bits message: unsupported wire type 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Bits{Words: []uint64{99}, Length: 99}
+			err := got.Unmarshal(tt.data)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.Equal(t, uint32(99), got.Length, "unchanged on error")
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, got, tt.name)
+		})
+	}
+}
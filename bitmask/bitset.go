@@ -0,0 +1,269 @@
+package bitmask
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// wordBits is the number of bits stored in each word of a BitSet.
+const wordBits = 64
+
+// BitSet is a set of non-negative integer IDs of any size, stored as bits in a
+// slice of 64-bit words that grows on demand. Use it instead of an int mask when
+// IDs can reach 64 or more.
+//
+// Bit n lives in word n/64 at position n%64:
+//
+//	id 3   → words[0], bit 3
+//	id 70  → words[1], bit 6
+//	id 130 → words[2], bit 2
+//
+// The zero value is an empty set ready to use. A BitSet is not safe for
+// concurrent use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet returns an empty BitSet with room for n bits before it has to grow.
+func NewBitSet(n int) *BitSet {
+	return &BitSet{words: make([]uint64, 0, (n+wordBits-1)/wordBits)}
+}
+
+// BitSetFromInt returns a BitSet holding the same bits as mask.
+//
+// Example:
+//
+//	BitSetFromInt(42) => {1, 3, 5}
+func BitSetFromInt(mask int) *BitSet {
+	b := &BitSet{}
+	if mask != 0 {
+		b.words = []uint64{uint64(uint(mask))}
+	}
+
+	return b
+}
+
+// Int returns the set as an int mask. ok is false if any bit at or above the
+// width of int is set, in which case mask holds only the low bits.
+//
+// Example:
+//
+//	b.Set(1); b.Set(3)
+//	b.Int() => 10, true
+//	b.Set(100)
+//	b.Int() => 10, false
+func (b *BitSet) Int() (mask int, ok bool) {
+	if len(b.words) == 0 {
+		return 0, true
+	}
+
+	low := b.words[0]
+	ok = low>>(bits.UintSize-1)>>1 == 0
+	for _, w := range b.words[1:] {
+		if w != 0 {
+			ok = false
+		}
+	}
+
+	return int(uint(low)), ok
+}
+
+// Set turns on bit id, growing the set if needed. It panics if id is negative.
+func (b *BitSet) Set(id int) {
+	i := wordIndex(id)
+	b.grow(i + 1)
+	b.words[i] |= 1 << (id % wordBits)
+}
+
+// Clear turns off bit id. Clearing a bit beyond the end of the set does nothing.
+func (b *BitSet) Clear(id int) {
+	i := wordIndex(id)
+	if i < len(b.words) {
+		b.words[i] &^= 1 << (id % wordBits)
+	}
+}
+
+// Test reports whether bit id is set.
+func (b *BitSet) Test(id int) bool {
+	i := wordIndex(id)
+	if i >= len(b.words) {
+		return false
+	}
+
+	return b.words[i]&(1<<(id%wordBits)) != 0
+}
+
+// Toggle flips bit id, growing the set if needed.
+func (b *BitSet) Toggle(id int) {
+	i := wordIndex(id)
+	b.grow(i + 1)
+	b.words[i] ^= 1 << (id % wordBits)
+}
+
+// Len returns the position of the highest set bit plus one, or 0 if the set is
+// empty. It is the smallest n such that every set bit is below n.
+//
+// Example:
+//
+//	{1, 3, 70}.Len() => 71
+func (b *BitSet) Len() int {
+	for i := len(b.words) - 1; i >= 0; i-- {
+		if w := b.words[i]; w != 0 {
+			return i*wordBits + bits.Len64(w)
+		}
+	}
+
+	return 0
+}
+
+// Bits returns an iterator over the set bits in ascending order.
+// The set must not be modified during iteration.
+//
+// Example:
+//
+//	for id := range b.Bits() {
+//		fmt.Println(id)
+//	}
+func (b *BitSet) Bits() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for ; w != 0; w &= w - 1 {
+				if !yield(i*wordBits + bits.TrailingZeros64(w)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Count returns the number of set bits.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+// IsEmpty reports whether no bits are set.
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether b and o have exactly the same bits set, regardless of
+// how much space either has allocated.
+func (b *BitSet) Equal(o *BitSet) bool {
+	long, short := b.words, o.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	for i, w := range long {
+		if i < len(short) {
+			if w != short[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a copy of b.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// Union returns a new set with the bits set in b or o.
+func (b *BitSet) Union(o *BitSet) *BitSet {
+	long, short := b.words, o.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+
+	out := &BitSet{words: append([]uint64(nil), long...)}
+	for i, w := range short {
+		out.words[i] |= w
+	}
+
+	return out
+}
+
+// Intersect returns a new set with the bits set in both b and o.
+func (b *BitSet) Intersect(o *BitSet) *BitSet {
+	out := &BitSet{words: make([]uint64, min(len(b.words), len(o.words)))}
+	for i := range out.words {
+		out.words[i] = b.words[i] & o.words[i]
+	}
+
+	return out.trim()
+}
+
+// Difference returns a new set with the bits of b that are not set in o.
+func (b *BitSet) Difference(o *BitSet) *BitSet {
+	out := b.Clone()
+	for i := range min(len(out.words), len(o.words)) {
+		out.words[i] &^= o.words[i]
+	}
+
+	return out.trim()
+}
+
+// SymmetricDifference returns a new set with the bits set in exactly one of b and o.
+func (b *BitSet) SymmetricDifference(o *BitSet) *BitSet {
+	long, short := b.words, o.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+
+	out := &BitSet{words: append([]uint64(nil), long...)}
+	for i, w := range short {
+		out.words[i] ^= w
+	}
+
+	return out.trim()
+}
+
+// trim drops trailing zero words so that results don't hold on to empty space.
+func (b *BitSet) trim() *BitSet {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+
+	return b
+}
+
+// grow makes sure the set has at least n words.
+func (b *BitSet) grow(n int) {
+	if n <= len(b.words) {
+		return
+	}
+	if n <= cap(b.words) {
+		old := len(b.words)
+		b.words = b.words[:n]
+		clear(b.words[old:])
+		return
+	}
+
+	words := make([]uint64, n, max(n, 2*cap(b.words)))
+	copy(words, b.words)
+	b.words = words
+}
+
+func wordIndex(id int) int {
+	if id < 0 {
+		panic("bitmask: negative bit index")
+	}
+
+	return id / wordBits
+}
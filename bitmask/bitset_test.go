@@ -0,0 +1,229 @@
+package bitmask
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSet(t *testing.T) {
+	var b BitSet
+	assert.Equal(t, 0, b.Len())
+	assert.False(t, b.Test(0))
+
+	for _, id := range []int{0, 3, 63, 64, 200} {
+		b.Set(id)
+	}
+	for _, id := range []int{0, 3, 63, 64, 200} {
+		assert.True(t, b.Test(id), "bit %d", id)
+	}
+	for _, id := range []int{1, 62, 65, 199, 201, 10000} {
+		assert.False(t, b.Test(id), "bit %d", id)
+	}
+	assert.Equal(t, 201, b.Len())
+
+	b.Clear(200)
+	b.Clear(5000)
+	assert.False(t, b.Test(200))
+	assert.Equal(t, 65, b.Len())
+
+	b.Toggle(64)
+	b.Toggle(1)
+	b.Toggle(300)
+	assert.False(t, b.Test(64))
+	assert.True(t, b.Test(1))
+	assert.True(t, b.Test(300))
+	assert.Equal(t, 301, b.Len())
+}
+
+func TestBitSetNegativePanics(t *testing.T) {
+	var b BitSet
+	assert.PanicsWithValue(t, "bitmask: negative bit index", func() { b.Set(-1) })
+	assert.PanicsWithValue(t, "bitmask: negative bit index", func() { b.Test(-1) })
+}
+
+func TestBitSetInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		expected int
+		ok       bool
+	}{
+		{
+			name:     "empty",
+			ids:      nil,
+			expected: 0,
+			ok:       true,
+		},
+		{
+			name:     "small",
+			ids:      []int{1, 3, 5},
+			expected: 42,
+			ok:       true,
+		},
+		{
+			name:     "too wide",
+			ids:      []int{1, 100},
+			expected: 2,
+			ok:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBitSet(128)
+			for _, id := range tt.ids {
+				b.Set(id)
+			}
+			mask, ok := b.Int()
+			assert.Equal(t, tt.expected, mask, tt.name)
+			assert.Equal(t, tt.ok, ok, tt.name)
+		})
+	}
+}
+
+func TestBitSetFromInt(t *testing.T) {
+	b := BitSetFromInt(42)
+	assert.True(t, b.Test(1))
+	assert.True(t, b.Test(3))
+	assert.True(t, b.Test(5))
+	assert.False(t, b.Test(2))
+	assert.Equal(t, 6, b.Len())
+
+	mask, ok := b.Int()
+	assert.Equal(t, 42, mask)
+	assert.True(t, ok)
+
+	assert.Equal(t, 0, BitSetFromInt(0).Len())
+
+	neg := BitSetFromInt(-1)
+	mask, ok = neg.Int()
+	assert.Equal(t, -1, mask, "sign bit round-trips")
+	assert.True(t, ok)
+}
+
+func TestBitSetGrowReusesCapacity(t *testing.T) {
+	b := NewBitSet(256)
+	b.Set(0)
+	b.Set(255)
+	assert.Equal(t, 4, len(b.words))
+	assert.Equal(t, 4, cap(b.words))
+}
+
+// setBits lists the set bits of b in ascending order.
+func setBits(b *BitSet) []int {
+	return append([]int{}, slices.Collect(b.Bits())...)
+}
+
+func bitSetOf(ids ...int) *BitSet {
+	var b BitSet
+	for _, id := range ids {
+		b.Set(id)
+	}
+	return &b
+}
+
+func TestBitSetSetOperations(t *testing.T) {
+	a := bitSetOf(1, 3, 64, 130)
+	b := bitSetOf(3, 64, 65)
+
+	tests := []struct {
+		name     string
+		result   *BitSet
+		expected []int
+	}{
+		{
+			name:     "union",
+			result:   a.Union(b),
+			expected: []int{1, 3, 64, 65, 130},
+		},
+		{
+			name:     "intersect",
+			result:   a.Intersect(b),
+			expected: []int{3, 64},
+		},
+		{
+			name:     "difference",
+			result:   a.Difference(b),
+			expected: []int{1, 130},
+		},
+		{
+			name:     "reverse difference",
+			result:   b.Difference(a),
+			expected: []int{65},
+		},
+		{
+			name:     "symmetric difference",
+			result:   a.SymmetricDifference(b),
+			expected: []int{1, 65, 130},
+		},
+		{
+			name:     "with empty",
+			result:   a.Intersect(&BitSet{}),
+			expected: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, setBits(tt.result), tt.name)
+		})
+	}
+
+	assert.Equal(t, []int{1, 3, 64, 130}, setBits(a), "operands are not modified")
+	assert.Equal(t, []int{3, 64, 65}, setBits(b), "operands are not modified")
+	assert.Len(t, bitSetOf(200).Difference(bitSetOf(200)).words, 0, "trailing empty words are trimmed")
+}
+
+func TestBitSetClone(t *testing.T) {
+	a := bitSetOf(1, 100)
+	c := a.Clone()
+	c.Set(5)
+	c.Clear(100)
+
+	assert.Equal(t, []int{1, 100}, setBits(a))
+	assert.Equal(t, []int{1, 5}, setBits(c))
+}
+
+func TestBitSetCount(t *testing.T) {
+	var empty BitSet
+	assert.Equal(t, 0, empty.Count())
+	assert.True(t, empty.IsEmpty())
+
+	b := bitSetOf(0, 63, 64, 500)
+	assert.Equal(t, 4, b.Count())
+	assert.False(t, b.IsEmpty())
+
+	b.Clear(500)
+	b.Clear(0)
+	b.Clear(63)
+	b.Clear(64)
+	assert.True(t, b.IsEmpty(), "cleared words still allocated")
+}
+
+func TestBitSetEqual(t *testing.T) {
+	a := bitSetOf(1, 70)
+	b := bitSetOf(1, 70, 300)
+	assert.False(t, a.Equal(b))
+	assert.False(t, b.Equal(a))
+
+	b.Clear(300)
+	assert.True(t, a.Equal(b), "trailing zero words are ignored")
+	assert.True(t, b.Equal(a))
+	assert.True(t, (&BitSet{}).Equal(NewBitSet(1000)))
+	assert.False(t, bitSetOf(2).Equal(bitSetOf(3)))
+}
+
+func TestBitSetBits(t *testing.T) {
+	b := bitSetOf(0, 5, 63, 64, 127, 1000)
+	assert.Equal(t, []int{0, 5, 63, 64, 127, 1000}, slices.Collect(b.Bits()))
+	assert.Empty(t, slices.Collect((&BitSet{}).Bits()))
+
+	var first []int
+	for id := range b.Bits() {
+		if id > 63 {
+			break
+		}
+		first = append(first, id)
+	}
+	assert.Equal(t, []int{0, 5, 63}, first)
+}
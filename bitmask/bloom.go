@@ -0,0 +1,174 @@
+package bitmask
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// Bloom is a Bloom filter: a set of items that answers "definitely not added"
+// or "probably added" using a fixed amount of memory, no matter how large the
+// items are. It stores m bits in a BitSet and sets k of them for each item.
+//
+// The k bit positions come from a single 64-bit FNV-1a hash split into two
+// 32-bit halves h1 and h2, combined as h1 + i*h2 for i in [0, k) (Kirsch and
+// Mitzenmacher's double hashing), so adding an item hashes it only once. FNV is
+// not randomized, so a filter serialized by one process can be read by another.
+//
+// Example:
+//
+//	seen := bitmask.NewBloom(1_000_000, 0.01) // ~1.2 MB, 7 hashes
+//	seen.AddString("alice@example.com")
+//	seen.TestString("alice@example.com") => true
+//	seen.TestString("bob@example.com")   => false (or true, 1% of the time)
+//
+// A Bloom is not safe for concurrent use.
+type Bloom struct {
+	bits *BitSet
+	m    uint64
+	k    int
+}
+
+// NewBloom returns an empty filter sized to hold n items with a false positive
+// rate of about fpRate once full. It uses the standard optimum:
+//
+//	m = -n·ln(fpRate) / ln(2)²   bits
+//	k = m/n · ln(2)              hash functions
+//
+// It panics unless fpRate is strictly between 0 and 1. An n below 1 is treated
+// as 1.
+func NewBloom(n int, fpRate float64) *Bloom {
+	if !(fpRate > 0 && fpRate < 1) {
+		panic("bitmask: Bloom false positive rate must be in (0, 1)")
+	}
+	n = max(n, 1)
+
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+
+	return newBloom(max(m, 1), max(k, 1))
+}
+
+func newBloom(m uint64, k int) *Bloom {
+	return &Bloom{bits: NewBitSet(int(m)), m: m, k: k}
+}
+
+// M returns the number of bits in the filter.
+func (f *Bloom) M() int {
+	return int(f.m)
+}
+
+// K returns the number of bits set per item.
+func (f *Bloom) K() int {
+	return f.k
+}
+
+// Add adds data to the filter.
+func (f *Bloom) Add(data []byte) {
+	h1, h2 := bloomHash(data)
+	for i := range f.k {
+		f.bits.Set(f.index(h1, h2, i))
+	}
+}
+
+// AddString adds s to the filter.
+func (f *Bloom) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// Test reports whether data may have been added. False means it definitely
+// wasn't; true is wrong at about the rate the filter was sized for.
+func (f *Bloom) Test(data []byte) bool {
+	h1, h2 := bloomHash(data)
+	for i := range f.k {
+		if !f.bits.Test(f.index(h1, h2, i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestString reports whether s may have been added.
+func (f *Bloom) TestString(s string) bool {
+	return f.Test([]byte(s))
+}
+
+// EstimateCount estimates how many distinct items have been added from the
+// fraction of bits set (Swamidass and Baldi):
+//
+//	n ≈ -m/k · ln(1 - set/m)
+//
+// It is accurate while the filter is below its designed capacity and grows
+// without bound as the filter saturates.
+func (f *Bloom) EstimateCount() int {
+	set := float64(f.bits.Count())
+	m := float64(f.m)
+	if set >= m {
+		return math.MaxInt
+	}
+
+	return int(math.Round(-m / float64(f.k) * math.Log(1-set/m)))
+}
+
+// index returns the bit for hash function i.
+func (f *Bloom) index(h1, h2 uint64, i int) int {
+	return int((h1 + uint64(i)*h2) % f.m)
+}
+
+func bloomHash(data []byte) (h1, h2 uint64) {
+	h := fnv.New64a()
+	h.Write(data)
+	sum := h.Sum64()
+
+	// Force h2 odd so that it is never 0, which would map every hash to one bit.
+	return sum & math.MaxUint32, sum>>32 | 1
+}
+
+// MarshalBinary encodes the filter as a version byte, m and k as uvarints, and
+// the bits in the BitSet binary format (see BitSet.MarshalBinary):
+//
+//	[0x01][uvarint m][uvarint k][BitSet encoding]
+//
+// The last part can be decoded on its own with BitSet.UnmarshalBinary.
+func (f *Bloom) MarshalBinary() ([]byte, error) {
+	bits, err := f.bits.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	out := []byte{binaryVersion}
+	out = binary.AppendUvarint(out, f.m)
+	out = binary.AppendUvarint(out, uint64(f.k))
+	return append(out, bits...), nil
+}
+
+// UnmarshalBinary decodes the format written by MarshalBinary, replacing f.
+func (f *Bloom) UnmarshalBinary(data []byte) error {
+	payload, err := checkVersion(data)
+	if err != nil {
+		return err
+	}
+
+	m, n := binary.Uvarint(payload)
+	if n <= 0 || m == 0 || m > math.MaxInt {
+		return fmt.Errorf("bitmask: %w: bad Bloom filter size", ErrInvalidEncoding)
+	}
+	payload = payload[n:]
+	k, n := binary.Uvarint(payload)
+	if n <= 0 || k == 0 || k > math.MaxInt32 {
+		return fmt.Errorf("bitmask: %w: bad Bloom filter hash count", ErrInvalidEncoding)
+	}
+
+	var bits BitSet
+	if err := bits.UnmarshalBinary(payload[n:]); err != nil {
+		return err
+	}
+	if uint64(bits.Len()) > m {
+		return fmt.Errorf("bitmask: %w: Bloom filter has bits beyond its size %d", ErrInvalidEncoding, m)
+	}
+
+	*f = Bloom{bits: &bits, m: m, k: int(k)}
+	return nil
+}
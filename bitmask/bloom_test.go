@@ -0,0 +1,146 @@
+package bitmask
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBloom(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		fpRate float64
+		m      int
+		k      int
+	}{
+		{
+			name:   "1% for a million",
+			n:      1_000_000,
+			fpRate: 0.01,
+			m:      9_585_059,
+			k:      7,
+		},
+		{
+			name:   "0.1% for a thousand",
+			n:      1000,
+			fpRate: 0.001,
+			m:      14_378,
+			k:      10,
+		},
+		{
+			name:   "zero items",
+			n:      0,
+			fpRate: 0.5,
+			m:      2,
+			k:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewBloom(tt.n, tt.fpRate)
+			assert.Equal(t, tt.m, f.M(), tt.name)
+			assert.Equal(t, tt.k, f.K(), tt.name)
+		})
+	}
+
+	assert.PanicsWithValue(t, "bitmask: Bloom false positive rate must be in (0, 1)", func() { NewBloom(10, 0) })
+	assert.PanicsWithValue(t, "bitmask: Bloom false positive rate must be in (0, 1)", func() { NewBloom(10, 1) })
+}
+
+func TestBloom(t *testing.T) {
+	const n = 10_000
+	f := NewBloom(n, 0.01)
+	for i := range n {
+		f.AddString(fmt.Sprintf("item-%d", i))
+	}
+
+	for i := range n {
+		assert.True(t, f.TestString(fmt.Sprintf("item-%d", i)), "no false negatives")
+	}
+
+	falsePositives := 0
+	for i := range n {
+		if f.Test([]byte(fmt.Sprintf("other-%d", i))) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, n*2/100, "false positive rate near 1%%")
+
+	assert.InDelta(t, n, f.EstimateCount(), n*0.05)
+	assert.Equal(t, 0, NewBloom(10, 0.01).EstimateCount())
+}
+
+func TestBloomBinary(t *testing.T) {
+	f := NewBloom(100, 0.01)
+	f.AddString("alice")
+	f.AddString("bob")
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got Bloom
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, f.M(), got.M())
+	assert.Equal(t, f.K(), got.K())
+	assert.True(t, got.TestString("alice"))
+	assert.True(t, got.TestString("bob"))
+	assert.False(t, got.TestString("carol"))
+
+	// m=959 and k=7 take 2 and 1 bytes; the rest is a BitSet encoding.
+	var bits BitSet
+	assert.NoError(t, bits.UnmarshalBinary(data[4:]))
+	assert.True(t, f.bits.Equal(&bits))
+
+	got.AddString("carol")
+	assert.True(t, got.TestString("carol"), "decoded filter accepts new items")
+}
+
+func TestBloomUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "empty",
+			data:     nil,
+			expected: "bitmask: invalid encoding: empty input",
+		},
+		{
+			name:     "zero size",
+			data:     []byte{1, 0, 1, 1, 0},
+			expected: "bitmask: invalid encoding: bad Bloom filter size",
+		},
+		{
+			name:     "zero hashes",
+			data:     []byte{1, 8, 0, 1, 0},
+			expected: "bitmask: invalid encoding: bad Bloom filter hash count",
+		},
+		{
+			name:     "bad bits",
+			data:     []byte{1, 8, 1, 2},
+			expected: "bitmask: invalid encoding: unsupported format version 2",
+		},
+		{
+			name:     "bits beyond size",
+			data:     []byte{1, 8, 1, 1, 1, 0xff, 1, 0, 0, 0, 0, 0, 0},
+			expected: "bitmask: invalid encoding: Bloom filter has bits beyond its size 8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f Bloom
+			assert.EqualError(t, f.UnmarshalBinary(tt.data), tt.expected, tt.name)
+		})
+	}
+}
+
+func BenchmarkBloomAdd(b *testing.B) {
+	f := NewBloom(b.N, 0.01)
+	data := []byte("benchmark-item")
+	for i := 0; i < b.N; i++ {
+		f.Add(data)
+	}
+}
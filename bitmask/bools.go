@@ -0,0 +1,62 @@
+package bitmask
+
+// FromBools returns a mask with bit i set for every true bools[i], such as the
+// state of a row of checkboxes. Entries at or beyond the width of int are
+// ignored, as in Encode.
+//
+// Example:
+//
+//	FromBools([]bool{false, true, false, true}) => 10 (binary: 1010)
+func FromBools(bools []bool) int {
+	var mask int
+	for i, b := range bools {
+		if b {
+			mask |= 1 << i
+		}
+	}
+
+	return mask
+}
+
+// ToBools returns width booleans, one per bit of mask from bit 0 up. Bits at or
+// above width are dropped; a width beyond the size of int pads with false.
+// It panics if width is negative.
+//
+// Example:
+//
+//	ToBools(10, 5) => [false, true, false, true, false]
+func ToBools(mask int, width int) []bool {
+	bools := make([]bool, width)
+	for i := range bools {
+		bools[i] = HasBit(mask, i)
+	}
+
+	return bools
+}
+
+// BitSetFromBools returns a BitSet with bit i set for every true bools[i].
+func BitSetFromBools(bools []bool) *BitSet {
+	b := NewBitSet(len(bools))
+	for i, v := range bools {
+		if v {
+			b.Set(i)
+		}
+	}
+
+	return b
+}
+
+// ToBools returns width booleans, one per bit of b from bit 0 up. Bits at or
+// above width are dropped and positions past Len are false. It panics if width
+// is negative.
+func (b *BitSet) ToBools(width int) []bool {
+	bools := make([]bool, width)
+	for id := range b.Bits() {
+		if id >= width {
+			break
+		}
+		bools[id] = true
+	}
+
+	return bools
+}
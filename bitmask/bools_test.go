@@ -0,0 +1,61 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBools(t *testing.T) {
+	tests := []struct {
+		name  string
+		bools []bool
+		mask  int
+	}{
+		{
+			name:  "empty",
+			bools: []bool{},
+			mask:  0,
+		},
+		{
+			name:  "checkboxes",
+			bools: []bool{false, true, false, true},
+			mask:  10,
+		},
+		{
+			name:  "all false",
+			bools: []bool{false, false, false},
+			mask:  0,
+		},
+		{
+			name:  "all true",
+			bools: []bool{true, true, true},
+			mask:  7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.mask, FromBools(tt.bools), tt.name)
+			assert.Equal(t, tt.bools, ToBools(tt.mask, len(tt.bools)), tt.name)
+
+			b := BitSetFromBools(tt.bools)
+			mask, _ := b.Int()
+			assert.Equal(t, tt.mask, mask, tt.name)
+			assert.Equal(t, tt.bools, b.ToBools(len(tt.bools)), tt.name)
+		})
+	}
+
+	assert.Equal(t, []bool{false, true}, ToBools(10, 2), "high bits dropped")
+	assert.Equal(t, 1, FromBools(append([]bool{true}, make([]bool, 100)...)))
+	assert.Len(t, ToBools(-1, 70), 70)
+	assert.False(t, ToBools(-1, 70)[69], "padded with false")
+	assert.Panics(t, func() { ToBools(1, -1) })
+
+	b := bitSetOf(2, 100, 200)
+	bools := b.ToBools(150)
+	assert.Len(t, bools, 150)
+	assert.True(t, bools[2])
+	assert.True(t, bools[100])
+	assert.Equal(t, []int{2, 100}, setBits(BitSetFromBools(bools)))
+	assert.Equal(t, []int{2, 100, 200}, setBits(BitSetFromBools(b.ToBools(300))))
+}
@@ -0,0 +1,78 @@
+package bitmask
+
+// Builder constructs a mask step by step with range checking, for
+// configuration code where a chain of named steps reads better than bit
+// arithmetic.
+//
+// Steps apply in order, so a later Without can remove IDs an earlier With or
+// WithRange added. The first out-of-range ID is remembered and returned by
+// Build; steps after it are ignored.
+//
+// Example:
+//
+//	mask, err := bitmask.NewBuilder().
+//		WithRange(0, 8).   // IDs 0-7
+//		Without(3).        // except 3
+//		With(12).
+//		Build()            // => 0b1_0000_1111_0111, nil
+type Builder struct {
+	cfg  strictConfig
+	mask int
+	err  error
+}
+
+// NewBuilder returns a Builder for an empty mask. IDs are checked against
+// MaxBit, or the limit set with WithMaxBit.
+func NewBuilder(opts ...StrictOption) *Builder {
+	return &Builder{cfg: newStrictConfig(opts)}
+}
+
+// With turns on the given IDs.
+func (b *Builder) With(ids ...int) *Builder {
+	for _, id := range ids {
+		if b.check(id) {
+			b.mask |= 1 << id
+		}
+	}
+
+	return b
+}
+
+// Without turns off the given IDs.
+func (b *Builder) Without(ids ...int) *Builder {
+	for _, id := range ids {
+		if b.check(id) {
+			b.mask &^= 1 << id
+		}
+	}
+
+	return b
+}
+
+// WithRange turns on IDs lo through hi-1. An empty range adds nothing.
+func (b *Builder) WithRange(lo, hi int) *Builder {
+	if lo < hi && b.check(lo) && b.check(hi-1) {
+		b.mask = SetRange(b.mask, lo, hi)
+	}
+
+	return b
+}
+
+// Build returns the mask, or the first error from the steps.
+func (b *Builder) Build() (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	return b.mask, nil
+}
+
+// check records an error for id unless one was already recorded, and reports
+// whether the step may go ahead.
+func (b *Builder) check(id int) bool {
+	if b.err == nil {
+		b.err = b.cfg.check(id)
+	}
+
+	return b.err == nil
+}
@@ -0,0 +1,80 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *Builder
+		expected int
+		err      string
+	}{
+		{
+			name:     "empty",
+			builder:  NewBuilder(),
+			expected: 0,
+		},
+		{
+			name:     "with",
+			builder:  NewBuilder().With(1, 3).With(5),
+			expected: 42,
+		},
+		{
+			name:     "steps apply in order",
+			builder:  NewBuilder().WithRange(0, 8).Without(3).With(12),
+			expected: 0b1_0000_1111_0111,
+		},
+		{
+			name:     "with after without",
+			builder:  NewBuilder().Without(2).With(2),
+			expected: 4,
+		},
+		{
+			name:     "empty range",
+			builder:  NewBuilder().WithRange(5, 5).WithRange(9, 2),
+			expected: 0,
+		},
+		{
+			name:     "range up to MaxBit",
+			builder:  NewBuilder().WithRange(MaxBit, MaxBit+1),
+			expected: 1 << MaxBit,
+		},
+		{
+			name:    "out of range",
+			builder: NewBuilder().With(1, 70, -1),
+			err:     "bitmask: ID 70 out of range [0, 62]",
+		},
+		{
+			name:    "negative without",
+			builder: NewBuilder().With(1).Without(-2),
+			err:     "bitmask: ID -2 out of range [0, 62]",
+		},
+		{
+			name:    "range past max",
+			builder: NewBuilder(WithMaxBit(15)).WithRange(8, 20),
+			err:     "bitmask: ID 19 out of range [0, 15]",
+		},
+		{
+			name:    "steps after an error are ignored",
+			builder: NewBuilder(WithMaxBit(7)).With(9).With(1).Without(100),
+			err:     "bitmask: ID 9 out of range [0, 7]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mask, err := tt.builder.Build()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.ErrorIs(t, err, ErrOutOfRange, tt.name)
+				assert.Equal(t, 0, mask, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, mask, tt.name)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskHexBase64(t *testing.T) {
+	tests := []struct {
+		name   string
+		mask   Mask
+		hex    string
+		base64 string
+	}{
+		{
+			name:   "empty",
+			mask:   0,
+			hex:    "",
+			base64: "",
+		},
+		{
+			name:   "one byte",
+			mask:   42,
+			hex:    "2a",
+			base64: "Kg",
+		},
+		{
+			name:   "little-endian",
+			mask:   0x012a,
+			hex:    "2a01",
+			base64: "KgE",
+		},
+		{
+			name:   "gap",
+			mask:   1 << 24,
+			hex:    "00000001",
+			base64: "AAAAAQ",
+		},
+		{
+			name:   "negative",
+			mask:   -1,
+			hex:    "ffffffffffffffff",
+			base64: "__________8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.hex, tt.mask.ToHex(), tt.name)
+			assert.Equal(t, tt.base64, tt.mask.ToBase64(), tt.name)
+
+			var fromHex, fromBase64 Mask
+			assert.NoError(t, fromHex.FromHex(tt.hex), tt.name)
+			assert.NoError(t, fromBase64.FromBase64(tt.base64), tt.name)
+			assert.Equal(t, tt.mask, fromHex, tt.name)
+			assert.Equal(t, tt.mask, fromBase64, tt.name)
+		})
+	}
+}
+
+func TestMaskHexBase64Lenient(t *testing.T) {
+	var m Mask
+	assert.NoError(t, m.FromHex("2A0100"))
+	assert.Equal(t, Mask(0x012a), m, "upper case and trailing zero bytes")
+	assert.NoError(t, m.FromBase64("Kg=="))
+	assert.Equal(t, Mask(42), m, "padding")
+}
+
+func TestMaskHexBase64Errors(t *testing.T) {
+	m := Mask(7)
+	assert.ErrorIs(t, m.FromHex("2x"), ErrInvalidEncoding)
+	assert.ErrorIs(t, m.FromHex("abc"), ErrInvalidEncoding)
+	assert.ErrorIs(t, m.FromBase64("K+g"), ErrInvalidEncoding)
+	assert.EqualError(t, m.FromHex("000000000000000001"), "bitmask: invalid encoding: 9 bytes don't fit in a Mask")
+	assert.Equal(t, Mask(7), m, "unchanged on error")
+}
+
+func TestBitSetHexBase64(t *testing.T) {
+	tests := []struct {
+		name   string
+		set    *BitSet
+		hex    string
+		base64 string
+	}{
+		{
+			name:   "empty",
+			set:    &BitSet{},
+			hex:    "",
+			base64: "",
+		},
+		{
+			name:   "same as Mask",
+			set:    bitSetOf(1, 3, 5, 8),
+			hex:    "2a01",
+			base64: "KgE",
+		},
+		{
+			name:   "wide",
+			set:    bitSetOf(0, 64, 129),
+			hex:    "0100000000000000" + "0100000000000000" + "02",
+			base64: "AQAAAAAAAAABAAAAAAAAAAI",
+		},
+		{
+			name:   "trailing empty words",
+			set:    &BitSet{words: []uint64{1, 0, 0}},
+			hex:    "01",
+			base64: "AQ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.hex, tt.set.ToHex(), tt.name)
+			assert.Equal(t, tt.base64, tt.set.ToBase64(), tt.name)
+
+			fromHex, fromBase64 := bitSetOf(500), bitSetOf(500)
+			assert.NoError(t, fromHex.FromHex(tt.hex), tt.name)
+			assert.NoError(t, fromBase64.FromBase64(tt.base64), tt.name)
+			assert.True(t, tt.set.Equal(fromHex), tt.name)
+			assert.True(t, tt.set.Equal(fromBase64), tt.name)
+		})
+	}
+
+	b := bitSetOf(3)
+	assert.ErrorIs(t, b.FromHex("zz"), ErrInvalidEncoding)
+	assert.ErrorIs(t, b.FromBase64("!"), ErrInvalidEncoding)
+	assert.Equal(t, []int{3}, setBits(b), "unchanged on error")
+}
@@ -0,0 +1,34 @@
+package bitmask
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Compare returns -1, 0, or +1 depending on whether mask a sorts before, equal
+// to, or after mask b. It has the signature slices.SortFunc expects.
+//
+// Masks are ordered first by the number of set bits, then by their value as an
+// unsigned integer, which is the same as comparing their highest differing bit.
+// This is a total order: masks compare equal only if they are equal. Smaller
+// sets come first, and a negative mask, with its sign bit set, sorts after
+// positive masks of the same size rather than before them.
+//
+// Example:
+//
+//	0b0000 < 0b0001 < 0b0100 < 0b0011 < 0b0101 < 0b0111
+//	(0 bits)  (1 bit)          (2 bits)           (3 bits)
+func Compare(a, b int) int {
+	if c := cmp.Compare(Count(a), Count(b)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(uint(a), uint(b))
+}
+
+// SortMasks sorts masks in place in the order defined by Compare, so that
+// collections of masks can be deduplicated with slices.Compact and printed in
+// a canonical order.
+func SortMasks(masks []int) {
+	slices.SortFunc(masks, Compare)
+}
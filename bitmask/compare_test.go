@@ -0,0 +1,66 @@
+package bitmask
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int
+		expected int
+	}{
+		{
+			name:     "equal",
+			a:        42,
+			b:        42,
+			expected: 0,
+		},
+		{
+			name:     "fewer bits first",
+			a:        0b1000,
+			b:        0b0011,
+			expected: -1,
+		},
+		{
+			name:     "more bits last",
+			a:        0b0111,
+			b:        0b1000_0000,
+			expected: 1,
+		},
+		{
+			name:     "same count by value",
+			a:        0b0011,
+			b:        0b0101,
+			expected: -1,
+		},
+		{
+			name:     "empty first",
+			a:        0,
+			b:        1,
+			expected: -1,
+		},
+		{
+			name:     "sign bit sorts high",
+			a:        -1 << 62 << 1,
+			b:        1 << 62,
+			expected: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Compare(tt.a, tt.b), tt.name)
+			assert.Equal(t, -tt.expected, Compare(tt.b, tt.a), tt.name)
+		})
+	}
+}
+
+func TestSortMasks(t *testing.T) {
+	masks := []int{0b0111, 0b0011, 0, 0b0100, 0b0101, 0b0001, 0b0011}
+	SortMasks(masks)
+	assert.Equal(t, []int{0, 0b0001, 0b0100, 0b0011, 0b0011, 0b0101, 0b0111}, masks)
+	assert.Equal(t, []int{0, 0b0001, 0b0100, 0b0011, 0b0101, 0b0111}, slices.Compact(masks))
+}
@@ -0,0 +1,748 @@
+package bitmask
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const (
+	// arrayMaxSize is the most values an array container holds before it is
+	// turned into a bitmap: at 4096 values both take 8 KiB.
+	arrayMaxSize = 4096
+	// bitmapWords is the number of 64-bit words covering one container's 2^16 values.
+	bitmapWords = 1 << 16 / wordBits
+	// maxCompressedID is the highest ID a Compressed bitmap can hold.
+	maxCompressedID = 1<<32 - 1
+)
+
+// Container kinds, as written in the binary encoding.
+const (
+	kindArray  = 1
+	kindBitmap = 2
+	kindRun    = 3
+)
+
+// Compressed is a compressed set of IDs in [0, 2^32) for large, sparse, or
+// clustered ID spaces, using the container layout of Roaring bitmaps.
+//
+// IDs are split into a 16-bit key (the high bits) and a 16-bit value (the low
+// bits). Each key that has any IDs owns one container holding its values in
+// whichever of three forms is smallest:
+//
+//	array   sorted list of values       up to 4096 values, 2 bytes each
+//	bitmap  2^16 bits                   more than 4096 values, always 8 KiB
+//	run     sorted [start, end] ranges  long stretches of consecutive IDs
+//
+// A million IDs spread over a 2^32 range take a few megabytes instead of the
+// 512 MiB a BitSet would need. Set and Clear keep containers as arrays or
+// bitmaps; call RunOptimize after loading data to turn containers into runs
+// where that is smaller.
+//
+// The zero value is an empty set ready to use. A Compressed is not safe for
+// concurrent use.
+//
+// Example:
+//
+//	var c bitmask.Compressed
+//	c.Set(7)
+//	c.Set(3_000_000_000)
+//	c.Test(7)           => true
+//	c.Count()           => 2
+//	c.SerializedSize()  => 14
+type Compressed struct {
+	keys       []uint16
+	containers []container
+}
+
+// container holds the low 16 bits of the IDs that share one key. Methods that
+// modify a container return the container to keep, which may be a different
+// kind.
+type container interface {
+	add(x uint16) container
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	values() iter.Seq[uint16]
+	clone() container
+	serializedSize() int
+	appendBinary(b []byte) []byte
+}
+
+// Set adds id to the set. It panics if id is outside [0, 2^32).
+func (c *Compressed) Set(id int) {
+	key, x := splitID(id)
+	i, ok := slices.BinarySearch(c.keys, key)
+	if !ok {
+		c.keys = slices.Insert(c.keys, i, key)
+		c.containers = slices.Insert(c.containers, i, container(arrayContainer{x}))
+		return
+	}
+	c.containers[i] = c.containers[i].add(x)
+}
+
+// Clear removes id from the set. Clearing an ID that isn't set does nothing.
+func (c *Compressed) Clear(id int) {
+	key, x := splitID(id)
+	i, ok := slices.BinarySearch(c.keys, key)
+	if !ok {
+		return
+	}
+	c.containers[i] = c.containers[i].remove(x)
+	if c.containers[i].cardinality() == 0 {
+		c.keys = slices.Delete(c.keys, i, i+1)
+		c.containers = slices.Delete(c.containers, i, i+1)
+	}
+}
+
+// Test reports whether id is in the set. IDs outside [0, 2^32) are never set.
+func (c *Compressed) Test(id int) bool {
+	if id < 0 || uint64(id) > maxCompressedID {
+		return false
+	}
+
+	key, x := splitID(id)
+	i, ok := slices.BinarySearch(c.keys, key)
+	return ok && c.containers[i].contains(x)
+}
+
+// Count returns the number of IDs in the set.
+func (c *Compressed) Count() int {
+	n := 0
+	for _, ct := range c.containers {
+		n += ct.cardinality()
+	}
+
+	return n
+}
+
+// IsEmpty reports whether the set has no IDs.
+func (c *Compressed) IsEmpty() bool {
+	return len(c.containers) == 0
+}
+
+// Bits returns an iterator over the IDs in ascending order.
+// The set must not be modified during iteration.
+func (c *Compressed) Bits() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ct := range c.containers {
+			high := int(c.keys[i]) << 16
+			for x := range ct.values() {
+				if !yield(high | int(x)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Equal reports whether c and o hold the same IDs, however they are stored.
+func (c *Compressed) Equal(o *Compressed) bool {
+	if !slices.Equal(c.keys, o.keys) {
+		return false
+	}
+	for i, ct := range c.containers {
+		if ct.cardinality() != o.containers[i].cardinality() {
+			return false
+		}
+		for x := range ct.values() {
+			if !o.containers[i].contains(x) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Clone returns a copy of c.
+func (c *Compressed) Clone() *Compressed {
+	out := &Compressed{
+		keys:       slices.Clone(c.keys),
+		containers: make([]container, len(c.containers)),
+	}
+	for i, ct := range c.containers {
+		out.containers[i] = ct.clone()
+	}
+
+	return out
+}
+
+// Union returns a new set with the IDs in c or o.
+func (c *Compressed) Union(o *Compressed) *Compressed {
+	out := &Compressed{}
+	i, j := 0, 0
+	for i < len(c.keys) || j < len(o.keys) {
+		switch {
+		case j == len(o.keys) || i < len(c.keys) && c.keys[i] < o.keys[j]:
+			out.append(c.keys[i], c.containers[i].clone())
+			i++
+		case i == len(c.keys) || o.keys[j] < c.keys[i]:
+			out.append(o.keys[j], o.containers[j].clone())
+			j++
+		default:
+			out.append(c.keys[i], unionContainers(c.containers[i], o.containers[j]))
+			i++
+			j++
+		}
+	}
+
+	return out
+}
+
+// Intersect returns a new set with the IDs in both c and o.
+func (c *Compressed) Intersect(o *Compressed) *Compressed {
+	out := &Compressed{}
+	i, j := 0, 0
+	for i < len(c.keys) && j < len(o.keys) {
+		switch {
+		case c.keys[i] < o.keys[j]:
+			i++
+		case o.keys[j] < c.keys[i]:
+			j++
+		default:
+			if ct := intersectContainers(c.containers[i], o.containers[j]); ct.cardinality() > 0 {
+				out.append(c.keys[i], ct)
+			}
+			i++
+			j++
+		}
+	}
+
+	return out
+}
+
+// RunOptimize converts each container to a run container if that makes it
+// smaller, and run containers back to arrays or bitmaps if it doesn't. Call it
+// after bulk loading sets with long stretches of consecutive IDs.
+func (c *Compressed) RunOptimize() {
+	for i, ct := range c.containers {
+		runs := toRuns(ct)
+		plain := newContainer(ct.values(), ct.cardinality())
+		if runs.serializedSize() < plain.serializedSize() {
+			c.containers[i] = runs
+		} else {
+			c.containers[i] = plain
+		}
+	}
+}
+
+// SerializedSize returns the number of bytes MarshalBinary produces for the set.
+// It is computed without encoding anything.
+func (c *Compressed) SerializedSize() int {
+	n := 1 + uvarintLen(uint64(len(c.keys)))
+	for _, ct := range c.containers {
+		n += 3 + ct.serializedSize()
+	}
+
+	return n
+}
+
+// MarshalBinary encodes the set as a version byte, the number of containers as
+// a uvarint, and each container as its key, its kind, and its contents:
+//
+//	[0x01][uvarint n] n × ([key: 2 bytes LE][kind: 1 byte][contents])
+//
+//	array  (kind 1): [uvarint count][count × 2 bytes LE]
+//	bitmap (kind 2): [1024 × 8 bytes LE]
+//	run    (kind 3): [uvarint runs][runs × (start: 2 bytes LE, length-1: 2 bytes LE)]
+func (c *Compressed) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, c.SerializedSize())
+	out = append(out, binaryVersion)
+	out = binary.AppendUvarint(out, uint64(len(c.keys)))
+	for i, ct := range c.containers {
+		out = binary.LittleEndian.AppendUint16(out, c.keys[i])
+		out = ct.appendBinary(out)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary decodes the format written by MarshalBinary, replacing the
+// contents of c.
+func (c *Compressed) UnmarshalBinary(data []byte) error {
+	payload, err := checkVersion(data)
+	if err != nil {
+		return err
+	}
+
+	r := binaryReader{data: payload}
+	n := r.uvarint()
+	if n > uint64(len(payload))/3 {
+		return fmt.Errorf("bitmask: %w: bad container count", ErrInvalidEncoding)
+	}
+
+	var out Compressed
+	for range n {
+		key, kind := r.uint16(), r.byte()
+		if r.err == nil && len(out.keys) > 0 && key <= out.keys[len(out.keys)-1] {
+			return fmt.Errorf("bitmask: %w: container keys out of order", ErrInvalidEncoding)
+		}
+		ct := r.container(kind)
+		if r.err != nil {
+			return r.err
+		}
+		out.append(key, ct)
+	}
+	if r.err == nil && len(r.data) > 0 {
+		r.fail("%d trailing bytes", len(r.data))
+	}
+	if r.err != nil {
+		return r.err
+	}
+	*c = out
+
+	return nil
+}
+
+func (c *Compressed) append(key uint16, ct container) {
+	c.keys = append(c.keys, key)
+	c.containers = append(c.containers, ct)
+}
+
+func splitID(id int) (key, x uint16) {
+	if id < 0 || uint64(id) > maxCompressedID {
+		panic("bitmask: bit index out of range for Compressed")
+	}
+
+	return uint16(id >> 16), uint16(id)
+}
+
+// newContainer returns an array or bitmap container holding n values.
+func newContainer(values iter.Seq[uint16], n int) container {
+	if n <= arrayMaxSize {
+		a := make(arrayContainer, 0, n)
+		for x := range values {
+			a = append(a, x)
+		}
+		return a
+	}
+
+	b := &bitmapContainer{}
+	for x := range values {
+		b.add(x)
+	}
+	return b
+}
+
+func unionContainers(a, b container) container {
+	if a, ok := a.(arrayContainer); ok {
+		if b, ok := b.(arrayContainer); ok && len(a)+len(b) <= arrayMaxSize {
+			out := make(arrayContainer, 0, len(a)+len(b))
+			i, j := 0, 0
+			for i < len(a) && j < len(b) {
+				switch {
+				case a[i] < b[j]:
+					out = append(out, a[i])
+					i++
+				case b[j] < a[i]:
+					out = append(out, b[j])
+					j++
+				default:
+					out = append(out, a[i])
+					i++
+					j++
+				}
+			}
+			out = append(out, a[i:]...)
+			return append(out, b[j:]...)
+		}
+	}
+
+	out := toBitmap(a)
+	for x := range b.values() {
+		out.add(x)
+	}
+	return out.shrink()
+}
+
+func intersectContainers(a, b container) container {
+	if _, ok := b.(arrayContainer); ok {
+		a, b = b, a
+	}
+	if a, ok := a.(arrayContainer); ok {
+		out := arrayContainer{}
+		for _, x := range a {
+			if b.contains(x) {
+				out = append(out, x)
+			}
+		}
+		return out
+	}
+
+	out, other := toBitmap(a), toBitmap(b)
+	out.n = 0
+	for i := range out.words {
+		out.words[i] &= other.words[i]
+		out.n += bits.OnesCount64(out.words[i])
+	}
+	return out.shrink()
+}
+
+// toBitmap returns a new bitmap container with the values of ct.
+func toBitmap(ct container) *bitmapContainer {
+	if b, ok := ct.(*bitmapContainer); ok {
+		return b.clone().(*bitmapContainer)
+	}
+
+	b := &bitmapContainer{}
+	for x := range ct.values() {
+		b.add(x)
+	}
+	return b
+}
+
+// toRuns returns a new run container with the values of ct.
+func toRuns(ct container) runContainer {
+	var runs runContainer
+	for x := range ct.values() {
+		if n := len(runs); n > 0 && runs[n-1].last+1 == x {
+			runs[n-1].last = x
+			continue
+		}
+		runs = append(runs, run{x, x})
+	}
+
+	return runs
+}
+
+// arrayContainer is a sorted list of values.
+type arrayContainer []uint16
+
+func (a arrayContainer) add(x uint16) container {
+	i, ok := slices.BinarySearch(a, x)
+	if ok {
+		return a
+	}
+	if len(a) == arrayMaxSize {
+		b := toBitmap(a)
+		b.add(x)
+		return b
+	}
+
+	return slices.Insert(a, i, x)
+}
+
+func (a arrayContainer) remove(x uint16) container {
+	if i, ok := slices.BinarySearch(a, x); ok {
+		return slices.Delete(a, i, i+1)
+	}
+
+	return a
+}
+
+func (a arrayContainer) contains(x uint16) bool {
+	_, ok := slices.BinarySearch(a, x)
+	return ok
+}
+
+func (a arrayContainer) cardinality() int {
+	return len(a)
+}
+
+func (a arrayContainer) values() iter.Seq[uint16] {
+	return slices.Values(a)
+}
+
+func (a arrayContainer) clone() container {
+	return slices.Clone(a)
+}
+
+func (a arrayContainer) serializedSize() int {
+	return uvarintLen(uint64(len(a))) + 2*len(a)
+}
+
+func (a arrayContainer) appendBinary(b []byte) []byte {
+	b = append(b, kindArray)
+	b = binary.AppendUvarint(b, uint64(len(a)))
+	for _, x := range a {
+		b = binary.LittleEndian.AppendUint16(b, x)
+	}
+
+	return b
+}
+
+// bitmapContainer holds one bit per possible value and tracks how many are set.
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	n     int
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	w, bit := &b.words[x/wordBits], uint64(1)<<(x%wordBits)
+	if *w&bit == 0 {
+		*w |= bit
+		b.n++
+	}
+
+	return b
+}
+
+func (b *bitmapContainer) remove(x uint16) container {
+	w, bit := &b.words[x/wordBits], uint64(1)<<(x%wordBits)
+	if *w&bit != 0 {
+		*w &^= bit
+		b.n--
+	}
+
+	return b.shrink()
+}
+
+// shrink turns b into an array container once that is no larger.
+func (b *bitmapContainer) shrink() container {
+	if b.n > arrayMaxSize {
+		return b
+	}
+
+	return newContainer(b.values(), b.n)
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/wordBits]&(1<<(x%wordBits)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.n
+}
+
+func (b *bitmapContainer) values() iter.Seq[uint16] {
+	return func(yield func(uint16) bool) {
+		for i, w := range b.words {
+			for ; w != 0; w &= w - 1 {
+				if !yield(uint16(i*wordBits + bits.TrailingZeros64(w))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *bitmapContainer) clone() container {
+	out := *b
+	return &out
+}
+
+func (b *bitmapContainer) serializedSize() int {
+	return 8 * bitmapWords
+}
+
+func (b *bitmapContainer) appendBinary(out []byte) []byte {
+	out = append(out, kindBitmap)
+	for _, w := range b.words {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+
+	return out
+}
+
+// run is an inclusive range of values. Storing the last value rather than a
+// length lets a run end at 65535 without overflowing.
+type run struct {
+	start, last uint16
+}
+
+// runContainer is a sorted list of non-overlapping, non-adjacent runs. It only
+// comes from RunOptimize or decoding: modifying it turns it back into an array
+// or bitmap.
+type runContainer []run
+
+func (r runContainer) add(x uint16) container {
+	if r.contains(x) {
+		return r
+	}
+
+	return newContainer(r.values(), r.cardinality()).add(x)
+}
+
+func (r runContainer) remove(x uint16) container {
+	if !r.contains(x) {
+		return r
+	}
+
+	return newContainer(r.values(), r.cardinality()).remove(x)
+}
+
+func (r runContainer) contains(x uint16) bool {
+	_, ok := slices.BinarySearchFunc(r, x, func(rn run, x uint16) int {
+		switch {
+		case rn.last < x:
+			return -1
+		case rn.start > x:
+			return 1
+		}
+		return 0
+	})
+
+	return ok
+}
+
+func (r runContainer) cardinality() int {
+	n := 0
+	for _, rn := range r {
+		n += int(rn.last-rn.start) + 1
+	}
+
+	return n
+}
+
+func (r runContainer) values() iter.Seq[uint16] {
+	return func(yield func(uint16) bool) {
+		for _, rn := range r {
+			for x := int(rn.start); x <= int(rn.last); x++ {
+				if !yield(uint16(x)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r runContainer) clone() container {
+	return slices.Clone(r)
+}
+
+func (r runContainer) serializedSize() int {
+	return uvarintLen(uint64(len(r))) + 4*len(r)
+}
+
+func (r runContainer) appendBinary(b []byte) []byte {
+	b = append(b, kindRun)
+	b = binary.AppendUvarint(b, uint64(len(r)))
+	for _, rn := range r {
+		b = binary.LittleEndian.AppendUint16(b, rn.start)
+		b = binary.LittleEndian.AppendUint16(b, rn.last-rn.start)
+	}
+
+	return b
+}
+
+func uvarintLen(x uint64) int {
+	n := 1
+	for ; x >= 0x80; x >>= 7 {
+		n++
+	}
+
+	return n
+}
+
+// binaryReader decodes a Compressed payload, remembering the first error so
+// that callers can check once after a group of reads.
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) fail(format string, args ...any) {
+	if r.err == nil {
+		r.err = fmt.Errorf("bitmask: %w: "+format, append([]any{ErrInvalidEncoding}, args...)...)
+	}
+}
+
+func (r *binaryReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.fail("unexpected end of input")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+
+	return b
+}
+
+func (r *binaryReader) byte() byte {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+
+	return 0
+}
+
+func (r *binaryReader) uint16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+
+	return 0
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail("bad uvarint")
+		return 0
+	}
+	r.data = r.data[n:]
+
+	return x
+}
+
+// container reads the contents of a container of the given kind and checks
+// that it is in canonical form.
+func (r *binaryReader) container(kind byte) container {
+	if r.err != nil {
+		return nil
+	}
+
+	switch kind {
+	case kindArray:
+		n := r.uvarint()
+		if r.err == nil && (n == 0 || n > arrayMaxSize || n > uint64(len(r.data))/2) {
+			r.fail("array container with %d values", n)
+		}
+		if r.err != nil {
+			return nil
+		}
+		a := make(arrayContainer, 0, n)
+		for range n {
+			x := r.uint16()
+			if r.err == nil && len(a) > 0 && x <= a[len(a)-1] {
+				r.fail("array values out of order")
+			}
+			a = append(a, x)
+		}
+		return a
+	case kindBitmap:
+		b := &bitmapContainer{}
+		for i := range b.words {
+			if w := r.take(8); w != nil {
+				b.words[i] = binary.LittleEndian.Uint64(w)
+				b.n += bits.OnesCount64(b.words[i])
+			}
+		}
+		if r.err == nil && b.n <= arrayMaxSize {
+			r.fail("bitmap container with %d values", b.n)
+		}
+		return b
+	case kindRun:
+		n := r.uvarint()
+		if r.err == nil && (n == 0 || n > uint64(len(r.data))/4) {
+			r.fail("run container with %d runs", n)
+		}
+		if r.err != nil {
+			return nil
+		}
+		runs := make(runContainer, 0, n)
+		for range n {
+			start, length := r.uint16(), r.uint16()
+			if r.err == nil && int(start)+int(length) > 0xFFFF {
+				r.fail("run overflows container")
+			}
+			if r.err == nil && len(runs) > 0 && int(start) <= int(runs[len(runs)-1].last)+1 {
+				r.fail("runs out of order")
+			}
+			runs = append(runs, run{start, start + length})
+		}
+		return runs
+	}
+
+	r.fail("unknown container kind %d", kind)
+	return nil
+}
@@ -0,0 +1,305 @@
+package bitmask
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func compressedOf(ids ...int) *Compressed {
+	c := &Compressed{}
+	for _, id := range ids {
+		c.Set(id)
+	}
+	return c
+}
+
+func TestCompressed(t *testing.T) {
+	var c Compressed
+	assert.True(t, c.IsEmpty())
+	assert.False(t, c.Test(0))
+
+	ids := []int{0, 7, 65535, 65536, 1 << 20, 3_000_000_000, maxCompressedID}
+	for _, id := range ids {
+		c.Set(id)
+	}
+	c.Set(7)
+	for _, id := range ids {
+		assert.True(t, c.Test(id), "id %d", id)
+	}
+	for _, id := range []int{1, 65537, -1, maxCompressedID + 1} {
+		assert.False(t, c.Test(id), "id %d", id)
+	}
+	assert.Equal(t, len(ids), c.Count())
+	assert.Equal(t, ids, slices.Collect(c.Bits()))
+
+	c.Clear(65536)
+	c.Clear(12345)
+	assert.False(t, c.Test(65536))
+	assert.Equal(t, len(ids)-1, c.Count())
+	assert.Len(t, c.keys, 4, "empty containers are dropped")
+
+	assert.PanicsWithValue(t, "bitmask: bit index out of range for Compressed", func() { c.Set(-1) })
+	assert.PanicsWithValue(t, "bitmask: bit index out of range for Compressed", func() { c.Set(maxCompressedID + 1) })
+}
+
+func TestCompressedContainerKinds(t *testing.T) {
+	var c Compressed
+	for id := range arrayMaxSize {
+		c.Set(id * 2)
+	}
+	assert.IsType(t, arrayContainer{}, c.containers[0])
+
+	c.Set(1)
+	assert.IsType(t, &bitmapContainer{}, c.containers[0], "array grows into a bitmap")
+	assert.Equal(t, arrayMaxSize+1, c.Count())
+
+	c.Clear(1)
+	assert.IsType(t, arrayContainer{}, c.containers[0], "bitmap shrinks back to an array")
+	assert.Equal(t, arrayMaxSize, c.Count())
+
+	var r Compressed
+	for id := 100; id < 60000; id++ {
+		r.Set(id)
+	}
+	assert.Equal(t, 8197, r.SerializedSize())
+	r.RunOptimize()
+	assert.Equal(t, runContainer{{100, 59999}}, r.containers[0])
+	assert.Equal(t, 10, r.SerializedSize())
+	assert.True(t, r.Test(100))
+	assert.True(t, r.Test(59999))
+	assert.False(t, r.Test(99))
+	assert.False(t, r.Test(60000))
+	assert.Equal(t, 59900, r.Count())
+
+	r.Clear(500)
+	assert.IsType(t, &bitmapContainer{}, r.containers[0], "modifying runs converts them back")
+	assert.False(t, r.Test(500))
+	assert.Equal(t, 59899, r.Count())
+	r.RunOptimize()
+	assert.Equal(t, runContainer{{100, 499}, {501, 59999}}, r.containers[0])
+
+	sparse := compressedOf(1, 5, 9)
+	sparse.RunOptimize()
+	assert.IsType(t, arrayContainer{}, sparse.containers[0], "runs are only used when smaller")
+}
+
+func TestCompressedSetOps(t *testing.T) {
+	dense := &Compressed{}
+	for id := 0; id < 10000; id += 2 {
+		dense.Set(id)
+	}
+	runs := &Compressed{}
+	for id := 5000; id < 7000; id++ {
+		runs.Set(id)
+	}
+	runs.RunOptimize()
+
+	tests := []struct {
+		name      string
+		a, b      *Compressed
+		union     []int
+		intersect []int
+	}{
+		{
+			name:      "arrays",
+			a:         compressedOf(1, 3, 5, 1<<20),
+			b:         compressedOf(3, 4, 1<<21),
+			union:     []int{1, 3, 4, 5, 1 << 20, 1 << 21},
+			intersect: []int{3},
+		},
+		{
+			name:      "empty",
+			a:         compressedOf(1, 2),
+			b:         &Compressed{},
+			union:     []int{1, 2},
+			intersect: nil,
+		},
+		{
+			name:      "disjoint",
+			a:         compressedOf(1),
+			b:         compressedOf(1 << 17),
+			union:     []int{1, 1 << 17},
+			intersect: nil,
+		},
+		{
+			name:      "array and bitmap",
+			a:         compressedOf(1, 2, 4, 9998, 20000),
+			b:         dense,
+			union:     append(slices.Collect(dense.Bits()), 1, 9998, 20000),
+			intersect: []int{2, 4, 9998},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slices.Sort(tt.union)
+			tt.union = slices.Compact(tt.union)
+			assert.Equal(t, tt.union, slices.Collect(tt.a.Union(tt.b).Bits()), tt.name)
+			assert.Equal(t, tt.union, slices.Collect(tt.b.Union(tt.a).Bits()), tt.name)
+			assert.Equal(t, tt.intersect, slices.Collect(tt.a.Intersect(tt.b).Bits()), tt.name)
+			assert.Equal(t, tt.intersect, slices.Collect(tt.b.Intersect(tt.a).Bits()), tt.name)
+		})
+	}
+
+	both := dense.Intersect(runs)
+	assert.Equal(t, 1000, both.Count())
+	assert.True(t, both.Test(5000))
+	assert.False(t, both.Test(5001))
+	assert.Equal(t, 5000+1000, dense.Union(runs).Count())
+
+	a := compressedOf(1, 2)
+	a.Union(compressedOf(3)).Set(4)
+	assert.False(t, a.Test(4), "results don't share containers")
+}
+
+func TestCompressedEqualClone(t *testing.T) {
+	a := compressedOf(1, 2, 3, 100000)
+	b := a.Clone()
+	assert.True(t, a.Equal(b))
+
+	b.RunOptimize()
+	assert.True(t, a.Equal(b), "storage doesn't matter")
+
+	b.Set(4)
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Test(4))
+	assert.False(t, a.Equal(compressedOf(1, 2, 3, 100001)))
+}
+
+func TestCompressedBinary(t *testing.T) {
+	bulk := &Compressed{}
+	for id := range 70000 {
+		bulk.Set(id * 3)
+	}
+	runs := compressedOf()
+	for id := 1 << 20; id < 1<<20+500; id++ {
+		runs.Set(id)
+	}
+	runs.Set(65535)
+	runs.RunOptimize()
+
+	for _, c := range []*Compressed{{}, compressedOf(7, 3_000_000_000), bulk, runs} {
+		data, err := c.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Len(t, data, c.SerializedSize())
+
+		var got Compressed
+		assert.NoError(t, got.UnmarshalBinary(data))
+		assert.True(t, c.Equal(&got))
+	}
+
+	data, _ := compressedOf(7, 3_000_000_000).MarshalBinary()
+	assert.Equal(t, []byte{1, 2, 0, 0, kindArray, 1, 7, 0, 0xd0, 0xb2, kindArray, 1, 0x00, 0x5e}, data)
+}
+
+func TestCompressedUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "empty",
+			data:     nil,
+			expected: "bitmask: invalid encoding: empty input",
+		},
+		{
+			name:     "truncated",
+			data:     []byte{1, 1, 0, 0, kindBitmap, 7, 0},
+			expected: "bitmask: invalid encoding: unexpected end of input",
+		},
+		{
+			name:     "trailing bytes",
+			data:     []byte{1, 1, 0, 0, kindArray, 1, 7, 0, 9},
+			expected: "bitmask: invalid encoding: 1 trailing bytes",
+		},
+		{
+			name:     "unknown kind",
+			data:     []byte{1, 1, 0, 0, 9, 0},
+			expected: "bitmask: invalid encoding: unknown container kind 9",
+		},
+		{
+			name:     "keys out of order",
+			data:     []byte{1, 2, 1, 0, kindArray, 1, 7, 0, 0, 0, kindArray, 1, 7, 0},
+			expected: "bitmask: invalid encoding: container keys out of order",
+		},
+		{
+			name:     "unsorted array",
+			data:     []byte{1, 1, 0, 0, kindArray, 2, 7, 0, 3, 0},
+			expected: "bitmask: invalid encoding: array values out of order",
+		},
+		{
+			name:     "empty array",
+			data:     []byte{1, 1, 0, 0, kindArray, 0},
+			expected: "bitmask: invalid encoding: array container with 0 values",
+		},
+		{
+			name:     "run overflow",
+			data:     []byte{1, 1, 0, 0, kindRun, 1, 0xff, 0xff, 1, 0},
+			expected: "bitmask: invalid encoding: run overflows container",
+		},
+		{
+			name:     "overlapping runs",
+			data:     []byte{1, 1, 0, 0, kindRun, 2, 0, 0, 5, 0, 6, 0, 1, 0},
+			expected: "bitmask: invalid encoding: runs out of order",
+		},
+		{
+			name:     "huge array count",
+			data:     binary.AppendUvarint([]byte{1, 1, 0, 0, kindArray}, 1<<60),
+			expected: "bitmask: invalid encoding: array container with 1152921504606846976 values",
+		},
+		{
+			name:     "array count beyond input",
+			data:     []byte{1, 1, 0, 0, kindArray, 3, 7, 0, 9, 0},
+			expected: "bitmask: invalid encoding: array container with 3 values",
+		},
+		{
+			name:     "huge run count",
+			data:     binary.AppendUvarint([]byte{1, 1, 0, 0, kindRun}, 1<<60),
+			expected: "bitmask: invalid encoding: run container with 1152921504606846976 runs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := compressedOf(42)
+			assert.EqualError(t, c.UnmarshalBinary(tt.data), tt.expected, tt.name)
+			assert.True(t, c.Test(42), "unchanged on error")
+		})
+	}
+}
+
+func FuzzCompressedUnmarshalBinary(f *testing.F) {
+	for _, c := range []*Compressed{{}, compressedOf(7, 3_000_000_000), compressedOf(1, 2, 3, 4, 5)} {
+		data, _ := c.MarshalBinary()
+		f.Add(data)
+	}
+	f.Add(binary.AppendUvarint([]byte{1, 1, 0, 0, kindArray}, 1<<60))
+	f.Add(binary.AppendUvarint([]byte{1, 1, 0, 0, kindRun}, 1<<40))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var c Compressed
+		if err := c.UnmarshalBinary(data); err != nil {
+			return
+		}
+
+		out, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var again Compressed
+		if err := again.UnmarshalBinary(out); err != nil || !again.Equal(&c) {
+			t.Fatalf("round trip failed: %v", err)
+		}
+	})
+}
+
+func BenchmarkCompressedSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var c Compressed
+		for id := 0; id < 1_000_000; id += 7 {
+			c.Set(id)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package bitmask
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff returns the IDs turned on and turned off going from old to new, each in
+// ascending order. Bits set in both, or in neither, are not reported.
+//
+// added is `new &^ old` and removed is `old &^ new`:
+//
+//	old = 00101010 (IDs 1, 3, 5)
+//	new = 00001110 (IDs 1, 2, 3)
+//	added   = 00000100 → [2]
+//	removed = 00100000 → [5]
+func Diff(old, new int) (added, removed []int) {
+	return Decode(new &^ old), Decode(old &^ new)
+}
+
+// Changes is the result of Diff as a value that can be logged directly.
+type Changes struct {
+	Added   []int
+	Removed []int
+}
+
+// DiffChanges is like Diff but returns a Changes.
+//
+// Example:
+//
+//	c := DiffChanges(oldRoles, newRoles)
+//	if !c.IsEmpty() {
+//		log.Printf("user %d roles: %v", id, c) // user 7 roles: added [2], removed [5]
+//	}
+func DiffChanges(old, new int) Changes {
+	added, removed := Diff(old, new)
+	return Changes{Added: added, Removed: removed}
+}
+
+// IsEmpty reports whether nothing changed.
+func (c Changes) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// String formats the changes as "added [1 2], removed [5]", leaving out an
+// empty side, or "no changes".
+func (c Changes) String() string {
+	var parts []string
+	if len(c.Added) > 0 {
+		parts = append(parts, fmt.Sprintf("added %v", c.Added))
+	}
+	if len(c.Removed) > 0 {
+		parts = append(parts, fmt.Sprintf("removed %v", c.Removed))
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+
+	return strings.Join(parts, ", ")
+}
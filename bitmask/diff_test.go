@@ -0,0 +1,64 @@
+package bitmask
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new int
+		added    []int
+		removed  []int
+		str      string
+	}{
+		{
+			name:    "both",
+			old:     Encode([]int{1, 3, 5}),
+			new:     Encode([]int{1, 2, 3}),
+			added:   []int{2},
+			removed: []int{5},
+			str:     "added [2], removed [5]",
+		},
+		{
+			name:    "only added",
+			old:     0,
+			new:     Encode([]int{0, 4}),
+			added:   []int{0, 4},
+			removed: []int{},
+			str:     "added [0 4]",
+		},
+		{
+			name:    "only removed",
+			old:     Encode([]int{7}),
+			new:     0,
+			added:   []int{},
+			removed: []int{7},
+			str:     "removed [7]",
+		},
+		{
+			name:    "unchanged",
+			old:     42,
+			new:     42,
+			added:   []int{},
+			removed: []int{},
+			str:     "no changes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := Diff(tt.old, tt.new)
+			assert.Equal(t, tt.added, added, tt.name)
+			assert.Equal(t, tt.removed, removed, tt.name)
+
+			c := DiffChanges(tt.old, tt.new)
+			assert.Equal(t, Changes{Added: tt.added, Removed: tt.removed}, c, tt.name)
+			assert.Equal(t, tt.str, c.String(), tt.name)
+			assert.Equal(t, tt.str, fmt.Sprint(c), tt.name)
+			assert.Equal(t, tt.old == tt.new, c.IsEmpty(), tt.name)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package bitmask
+
+// EnumMask is a bitmask of values of the enum type T, whose constants are bit
+// positions. It is stored as an int, but Decode returns []T rather than []int,
+// and because EnumMask[Role] and EnumMask[Feature] are different types, passing
+// one where the other is expected fails to compile.
+//
+// Example:
+//
+//	type Role int
+//
+//	const (
+//		Viewer Role = iota
+//		Editor
+//		Owner
+//	)
+//
+//	roles := bitmask.EncodeEnum(Viewer, Owner) // EnumMask[Role](5)
+//	roles.Has(Editor)                          // false
+//	roles.Decode()                             // []Role{Viewer, Owner}
+//
+// See Permissions for a mutable set with grant and revoke semantics.
+type EnumMask[T ~int] int
+
+// EncodeEnum returns a mask with the bits of the given values set.
+func EncodeEnum[T ~int](values ...T) EnumMask[T] {
+	var mask EnumMask[T]
+	for _, v := range values {
+		mask |= 1 << v
+	}
+
+	return mask
+}
+
+// Decode returns the values set in the mask in ascending order.
+func (m EnumMask[T]) Decode() []T {
+	values := make([]T, 0, Count(int(m)))
+	for id := range Bits(int(m)) {
+		values = append(values, T(id))
+	}
+
+	return values
+}
+
+// Has reports whether v is set in the mask.
+func (m EnumMask[T]) Has(v T) bool {
+	return HasBit(int(m), int(v))
+}
+
+// With returns m with the given values set.
+func (m EnumMask[T]) With(values ...T) EnumMask[T] {
+	return m | EncodeEnum(values...)
+}
+
+// Without returns m with the given values cleared.
+func (m EnumMask[T]) Without(values ...T) EnumMask[T] {
+	return m &^ EncodeEnum(values...)
+}
+
+// Count returns the number of values set.
+func (m EnumMask[T]) Count() int {
+	return Count(int(m))
+}
@@ -0,0 +1,56 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRole int
+
+const (
+	roleViewer testRole = iota
+	roleEditor
+	roleOwner
+)
+
+func TestEnumMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     EnumMask[testRole]
+		expected []testRole
+	}{
+		{
+			name:     "empty",
+			mask:     EncodeEnum[testRole](),
+			expected: []testRole{},
+		},
+		{
+			name:     "encode",
+			mask:     EncodeEnum(roleViewer, roleOwner),
+			expected: []testRole{roleViewer, roleOwner},
+		},
+		{
+			name:     "with",
+			mask:     EncodeEnum(roleViewer).With(roleEditor, roleEditor),
+			expected: []testRole{roleViewer, roleEditor},
+		},
+		{
+			name:     "without",
+			mask:     EncodeEnum(roleViewer, roleEditor, roleOwner).Without(roleEditor),
+			expected: []testRole{roleViewer, roleOwner},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.mask.Decode(), tt.name)
+			assert.Equal(t, len(tt.expected), tt.mask.Count(), tt.name)
+		})
+	}
+
+	roles := EncodeEnum(roleViewer, roleOwner)
+	assert.Equal(t, EnumMask[testRole](5), roles)
+	assert.True(t, roles.Has(roleOwner))
+	assert.False(t, roles.Has(roleEditor))
+	assert.Equal(t, 5, int(roles), "stored as a plain int")
+}
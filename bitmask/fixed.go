@@ -0,0 +1,253 @@
+package bitmask
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// Mask32 is a bitmask that is 32 bits wide on every platform.
+//
+// Mask and the int functions follow the width of int, so bit 31 is a sign bit
+// on 32-bit and WASM builds but an ordinary bit on 64-bit ones. Mask32 and
+// Mask64 are unsigned and check IDs against their own width, so a mask behaves
+// the same wherever it runs and every bit, including the top one, is usable.
+//
+// Example:
+//
+//	m, _ := bitmask.Mask32(0).SetBit(31) // 2147483648 on every GOARCH
+//	m.SetBit(32)                         // error: bitmask: ID 32 out of range [0, 31]
+type Mask32 uint32
+
+// Mask64 is a bitmask that is 64 bits wide on every platform. See Mask32.
+type Mask64 uint64
+
+// Mask32Of returns a Mask32 with the given bits set, or a *RangeError for the
+// first ID outside [0, 31].
+func Mask32Of(ids ...int) (Mask32, error) {
+	mask, err := fixedOf(ids, 32)
+	return Mask32(mask), err
+}
+
+// Mask64Of returns a Mask64 with the given bits set, or a *RangeError for the
+// first ID outside [0, 63].
+func Mask64Of(ids ...int) (Mask64, error) {
+	mask, err := fixedOf(ids, 64)
+	return Mask64(mask), err
+}
+
+// Mask32FromInt converts an int mask, returning a *RangeError for the lowest
+// set bit that doesn't fit in 32 bits. Negative masks always fail, since their
+// sign bit is set.
+func Mask32FromInt(mask int) (Mask32, error) {
+	if err := checkFits(uint64(mask), 32); err != nil {
+		return 0, err
+	}
+
+	return Mask32(mask), nil
+}
+
+// Mask64FromInt converts an int mask, returning a *RangeError if it is
+// negative.
+func Mask64FromInt(mask int) (Mask64, error) {
+	if mask < 0 {
+		return 0, &RangeError{ID: strconv.IntSize - 1, Max: 63}
+	}
+
+	return Mask64(mask), nil
+}
+
+// HasBit reports whether the bit at position id is set. IDs outside [0, 31]
+// report false.
+func (m Mask32) HasBit(id int) bool {
+	return id >= 0 && id < 32 && m&(1<<id) != 0
+}
+
+// SetBit returns m with the bit at position id turned on, or m and a
+// *RangeError if id is outside [0, 31].
+func (m Mask32) SetBit(id int) (Mask32, error) {
+	if err := checkWidth(id, 32); err != nil {
+		return m, err
+	}
+
+	return m | 1<<id, nil
+}
+
+// ClearBit returns m with the bit at position id turned off, or m and a
+// *RangeError if id is outside [0, 31].
+func (m Mask32) ClearBit(id int) (Mask32, error) {
+	if err := checkWidth(id, 32); err != nil {
+		return m, err
+	}
+
+	return m &^ (1 << id), nil
+}
+
+// ToggleBit returns m with the bit at position id flipped, or m and a
+// *RangeError if id is outside [0, 31].
+func (m Mask32) ToggleBit(id int) (Mask32, error) {
+	if err := checkWidth(id, 32); err != nil {
+		return m, err
+	}
+
+	return m ^ 1<<id, nil
+}
+
+// IDs returns the set bit positions in ascending order.
+func (m Mask32) IDs() []int {
+	return DecodeUnsigned(uint32(m))
+}
+
+// Count returns the number of set bits.
+func (m Mask32) Count() int {
+	return bits.OnesCount32(uint32(m))
+}
+
+// IsEmpty reports whether no bits are set.
+func (m Mask32) IsEmpty() bool {
+	return m == 0
+}
+
+// Union returns the bits set in m or o.
+func (m Mask32) Union(o Mask32) Mask32 {
+	return m | o
+}
+
+// Intersect returns the bits set in both m and o.
+func (m Mask32) Intersect(o Mask32) Mask32 {
+	return m & o
+}
+
+// Difference returns the bits of m that are not set in o.
+func (m Mask32) Difference(o Mask32) Mask32 {
+	return m &^ o
+}
+
+// Mask64 widens m. It never fails.
+func (m Mask32) Mask64() Mask64 {
+	return Mask64(m)
+}
+
+// Int converts m to an int mask, returning a *RangeError for the lowest set bit
+// above MaxBit. This only fails for bit 31 on 32-bit platforms.
+func (m Mask32) Int() (int, error) {
+	if err := checkFits(uint64(m), MaxBit+1); err != nil {
+		return 0, err
+	}
+
+	return int(m), nil
+}
+
+// HasBit reports whether the bit at position id is set. IDs outside [0, 63]
+// report false.
+func (m Mask64) HasBit(id int) bool {
+	return id >= 0 && id < 64 && m&(1<<id) != 0
+}
+
+// SetBit returns m with the bit at position id turned on, or m and a
+// *RangeError if id is outside [0, 63].
+func (m Mask64) SetBit(id int) (Mask64, error) {
+	if err := checkWidth(id, 64); err != nil {
+		return m, err
+	}
+
+	return m | 1<<id, nil
+}
+
+// ClearBit returns m with the bit at position id turned off, or m and a
+// *RangeError if id is outside [0, 63].
+func (m Mask64) ClearBit(id int) (Mask64, error) {
+	if err := checkWidth(id, 64); err != nil {
+		return m, err
+	}
+
+	return m &^ (1 << id), nil
+}
+
+// ToggleBit returns m with the bit at position id flipped, or m and a
+// *RangeError if id is outside [0, 63].
+func (m Mask64) ToggleBit(id int) (Mask64, error) {
+	if err := checkWidth(id, 64); err != nil {
+		return m, err
+	}
+
+	return m ^ 1<<id, nil
+}
+
+// IDs returns the set bit positions in ascending order.
+func (m Mask64) IDs() []int {
+	return DecodeUnsigned(uint64(m))
+}
+
+// Count returns the number of set bits.
+func (m Mask64) Count() int {
+	return bits.OnesCount64(uint64(m))
+}
+
+// IsEmpty reports whether no bits are set.
+func (m Mask64) IsEmpty() bool {
+	return m == 0
+}
+
+// Union returns the bits set in m or o.
+func (m Mask64) Union(o Mask64) Mask64 {
+	return m | o
+}
+
+// Intersect returns the bits set in both m and o.
+func (m Mask64) Intersect(o Mask64) Mask64 {
+	return m & o
+}
+
+// Difference returns the bits of m that are not set in o.
+func (m Mask64) Difference(o Mask64) Mask64 {
+	return m &^ o
+}
+
+// Mask32 narrows m, returning a *RangeError for the lowest set bit above 31.
+func (m Mask64) Mask32() (Mask32, error) {
+	if err := checkFits(uint64(m), 32); err != nil {
+		return 0, err
+	}
+
+	return Mask32(m), nil
+}
+
+// Int converts m to an int mask, returning a *RangeError for the lowest set bit
+// above MaxBit: bit 63 on 64-bit platforms, bits 31 and up on 32-bit ones.
+func (m Mask64) Int() (int, error) {
+	if err := checkFits(uint64(m), MaxBit+1); err != nil {
+		return 0, err
+	}
+
+	return int(m), nil
+}
+
+// fixedOf sets ids in a mask of the given width.
+func fixedOf(ids []int, width int) (uint64, error) {
+	var mask uint64
+	for _, id := range ids {
+		if err := checkWidth(id, width); err != nil {
+			return 0, err
+		}
+		mask |= 1 << id
+	}
+
+	return mask, nil
+}
+
+func checkWidth(id, width int) error {
+	if id < 0 || id >= width {
+		return &RangeError{ID: id, Max: width - 1}
+	}
+
+	return nil
+}
+
+// checkFits reports the lowest bit of mask at or above width.
+func checkFits(mask uint64, width int) error {
+	if high := mask >> width; high != 0 {
+		return &RangeError{ID: width + bits.TrailingZeros64(high), Max: width - 1}
+	}
+
+	return nil
+}
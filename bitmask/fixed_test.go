@@ -0,0 +1,181 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask32(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		expected Mask32
+		err      string
+	}{
+		{
+			name:     "low bits",
+			ids:      []int{1, 3, 5},
+			expected: 42,
+		},
+		{
+			name:     "top bit",
+			ids:      []int{0, 31},
+			expected: 1<<31 | 1,
+		},
+		{
+			name: "too wide",
+			ids:  []int{1, 32},
+			err:  "bitmask: ID 32 out of range [0, 31]",
+		},
+		{
+			name: "negative",
+			ids:  []int{-1},
+			err:  "bitmask: ID -1 out of range [0, 31]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Mask32Of(tt.ids...)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.ErrorIs(t, err, ErrOutOfRange, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, got, tt.name)
+			assert.Equal(t, tt.ids, got.IDs(), tt.name)
+			assert.Equal(t, len(tt.ids), got.Count(), tt.name)
+		})
+	}
+
+	m, err := Mask32(0).SetBit(31)
+	assert.NoError(t, err)
+	assert.Equal(t, Mask32(1<<31), m)
+	assert.True(t, m.HasBit(31))
+	assert.False(t, m.HasBit(32))
+	assert.False(t, m.HasBit(-1))
+
+	m, err = m.ToggleBit(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 31}, m.IDs())
+	m, err = m.ClearBit(31)
+	assert.NoError(t, err)
+	assert.Equal(t, Mask32(1), m)
+
+	got, err := m.SetBit(40)
+	assert.EqualError(t, err, "bitmask: ID 40 out of range [0, 31]")
+	assert.Equal(t, m, got, "unchanged on error")
+	_, err = m.ClearBit(32)
+	assert.Error(t, err)
+	_, err = m.ToggleBit(-3)
+	assert.Error(t, err)
+
+	assert.Equal(t, Mask32(0b111), Mask32(0b011).Union(0b110))
+	assert.Equal(t, Mask32(0b010), Mask32(0b011).Intersect(0b110))
+	assert.Equal(t, Mask32(0b001), Mask32(0b011).Difference(0b110))
+	assert.True(t, Mask32(0).IsEmpty())
+	assert.Equal(t, Mask64(1<<31), Mask32(1<<31).Mask64())
+}
+
+func TestMask64(t *testing.T) {
+	m, err := Mask64Of(0, 63)
+	assert.NoError(t, err)
+	assert.Equal(t, Mask64(1<<63|1), m)
+	assert.Equal(t, []int{0, 63}, m.IDs())
+	assert.Equal(t, 2, m.Count())
+	assert.True(t, m.HasBit(63))
+	assert.False(t, m.HasBit(64))
+
+	_, err = Mask64Of(64)
+	assert.EqualError(t, err, "bitmask: ID 64 out of range [0, 63]")
+
+	m, err = m.ClearBit(63)
+	assert.NoError(t, err)
+	m, err = m.ToggleBit(40)
+	assert.NoError(t, err)
+	m, err = m.SetBit(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 40}, m.IDs())
+
+	got, err := m.SetBit(64)
+	assert.EqualError(t, err, "bitmask: ID 64 out of range [0, 63]")
+	assert.Equal(t, m, got, "unchanged on error")
+
+	assert.Equal(t, Mask64(0b111), Mask64(0b011).Union(0b110))
+	assert.Equal(t, Mask64(0b010), Mask64(0b011).Intersect(0b110))
+	assert.Equal(t, Mask64(0b001), Mask64(0b011).Difference(0b110))
+	assert.False(t, m.IsEmpty())
+}
+
+func TestFixedMaskConversions(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func() (any, error)
+		want any
+		err  string
+	}{
+		{
+			name: "int to Mask32",
+			fn:   func() (any, error) { return Mask32FromInt(42) },
+			want: Mask32(42),
+		},
+		{
+			name: "int too wide for Mask32",
+			fn:   func() (any, error) { return Mask32FromInt(1<<40 | 1<<35 | 1) },
+			err:  "bitmask: ID 35 out of range [0, 31]",
+		},
+		{
+			name: "negative int to Mask32",
+			fn:   func() (any, error) { return Mask32FromInt(-1) },
+			err:  "bitmask: ID 32 out of range [0, 31]",
+		},
+		{
+			name: "int to Mask64",
+			fn:   func() (any, error) { return Mask64FromInt(1 << MaxBit) },
+			want: Mask64(1 << MaxBit),
+		},
+		{
+			name: "negative int to Mask64",
+			fn:   func() (any, error) { return Mask64FromInt(-1) },
+			err:  "bitmask: ID 63 out of range [0, 63]",
+		},
+		{
+			name: "Mask64 to Mask32",
+			fn:   func() (any, error) { return Mask64(1<<31 | 1).Mask32() },
+			want: Mask32(1<<31 | 1),
+		},
+		{
+			name: "Mask64 too wide for Mask32",
+			fn:   func() (any, error) { return Mask64(1 << 32).Mask32() },
+			err:  "bitmask: ID 32 out of range [0, 31]",
+		},
+		{
+			name: "Mask32 to int",
+			fn:   func() (any, error) { return Mask32(42).Int() },
+			want: 42,
+		},
+		{
+			name: "Mask64 to int",
+			fn:   func() (any, error) { return Mask64(1 << MaxBit).Int() },
+			want: 1 << MaxBit,
+		},
+		{
+			name: "Mask64 sign bit to int",
+			fn:   func() (any, error) { return Mask64(1 << 63).Int() },
+			err:  "bitmask: ID 63 out of range [0, 62]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.ErrorIs(t, err, ErrOutOfRange, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.want, got, tt.name)
+		})
+	}
+}
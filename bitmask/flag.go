@@ -0,0 +1,90 @@
+package bitmask
+
+import (
+	"flag"
+	"strings"
+)
+
+// Mask implements flag.Value, so a mask can be a command-line flag holding
+// comma-separated IDs:
+//
+//	var levels bitmask.Mask
+//	flag.Var(&levels, "levels", "comma-separated log levels to enable")
+//	// -levels=1,3,5 → levels == 42
+//
+// Use FlagSet.FlagValue to accept names instead of IDs. Type makes Mask a
+// pflag.Value as well.
+var _ flag.Value = (*Mask)(nil)
+
+// String formats the mask as comma-separated IDs, e.g. "1,3,5".
+func (m Mask) String() string {
+	return string(appendIDs(nil, Bits(int(m))))
+}
+
+// Set parses comma-separated IDs, replacing the mask. IDs beyond the width of
+// int are rejected.
+func (m *Mask) Set(s string) error {
+	return m.UnmarshalText([]byte(s))
+}
+
+// Type returns the type name pflag shows in help output.
+func (m *Mask) Type() string {
+	return "mask"
+}
+
+// FlagValue returns a flag.Value that stores into m and reads and writes the
+// mask as comma-separated flag names. An unknown name is an error listing the
+// valid ones, which the flag package prints with the usage message.
+//
+// Example:
+//
+//	var features bitmask.Mask
+//	flag.Var(featureFlags.FlagValue(&features), "features", "features to enable")
+//
+//	// --features=audit,beta,tracing → features has those three bits set
+//	// --features=audit,nope        → invalid value "audit,nope" for flag -features:
+//	//                                bitmask: unknown flag "nope" (valid: audit, beta, tracing)
+//
+// The returned value also has the Type method pflag requires.
+func (fs *FlagSet) FlagValue(m *Mask) flag.Value {
+	return &maskFlag{mask: m, flags: fs}
+}
+
+// maskFlag is the flag.Value returned by FlagSet.FlagValue.
+type maskFlag struct {
+	mask  *Mask
+	flags *FlagSet
+}
+
+// String joins the names of the set flags with commas. The flag package calls
+// it on a zero maskFlag to find the default, so it must handle nil fields.
+func (f *maskFlag) String() string {
+	if f.mask == nil || f.flags == nil {
+		return ""
+	}
+
+	return strings.Join(f.flags.DecodeNames(int(*f.mask)), ",")
+}
+
+// Set parses comma-separated names, replacing the mask. Spaces around names are
+// ignored.
+func (f *maskFlag) Set(s string) error {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	mask, err := f.flags.EncodeNames(names)
+	if err != nil {
+		return err
+	}
+	*f.mask = Mask(mask)
+
+	return nil
+}
+
+func (f *maskFlag) Type() string {
+	return "flags"
+}
@@ -0,0 +1,105 @@
+package bitmask
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskFlag(t *testing.T) {
+	var m Mask
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	fs.Var(&m, "levels", "levels to enable")
+
+	assert.NoError(t, fs.Parse([]string{"-levels=1, 3,5"}))
+	assert.Equal(t, Mask(42), m)
+	assert.Equal(t, "1,3,5", m.String())
+	assert.Equal(t, "1,3,5", fmt.Sprint(m))
+	assert.Equal(t, "mask", m.Type())
+
+	err := fs.Parse([]string{"-levels=1,99"})
+	assert.ErrorContains(t, err, "bitmask: ID 99 out of range [0, 63]")
+	assert.Equal(t, Mask(42), m, "unchanged on error")
+
+	assert.NoError(t, m.Set(""))
+	assert.Equal(t, Mask(0), m)
+}
+
+func TestFlagSetFlagValue(t *testing.T) {
+	features := NewFlagSet()
+	features.MustRegister(0, "audit")
+	features.MustRegister(1, "beta")
+	features.MustRegister(2, "tracing")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected Mask
+		err      string
+	}{
+		{
+			name:     "names",
+			args:     []string{"--features=audit,tracing"},
+			expected: 0b101,
+		},
+		{
+			name:     "spaces and empty names",
+			args:     []string{"--features", " beta , ,audit"},
+			expected: 0b011,
+		},
+		{
+			name:     "last flag wins",
+			args:     []string{"--features=audit", "--features=beta"},
+			expected: 0b010,
+		},
+		{
+			name:     "empty",
+			args:     []string{"--features="},
+			expected: 0,
+		},
+		{
+			name: "unknown name",
+			args: []string{"--features=audit,nope"},
+			err:  `invalid value "audit,nope" for flag -features: bitmask: unknown flag "nope" (valid: audit, beta, tracing)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Mask(0b111)
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&bytes.Buffer{})
+			fs.Var(features.FlagValue(&m), "features", "features to enable")
+
+			err := fs.Parse(tt.args)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.Equal(t, Mask(0b111), m, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, m, tt.name)
+		})
+	}
+}
+
+func TestFlagSetFlagValueUsage(t *testing.T) {
+	features := NewFlagSet()
+	features.MustRegister(0, "audit")
+	features.MustRegister(3, "beta")
+
+	m := Mask(0b1001)
+	v := features.FlagValue(&m)
+	assert.Equal(t, "audit,beta", v.String())
+	assert.Equal(t, "flags", v.(interface{ Type() string }).Type())
+
+	var out bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	fs.Var(v, "features", "features to enable")
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), `(default audit,beta)`)
+}
@@ -0,0 +1,174 @@
+package bitmask
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrFlagConflict is returned (wrapped) when a bit or name is registered twice.
+	ErrFlagConflict = errors.New("flag conflict")
+	// ErrUnknownFlag is returned (wrapped) when a name isn't registered in a FlagSet.
+	ErrUnknownFlag = errors.New("unknown flag")
+)
+
+// FlagSet maps bit positions to names, turning masks into readable permission
+// or feature-flag sets.
+//
+// Example:
+//
+//	perms := bitmask.NewFlagSet()
+//	perms.MustRegister(0, "read")
+//	perms.MustRegister(1, "write")
+//	perms.MustRegister(2, "admin")
+//
+//	mask, _ := perms.EncodeNames([]string{"read", "admin"}) // 5 (binary: 101)
+//	perms.DecodeNames(mask)                                 // ["read", "admin"]
+//
+// A FlagSet is safe for concurrent use; typically flags are registered once at
+// init time and looked up from then on.
+type FlagSet struct {
+	mu     sync.RWMutex
+	byBit  map[int]string
+	byName map[string]int
+}
+
+// NewFlagSet returns an empty FlagSet.
+func NewFlagSet() *FlagSet {
+	return &FlagSet{byBit: map[int]string{}, byName: map[string]int{}}
+}
+
+// Register names the bit at position bit.
+//
+// Names must be non-empty and may not contain commas or spaces, so that they
+// can be written as a comma-separated list. Registering a bit or a name that is
+// already taken returns an error wrapping ErrFlagConflict; bits outside
+// [0, MaxBit] return a *RangeError.
+func (fs *FlagSet) Register(bit int, name string) error {
+	if err := newStrictConfig(nil).check(bit); err != nil {
+		return err
+	}
+	if name == "" || strings.ContainsAny(name, ", \t\n") {
+		return fmt.Errorf("bitmask: invalid flag name %q", name)
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if existing, ok := fs.byBit[bit]; ok {
+		return fmt.Errorf("bitmask: %w: bit %d is already registered as %q", ErrFlagConflict, bit, existing)
+	}
+	if existing, ok := fs.byName[name]; ok {
+		return fmt.Errorf("bitmask: %w: %q is already registered for bit %d", ErrFlagConflict, name, existing)
+	}
+	fs.byBit[bit] = name
+	fs.byName[name] = bit
+
+	return nil
+}
+
+// MustRegister is like Register but panics on error. It returns bit so that it
+// can be used to declare flag constants.
+//
+// Example:
+//
+//	var FlagBeta = flags.MustRegister(3, "beta")
+func (fs *FlagSet) MustRegister(bit int, name string) int {
+	if err := fs.Register(bit, name); err != nil {
+		panic(err)
+	}
+
+	return bit
+}
+
+// Bit returns the bit registered under name.
+func (fs *FlagSet) Bit(name string) (int, bool) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	bit, ok := fs.byName[name]
+	return bit, ok
+}
+
+// Name returns the name registered for bit.
+func (fs *FlagSet) Name(bit int) (string, bool) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	name, ok := fs.byBit[bit]
+	return name, ok
+}
+
+// Names returns all registered names in bit order.
+func (fs *FlagSet) Names() []string {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	return fs.namesLocked()
+}
+
+func (fs *FlagSet) namesLocked() []string {
+	names := make([]string, 0, len(fs.byBit))
+	for bit := range MaxBit + 1 {
+		if name, ok := fs.byBit[bit]; ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// EncodeNames returns a mask with the bits of the named flags set. An unknown
+// name returns an error wrapping ErrUnknownFlag that lists the valid names.
+//
+// Example:
+//
+//	perms.EncodeNames([]string{"read", "admin"}) => 5
+//	perms.EncodeNames([]string{"delete"})        => error: bitmask: unknown flag "delete" (valid: read, write, admin)
+func (fs *FlagSet) EncodeNames(names []string) (int, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	var mask int
+	for _, name := range names {
+		bit, ok := fs.byName[name]
+		if !ok {
+			return 0, fmt.Errorf("bitmask: %w %q (valid: %s)", ErrUnknownFlag, name, strings.Join(fs.namesLocked(), ", "))
+		}
+		mask |= 1 << bit
+	}
+
+	return mask, nil
+}
+
+// DecodeNames returns the names of the registered flags set in mask, in bit
+// order. Set bits without a name are skipped; see Unknown to detect them.
+func (fs *FlagSet) DecodeNames(mask int) []string {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	names := make([]string, 0, Count(mask))
+	for bit := range Bits(mask) {
+		if name, ok := fs.byBit[bit]; ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Unknown returns the bits set in mask that have no registered name.
+func (fs *FlagSet) Unknown(mask int) int {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	for bit := range Bits(mask) {
+		if _, ok := fs.byBit[bit]; ok {
+			mask &^= 1 << bit
+		}
+	}
+
+	return mask
+}
@@ -0,0 +1,110 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFlags() *FlagSet {
+	fs := NewFlagSet()
+	fs.MustRegister(0, "read")
+	fs.MustRegister(1, "write")
+	fs.MustRegister(4, "admin")
+	return fs
+}
+
+func TestFlagSetRegister(t *testing.T) {
+	fs := testFlags()
+
+	tests := []struct {
+		name     string
+		bit      int
+		flag     string
+		expected string
+	}{
+		{
+			name:     "bit taken",
+			bit:      1,
+			flag:     "delete",
+			expected: `bitmask: flag conflict: bit 1 is already registered as "write"`,
+		},
+		{
+			name:     "name taken",
+			bit:      2,
+			flag:     "read",
+			expected: `bitmask: flag conflict: "read" is already registered for bit 0`,
+		},
+		{
+			name:     "empty name",
+			bit:      2,
+			flag:     "",
+			expected: `bitmask: invalid flag name ""`,
+		},
+		{
+			name:     "comma in name",
+			bit:      2,
+			flag:     "a,b",
+			expected: `bitmask: invalid flag name "a,b"`,
+		},
+		{
+			name:     "negative bit",
+			bit:      -1,
+			flag:     "neg",
+			expected: "bitmask: ID -1 out of range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, fs.Register(tt.bit, tt.flag), tt.expected, tt.name)
+		})
+	}
+
+	assert.ErrorIs(t, fs.Register(0, "other"), ErrFlagConflict)
+	assert.Panics(t, func() { fs.MustRegister(0, "other") })
+	assert.Equal(t, 7, fs.MustRegister(7, "audit"))
+}
+
+func TestFlagSetLookup(t *testing.T) {
+	fs := testFlags()
+
+	bit, ok := fs.Bit("admin")
+	assert.True(t, ok)
+	assert.Equal(t, 4, bit)
+	_, ok = fs.Bit("nope")
+	assert.False(t, ok)
+
+	name, ok := fs.Name(1)
+	assert.True(t, ok)
+	assert.Equal(t, "write", name)
+	_, ok = fs.Name(2)
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"read", "write", "admin"}, fs.Names())
+}
+
+func TestFlagSetEncodeNames(t *testing.T) {
+	fs := testFlags()
+
+	mask, err := fs.EncodeNames([]string{"read", "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, 17, mask)
+
+	mask, err = fs.EncodeNames(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mask)
+
+	_, err = fs.EncodeNames([]string{"read", "delete"})
+	assert.EqualError(t, err, `bitmask: unknown flag "delete" (valid: read, write, admin)`)
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+}
+
+func TestFlagSetDecodeNames(t *testing.T) {
+	fs := testFlags()
+
+	assert.Equal(t, []string{"read", "admin"}, fs.DecodeNames(17))
+	assert.Equal(t, []string{}, fs.DecodeNames(0))
+	assert.Equal(t, []string{"write"}, fs.DecodeNames(0b1000_0010), "unnamed bits are skipped")
+	assert.Equal(t, 0b1000_0000, fs.Unknown(0b1000_0011))
+	assert.Equal(t, 0, fs.Unknown(17))
+}
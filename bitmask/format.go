@@ -0,0 +1,115 @@
+package bitmask
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// FormatOption configures ToBinaryString.
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	width   int
+	prefix  bool
+	nibbles bool
+}
+
+// WithWidth pads the output with leading zeros to at least width digits, so
+// masks in a log line up. Masks that need more digits are never cut short.
+func WithWidth(width int) FormatOption {
+	return func(c *formatConfig) {
+		c.width = min(width, bits.UintSize)
+	}
+}
+
+// WithPrefix adds a "0b" prefix.
+func WithPrefix() FormatOption {
+	return func(c *formatConfig) {
+		c.prefix = true
+	}
+}
+
+// WithNibbles separates every four digits, counted from the right, with an
+// underscore. Together with WithPrefix the output is a valid Go literal.
+func WithNibbles() FormatOption {
+	return func(c *formatConfig) {
+		c.nibbles = true
+	}
+}
+
+// ToBinaryString formats the mask in base 2, highest bit first. A negative mask
+// shows all the bits of its two's-complement form.
+//
+// Example:
+//
+//	ToBinaryString(42)                                              => "101010"
+//	ToBinaryString(42, WithWidth(8))                                => "00101010"
+//	ToBinaryString(42, WithWidth(8), WithPrefix(), WithNibbles())   => "0b0010_1010"
+func ToBinaryString(mask int, opts ...FormatOption) string {
+	var c formatConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	digits := strconv.FormatUint(uint64(uint(mask)), 2)
+	if len(digits) < c.width {
+		digits = strings.Repeat("0", c.width-len(digits)) + digits
+	}
+
+	var b strings.Builder
+	if c.prefix {
+		b.WriteString("0b")
+	}
+	for i, d := range digits {
+		if c.nibbles && i > 0 && (len(digits)-i)%4 == 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(d)
+	}
+
+	return b.String()
+}
+
+// binarySeparators are the characters FromBinaryString ignores between digits.
+const binarySeparators = "_ '.:"
+
+// FromBinaryString parses a mask written in base 2, as produced by
+// ToBinaryString. An optional "0b" prefix is accepted, and underscores, spaces,
+// apostrophes, dots, and colons between digits are ignored, so grouped forms
+// copied from logs or datasheets parse as they are. Leading zeros are allowed,
+// but a mask wider than an int is an error.
+//
+// Example:
+//
+//	FromBinaryString("0b0010_1010") => 42, nil
+//	FromBinaryString("1010 1010")   => 170, nil
+//	FromBinaryString("0b102")       => 0, error
+func FromBinaryString(s string) (int, error) {
+	digits := strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(digits, "0b"); ok {
+		digits = rest
+	} else if rest, ok := strings.CutPrefix(digits, "0B"); ok {
+		digits = rest
+	}
+	digits = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(binarySeparators, r) {
+			return -1
+		}
+		return r
+	}, digits)
+
+	if digits == "" {
+		return 0, fmt.Errorf("bitmask: %w: no digits in binary string %q", ErrInvalidEncoding, s)
+	}
+	if i := strings.IndexFunc(digits, func(r rune) bool { return r != '0' && r != '1' }); i >= 0 {
+		return 0, fmt.Errorf("bitmask: %w: invalid binary digit %q in %q", ErrInvalidEncoding, digits[i:i+1], s)
+	}
+	mask, err := strconv.ParseUint(digits, 2, bits.UintSize)
+	if err != nil {
+		return 0, fmt.Errorf("bitmask: %w: binary string %q is wider than %d bits", ErrInvalidEncoding, s, bits.UintSize)
+	}
+
+	return int(uint(mask)), nil
+}
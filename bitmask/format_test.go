@@ -0,0 +1,148 @@
+package bitmask
+
+import (
+	"math/bits"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToBinaryString(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		opts     []FormatOption
+		expected string
+	}{
+		{
+			name:     "plain",
+			mask:     42,
+			opts:     nil,
+			expected: "101010",
+		},
+		{
+			name:     "zero",
+			mask:     0,
+			opts:     nil,
+			expected: "0",
+		},
+		{
+			name:     "width",
+			mask:     42,
+			opts:     []FormatOption{WithWidth(8)},
+			expected: "00101010",
+		},
+		{
+			name:     "width smaller than mask",
+			mask:     42,
+			opts:     []FormatOption{WithWidth(3)},
+			expected: "101010",
+		},
+		{
+			name:     "prefix",
+			mask:     5,
+			opts:     []FormatOption{WithPrefix()},
+			expected: "0b101",
+		},
+		{
+			name:     "nibbles",
+			mask:     42,
+			opts:     []FormatOption{WithNibbles()},
+			expected: "10_1010",
+		},
+		{
+			name:     "all options",
+			mask:     42,
+			opts:     []FormatOption{WithWidth(8), WithPrefix(), WithNibbles()},
+			expected: "0b0010_1010",
+		},
+		{
+			name:     "negative",
+			mask:     -1,
+			opts:     nil,
+			expected: strings.Repeat("1", bits.UintSize),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToBinaryString(tt.mask, tt.opts...), tt.name)
+		})
+	}
+}
+
+func TestFromBinaryString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		err      string
+	}{
+		{
+			name:     "plain",
+			input:    "101010",
+			expected: 42,
+		},
+		{
+			name:     "prefix and nibbles",
+			input:    "0b0010_1010",
+			expected: 42,
+		},
+		{
+			name:     "upper-case prefix",
+			input:    "0B11",
+			expected: 3,
+		},
+		{
+			name:     "spaces and dots",
+			input:    " 1010 1010.0001 ",
+			expected: 0b1010_1010_0001,
+		},
+		{
+			name:     "leading zeros",
+			input:    strings.Repeat("0", 100) + "1",
+			expected: 1,
+		},
+		{
+			name:     "full width",
+			input:    strings.Repeat("1", bits.UintSize),
+			expected: -1,
+		},
+		{
+			name:  "empty",
+			input: "0b_",
+			err:   `bitmask: invalid encoding: no digits in binary string "0b_"`,
+		},
+		{
+			name:  "bad digit",
+			input: "0b102",
+			err:   `bitmask: invalid encoding: invalid binary digit "2" in "0b102"`,
+		},
+		{
+			name:  "too wide",
+			input: "1" + strings.Repeat("0", bits.UintSize),
+			err:   "wider than",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mask, err := FromBinaryString(tt.input)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err, tt.name)
+				assert.ErrorIs(t, err, ErrInvalidEncoding, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, mask, tt.name)
+		})
+	}
+}
+
+func TestBinaryStringRoundTrip(t *testing.T) {
+	for _, mask := range []int{0, 1, 42, 1 << 40, -1, -42} {
+		s := ToBinaryString(mask, WithPrefix(), WithNibbles(), WithWidth(16))
+		got, err := FromBinaryString(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, mask, got, s)
+	}
+}
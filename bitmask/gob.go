@@ -0,0 +1,13 @@
+package bitmask
+
+import "encoding/gob"
+
+// Mask, BitSet, and Compressed implement encoding.BinaryMarshaler, which gob
+// uses in place of its own encoding, so they can be gob-encoded as they are.
+// Registering them also lets them travel inside interface values, as in a
+// map[string]any cache entry.
+func init() {
+	gob.Register(Mask(0))
+	gob.Register(&BitSet{})
+	gob.Register(&Compressed{})
+}
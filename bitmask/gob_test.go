@@ -0,0 +1,36 @@
+package bitmask
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGob(t *testing.T) {
+	type entry struct {
+		Roles Mask
+		Seen  *BitSet
+		Large *Compressed
+		Extra any
+	}
+	in := entry{
+		Roles: 42,
+		Seen:  bitSetOf(1, 70, 300),
+		Large: compressedOf(5, 3_000_000_000),
+		Extra: bitSetOf(9),
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out entry
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	assert.Equal(t, Mask(42), out.Roles)
+	assert.True(t, in.Seen.Equal(out.Seen))
+	assert.True(t, in.Large.Equal(out.Large))
+	if assert.IsType(t, &BitSet{}, out.Extra) {
+		assert.Equal(t, []int{9}, setBits(out.Extra.(*BitSet)))
+	}
+}
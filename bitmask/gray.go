@@ -0,0 +1,56 @@
+package bitmask
+
+import "iter"
+
+// ToGray returns the reflected binary Gray code of n: `n ^ (n >> 1)`. Gray codes
+// of consecutive numbers differ in exactly one bit.
+//
+// Example:
+//
+//	n:    0    1    2    3    4    5    6    7
+//	gray: 000  001  011  010  110  111  101  100
+func ToGray(n uint64) uint64 {
+	return n ^ n>>1
+}
+
+// FromGray returns the number whose Gray code is g, undoing ToGray.
+//
+// Bit i of the result is the XOR of bits i and above of g. XORing g with itself
+// shifted by 1, 2, 4, 8, 16, and 32 accumulates those prefixes in six steps
+// instead of one per bit.
+//
+// Example:
+//
+//	FromGray(0b110) => 4
+func FromGray(g uint64) uint64 {
+	for shift := 1; shift < 64; shift <<= 1 {
+		g ^= g >> shift
+	}
+
+	return g
+}
+
+// GrayCodes returns an iterator over all 2^width masks of width bits in Gray
+// code order, starting at 0. Each mask differs from the previous one in a single
+// bit, so enumerating every subset of width items costs one toggle per step. It
+// panics if width is outside [0, 64].
+//
+// Example:
+//
+//	for mask := range GrayCodes(3) {
+//		fmt.Printf("%03b ", mask) // 000 001 011 010 110 111 101 100
+//	}
+func GrayCodes(width int) iter.Seq[uint64] {
+	if width < 0 || width > 64 {
+		panic("bitmask: Gray code width out of range [0, 64]")
+	}
+
+	last := uint64(1)<<width - 1
+	return func(yield func(uint64) bool) {
+		for n := uint64(0); ; n++ {
+			if !yield(ToGray(n)) || n == last {
+				return
+			}
+		}
+	}
+}
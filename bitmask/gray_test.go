@@ -0,0 +1,81 @@
+package bitmask
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGray(t *testing.T) {
+	tests := []struct {
+		name string
+		n    uint64
+		gray uint64
+	}{
+		{
+			name: "zero",
+			n:    0,
+			gray: 0,
+		},
+		{
+			name: "three",
+			n:    3,
+			gray: 0b010,
+		},
+		{
+			name: "four",
+			n:    4,
+			gray: 0b110,
+		},
+		{
+			name: "seven",
+			n:    7,
+			gray: 0b100,
+		},
+		{
+			name: "max",
+			n:    math.MaxUint64,
+			gray: 1 << 63,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.gray, ToGray(tt.n), tt.name)
+			assert.Equal(t, tt.n, FromGray(tt.gray), tt.name)
+		})
+	}
+
+	for _, n := range []uint64{12345, 1 << 40, math.MaxUint64 - 1} {
+		assert.Equal(t, n, FromGray(ToGray(n)))
+		assert.Equal(t, 1, bits.OnesCount64(ToGray(n)^ToGray(n+1)), "neighbors differ in one bit")
+	}
+}
+
+func TestGrayCodes(t *testing.T) {
+	assert.Equal(t, []uint64{0, 1, 3, 2, 6, 7, 5, 4}, slices.Collect(GrayCodes(3)))
+	assert.Equal(t, []uint64{0}, slices.Collect(GrayCodes(0)))
+
+	codes := slices.Collect(GrayCodes(10))
+	assert.Len(t, codes, 1024)
+	seen := make(map[uint64]bool)
+	for i, c := range codes {
+		seen[c] = true
+		if i > 0 {
+			assert.Equal(t, 1, bits.OnesCount64(c^codes[i-1]))
+		}
+	}
+	assert.Len(t, seen, 1024, "every mask appears once")
+
+	n := 0
+	for range GrayCodes(64) {
+		if n++; n == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, n)
+
+	assert.Panics(t, func() { GrayCodes(65) })
+}
@@ -0,0 +1,58 @@
+package bitmask
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Bits returns an iterator over the IDs (bit positions) set in the mask, in
+// ascending order. Unlike Decode it doesn't allocate, and the loop can stop early.
+//
+// Each step jumps straight to the next set bit with `bits.TrailingZeros` and
+// then clears it with `m &= m - 1`, so the cost depends on the number of set
+// bits rather than the position of the highest one.
+//
+// Example:
+//
+//	for id := range Bits(42) {
+//		fmt.Println(id) // 1, 3, 5
+//	}
+func Bits(mask int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for m := uint(mask); m != 0; m &= m - 1 {
+			if !yield(bits.TrailingZeros(m)) {
+				return
+			}
+		}
+	}
+}
+
+// DecodeAppend appends the IDs set in the mask to dst in ascending order and
+// returns the extended slice. Reusing dst across calls avoids the allocation
+// Decode makes for every mask.
+//
+// Example:
+//
+//	buf := make([]int, 0, 64)
+//	for _, mask := range masks {
+//		buf = DecodeAppend(buf[:0], mask)
+//		process(buf)
+//	}
+func DecodeAppend(dst []int, mask int) []int {
+	for m := uint(mask); m != 0; m &= m - 1 {
+		dst = append(dst, bits.TrailingZeros(m))
+	}
+
+	return dst
+}
+
+// DecodeFunc calls fn for each ID set in the mask in ascending order, stopping
+// early if fn returns false. It is Bits without the iterator, for callers that
+// already have a callback.
+func DecodeFunc(mask int, fn func(id int) bool) {
+	for m := uint(mask); m != 0; m &= m - 1 {
+		if !fn(bits.TrailingZeros(m)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package bitmask
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		expected []int
+	}{
+		{
+			name:     "zero mask",
+			mask:     0,
+			expected: nil,
+		},
+		{
+			name:     "multiple bits",
+			mask:     42,
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:     "high bit",
+			mask:     1 << 40,
+			expected: []int{40},
+		},
+		{
+			name:     "sign bit",
+			mask:     -1 << (strconv.IntSize - 1),
+			expected: []int{strconv.IntSize - 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slices.Collect(Bits(tt.mask)), tt.name)
+		})
+	}
+}
+
+func TestBitsBreak(t *testing.T) {
+	var got []int
+	for id := range Bits(0b11110) {
+		if id == 3 {
+			break
+		}
+		got = append(got, id)
+	}
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestDecodeAppend(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      []int
+		mask     int
+		expected []int
+	}{
+		{
+			name:     "empty dst",
+			dst:      nil,
+			mask:     42,
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:     "appends",
+			dst:      []int{-1},
+			mask:     0b101,
+			expected: []int{-1, 0, 2},
+		},
+		{
+			name:     "zero mask",
+			dst:      []int{7},
+			mask:     0,
+			expected: []int{7},
+		},
+		{
+			name:     "sign bit",
+			dst:      nil,
+			mask:     -1 << (strconv.IntSize - 1),
+			expected: []int{strconv.IntSize - 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DecodeAppend(tt.dst, tt.mask), tt.name)
+		})
+	}
+
+	buf := make([]int, 0, 8)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = DecodeAppend(buf[:0], 42)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestDecodeFunc(t *testing.T) {
+	var got []int
+	DecodeFunc(42, func(id int) bool {
+		got = append(got, id)
+		return true
+	})
+	assert.Equal(t, []int{1, 3, 5}, got)
+
+	got = nil
+	DecodeFunc(0b11110, func(id int) bool {
+		got = append(got, id)
+		return id < 2
+	})
+	assert.Equal(t, []int{1, 2}, got, "stops when fn returns false")
+
+	DecodeFunc(0, func(int) bool {
+		t.Fatal("called for an empty mask")
+		return true
+	})
+}
+
+func BenchmarkBits(b *testing.B) {
+	mask := Encode([]int{1, 7, 13, 22, 40, 61})
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for id := range Bits(mask) {
+			n += id
+		}
+		_ = n
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	mask := Encode([]int{1, 7, 13, 22, 40, 61})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for _, id := range Decode(mask) {
+			n += id
+		}
+		_ = n
+	}
+}
+
+func BenchmarkDecodeAppend(b *testing.B) {
+	mask := Encode([]int{1, 7, 13, 22, 40, 61})
+	buf := make([]int, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		buf = DecodeAppend(buf[:0], mask)
+		for _, id := range buf {
+			n += id
+		}
+		_ = n
+	}
+}
+
+func BenchmarkDecodeFunc(b *testing.B) {
+	mask := Encode([]int{1, 7, 13, 22, 40, 61})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		DecodeFunc(mask, func(id int) bool {
+			n += id
+			return true
+		})
+		_ = n
+	}
+}
@@ -0,0 +1,183 @@
+package bitmask
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// binaryVersion is the first byte of every binary encoding produced by this
+// package. It lets the layout change later without breaking stored data:
+// decoders switch on it and reject versions they don't know.
+const binaryVersion = 1
+
+// ErrInvalidEncoding is returned (wrapped) when text or binary input can't be decoded.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// MarshalText encodes the mask as comma-separated IDs, e.g. "1,3,5".
+// An empty mask is the empty string.
+func (m Mask) MarshalText() ([]byte, error) {
+	return appendIDs(nil, Bits(int(m))), nil
+}
+
+// UnmarshalText decodes comma-separated IDs as written by MarshalText. Spaces
+// around IDs are ignored and IDs above the sign bit are rejected, so every
+// mask MarshalText writes, negative ones included, decodes to the same value.
+func (m *Mask) UnmarshalText(text []byte) error {
+	ids, err := parseIDs(string(text))
+	if err != nil {
+		return err
+	}
+	mask, err := encodeMask(ids)
+	if err != nil {
+		return err
+	}
+	*m = mask
+
+	return nil
+}
+
+// MarshalBinary encodes the mask as a version byte followed by the mask as a
+// little-endian uint64:
+//
+//	[0x01][8 bytes, least significant first]
+func (m Mask) MarshalBinary() ([]byte, error) {
+	return binary.LittleEndian.AppendUint64([]byte{binaryVersion}, uint64(m)), nil
+}
+
+// UnmarshalBinary decodes the format written by MarshalBinary.
+func (m *Mask) UnmarshalBinary(data []byte) error {
+	payload, err := checkVersion(data)
+	if err != nil {
+		return err
+	}
+	if len(payload) != 8 {
+		return fmt.Errorf("bitmask: %w: mask payload is %d bytes, want 8", ErrInvalidEncoding, len(payload))
+	}
+	*m = Mask(binary.LittleEndian.Uint64(payload))
+
+	return nil
+}
+
+// MarshalText encodes the set as comma-separated IDs, e.g. "1,3,70".
+func (b *BitSet) MarshalText() ([]byte, error) {
+	return appendIDs(nil, b.Bits()), nil
+}
+
+// UnmarshalText decodes comma-separated IDs as written by MarshalText,
+// replacing the contents of b. IDs must be below 2^30, the same limit
+// UnmarshalRLE applies, so untrusted input cannot force a huge allocation.
+func (b *BitSet) UnmarshalText(text []byte) error {
+	ids, err := parseIDs(string(text))
+	if err != nil {
+		return err
+	}
+
+	var out BitSet
+	for _, id := range ids {
+		if id < 0 {
+			return fmt.Errorf("bitmask: %w: negative ID %d", ErrInvalidEncoding, id)
+		}
+		if id >= maxRLEBits {
+			return fmt.Errorf("bitmask: %w: ID %d exceeds %d", ErrInvalidEncoding, id, maxRLEBits-1)
+		}
+		out.Set(id)
+	}
+	*b = out
+
+	return nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of 64-bit words as
+// a uvarint, and the words in little-endian order:
+//
+//	[0x01][uvarint n][n × 8 bytes, least significant first]
+//
+// Trailing empty words are not written, so equal sets always encode to the same
+// bytes.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	words := b.Clone().trim().words
+
+	out := make([]byte, 0, 1+binary.MaxVarintLen64+8*len(words))
+	out = append(out, binaryVersion)
+	out = binary.AppendUvarint(out, uint64(len(words)))
+	for _, w := range words {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary decodes the format written by MarshalBinary, replacing the
+// contents of b.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	payload, err := checkVersion(data)
+	if err != nil {
+		return err
+	}
+
+	n, size := binary.Uvarint(payload)
+	if size <= 0 {
+		return fmt.Errorf("bitmask: %w: bad word count", ErrInvalidEncoding)
+	}
+	payload = payload[size:]
+	if n > uint64(len(payload))/8 || uint64(len(payload)) != 8*n {
+		return fmt.Errorf("bitmask: %w: %d words need %d bytes, got %d", ErrInvalidEncoding, n, 8*n, len(payload))
+	}
+
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(payload[8*i:])
+	}
+	b.words = words
+
+	return nil
+}
+
+func checkVersion(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("bitmask: %w: empty input", ErrInvalidEncoding)
+	}
+	if data[0] != binaryVersion {
+		return nil, fmt.Errorf("bitmask: %w: unsupported format version %d", ErrInvalidEncoding, data[0])
+	}
+
+	return data[1:], nil
+}
+
+func appendIDs(b []byte, ids iter.Seq[int]) []byte {
+	first := true
+	for id := range ids {
+		if !first {
+			b = append(b, ',')
+		}
+		first = false
+		b = strconv.AppendInt(b, int64(id), 10)
+	}
+
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
+
+func parseIDs(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	ids := make([]int, len(parts))
+	for i, p := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("bitmask: %w: bad ID %q", ErrInvalidEncoding, strings.TrimSpace(p))
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
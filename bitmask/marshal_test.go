@@ -0,0 +1,168 @@
+package bitmask
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskText(t *testing.T) {
+	b, err := Mask(42).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1,3,5", string(b))
+
+	b, err = Mask(0).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(b))
+
+	tests := []struct {
+		name     string
+		input    string
+		expected Mask
+		err      string
+	}{
+		{
+			name:     "ids",
+			input:    "1,3,5",
+			expected: 42,
+		},
+		{
+			name:     "spaces",
+			input:    " 5, 1 ,3 ",
+			expected: 42,
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: 0,
+		},
+		{
+			name:  "bad id",
+			input: "1,x",
+			err:   `bitmask: invalid encoding: bad ID "x"`,
+		},
+		{
+			name:     "sign bit",
+			input:    "0,63",
+			expected: Mask(-1<<63 | 1),
+		},
+		{
+			name:  "out of range",
+			input: "1,99",
+			err:   "bitmask: ID 99 out of range [0, 63]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Mask(7)
+			err := m.UnmarshalText([]byte(tt.input))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, m, tt.name)
+		})
+	}
+}
+
+func TestMaskTextRoundTrip(t *testing.T) {
+	for _, in := range []Mask{0, 42, 1 << MaxBit, -1 << 63, -1} {
+		b, err := in.MarshalText()
+		assert.NoError(t, err)
+
+		var out Mask
+		assert.NoError(t, out.UnmarshalText(b), string(b))
+		assert.Equal(t, in, out, string(b))
+	}
+}
+
+func TestMaskBinary(t *testing.T) {
+	b, err := Mask(0x0102).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 0x02, 0x01, 0, 0, 0, 0, 0, 0}, b, "version byte then little-endian")
+
+	var m Mask
+	assert.NoError(t, m.UnmarshalBinary(b))
+	assert.Equal(t, Mask(0x0102), m)
+
+	for _, bad := range [][]byte{nil, {2, 0, 0, 0, 0, 0, 0, 0, 0}, {1, 0, 0}} {
+		assert.ErrorIs(t, m.UnmarshalBinary(bad), ErrInvalidEncoding)
+	}
+	assert.EqualError(t, m.UnmarshalBinary([]byte{9}), "bitmask: invalid encoding: unsupported format version 9")
+}
+
+func TestBitSetText(t *testing.T) {
+	b, err := bitSetOf(1, 3, 70).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1,3,70", string(b))
+
+	var out BitSet
+	assert.NoError(t, out.UnmarshalText([]byte("70, 1,3")))
+	assert.Equal(t, []int{1, 3, 70}, setBits(&out))
+
+	assert.NoError(t, out.UnmarshalText([]byte("")))
+	assert.True(t, out.IsEmpty(), "contents are replaced")
+
+	assert.EqualError(t, out.UnmarshalText([]byte("1,-4")), "bitmask: invalid encoding: negative ID -4")
+	assert.ErrorIs(t, out.UnmarshalText([]byte("a")), ErrInvalidEncoding)
+	assert.EqualError(t, out.UnmarshalText([]byte("1,1073741824")), "bitmask: invalid encoding: ID 1073741824 exceeds 1073741823")
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"4611686018427387904"`), &out), ErrInvalidEncoding)
+}
+
+func TestBitSetBinary(t *testing.T) {
+	in := bitSetOf(0, 64, 65)
+	in.Set(500)
+	in.Clear(500)
+
+	b, err := in.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		1,                      // version
+		2,                      // two words, trailing empty words dropped
+		1, 0, 0, 0, 0, 0, 0, 0, // bit 0
+		3, 0, 0, 0, 0, 0, 0, 0, // bits 64 and 65
+	}, b)
+
+	out := bitSetOf(7)
+	assert.NoError(t, out.UnmarshalBinary(b))
+	assert.True(t, in.Equal(out))
+	assert.Equal(t, []int{0, 64, 65}, setBits(out))
+
+	empty, err := (&BitSet{}).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 0}, empty)
+
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			err:   "bitmask: invalid encoding: empty input",
+		},
+		{
+			name:  "bad count",
+			input: []byte{1},
+			err:   "bitmask: invalid encoding: bad word count",
+		},
+		{
+			name:  "truncated",
+			input: []byte{1, 2, 1, 0, 0, 0, 0, 0, 0, 0},
+			err:   "bitmask: invalid encoding: 2 words need 16 bytes, got 8",
+		},
+		{
+			name:  "huge count",
+			input: []byte{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+			err:   "bitmask: invalid encoding",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, out.UnmarshalBinary(tt.input), tt.err, tt.name)
+		})
+	}
+}
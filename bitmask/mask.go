@@ -0,0 +1,138 @@
+package bitmask
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Mask is an int bitmask that marshals to JSON as the sorted list of its set IDs,
+// so API payloads stay readable while the value is stored as a single integer.
+//
+// Example:
+//
+//	type User struct {
+//		Roles bitmask.Mask `json:"roles"`
+//	}
+//	json.Marshal(User{Roles: 42}) => {"roles":[1,3,5]}
+//
+// Unmarshaling accepts the same list, and also a plain integer for payloads
+// written before the field switched to Mask.
+type Mask int
+
+// HasBit reports whether the bit at position id is set.
+func (m Mask) HasBit(id int) bool {
+	return HasBit(int(m), id)
+}
+
+// SetBit returns m with the bit at position id turned on.
+func (m Mask) SetBit(id int) Mask {
+	return Mask(SetBit(int(m), id))
+}
+
+// ClearBit returns m with the bit at position id turned off.
+func (m Mask) ClearBit(id int) Mask {
+	return Mask(ClearBit(int(m), id))
+}
+
+// ToggleBit returns m with the bit at position id flipped.
+func (m Mask) ToggleBit(id int) Mask {
+	return Mask(ToggleBit(int(m), id))
+}
+
+// IDs returns the set bit positions in ascending order.
+func (m Mask) IDs() []int {
+	ids := make([]int, 0, Count(int(m)))
+	for id := range Bits(int(m)) {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Count returns the number of set bits.
+func (m Mask) Count() int {
+	return Count(int(m))
+}
+
+// IsEmpty reports whether no bits are set.
+func (m Mask) IsEmpty() bool {
+	return m == 0
+}
+
+// Union returns the bits set in m or o.
+func (m Mask) Union(o Mask) Mask {
+	return m | o
+}
+
+// Intersect returns the bits set in both m and o.
+func (m Mask) Intersect(o Mask) Mask {
+	return m & o
+}
+
+// Difference returns the bits of m that are not set in o.
+func (m Mask) Difference(o Mask) Mask {
+	return m &^ o
+}
+
+// SymmetricDifference returns the bits set in exactly one of m and o.
+func (m Mask) SymmetricDifference(o Mask) Mask {
+	return m ^ o
+}
+
+// MarshalJSON encodes the mask as a JSON array of IDs, e.g. [1,3,5].
+func (m Mask) MarshalJSON() ([]byte, error) {
+	b := []byte{'['}
+	for i, id := range m.IDs() {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendInt(b, int64(id), 10)
+	}
+
+	return append(b, ']'), nil
+}
+
+// UnmarshalJSON decodes an array of IDs or a plain integer. IDs outside
+// [0, maskMaxBit] are rejected, and null leaves the mask unchanged.
+func (m *Mask) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '[' {
+		var n int
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("bitmask: mask must be an array of IDs or an integer: %w", err)
+		}
+		*m = Mask(n)
+		return nil
+	}
+
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return fmt.Errorf("bitmask: %w", err)
+	}
+	mask, err := encodeMask(ids)
+	if err != nil {
+		return err
+	}
+	*m = mask
+
+	return nil
+}
+
+// maskMaxBit is the highest ID a Mask's encodings accept. Unlike MaxBit it
+// includes the sign bit: a Mask can hold any int, such as a negative value
+// scanned from a BIGINT column, and the ID list it marshals to must decode back
+// to the same value.
+const maskMaxBit = strconv.IntSize - 1
+
+// encodeMask is EncodeStrict with maskMaxBit as the limit. WithMaxBit caps its
+// argument at MaxBit, so the limit is set directly.
+func encodeMask(ids []int) (Mask, error) {
+	mask, err := EncodeStrict(ids, func(c *strictConfig) { c.max = maskMaxBit })
+	return Mask(mask), err
+}
@@ -0,0 +1,169 @@
+package bitmask
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskMethods(t *testing.T) {
+	m := Mask(42)
+	assert.True(t, m.HasBit(3))
+	assert.False(t, m.HasBit(2))
+	assert.Equal(t, Mask(46), m.SetBit(2))
+	assert.Equal(t, Mask(42), m.SetBit(3))
+	assert.Equal(t, Mask(34), m.ClearBit(3))
+	assert.Equal(t, Mask(34), m.ToggleBit(3))
+	assert.Equal(t, []int{1, 3, 5}, m.IDs())
+	assert.Equal(t, []int{}, Mask(0).IDs())
+	assert.Equal(t, 3, m.Count())
+	assert.False(t, m.IsEmpty())
+	assert.True(t, Mask(0).IsEmpty())
+}
+
+func TestMaskSetOperations(t *testing.T) {
+	a, b := Mask(Encode([]int{1, 3})), Mask(Encode([]int{2, 3}))
+	assert.Equal(t, []int{1, 2, 3}, a.Union(b).IDs())
+	assert.Equal(t, []int{3}, a.Intersect(b).IDs())
+	assert.Equal(t, []int{1}, a.Difference(b).IDs())
+	assert.Equal(t, []int{1, 2}, a.SymmetricDifference(b).IDs())
+}
+
+func TestMaskMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     Mask
+		expected string
+	}{
+		{
+			name:     "empty",
+			mask:     0,
+			expected: `{"roles":[]}`,
+		},
+		{
+			name:     "multiple bits",
+			mask:     42,
+			expected: `{"roles":[1,3,5]}`,
+		},
+		{
+			name:     "high bit",
+			mask:     1 << 40,
+			expected: `{"roles":[40]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(struct {
+				Roles Mask `json:"roles"`
+			}{tt.mask})
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, string(b), tt.name)
+		})
+	}
+}
+
+func TestMaskUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Mask
+		err      string
+	}{
+		{
+			name:     "array",
+			input:    `[5, 1, 3, 3]`,
+			expected: 42,
+		},
+		{
+			name:     "empty array",
+			input:    `[]`,
+			expected: 0,
+		},
+		{
+			name:     "integer",
+			input:    `42`,
+			expected: 42,
+		},
+		{
+			name:     "null",
+			input:    `null`,
+			expected: 7,
+		},
+		{
+			name:     "sign bit",
+			input:    `[0, 63]`,
+			expected: Mask(-1<<63 | 1),
+		},
+		{
+			name:     "negative integer",
+			input:    `-2`,
+			expected: -2,
+		},
+		{
+			name:  "out of range",
+			input: `[1, 200]`,
+			err:   "bitmask: ID 200 out of range [0, 63]",
+		},
+		{
+			name:  "negative ID",
+			input: `[-1]`,
+			err:   "bitmask: ID -1 out of range [0, 63]",
+		},
+		{
+			name:  "not numbers",
+			input: `["a"]`,
+			err:   "bitmask: json: cannot unmarshal string",
+		},
+		{
+			name:  "wrong type",
+			input: `"42"`,
+			err:   "bitmask: mask must be an array of IDs or an integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v struct {
+				Roles Mask `json:"roles"`
+			}
+			v.Roles = 7
+			err := json.Unmarshal([]byte(`{"roles":`+tt.input+`}`), &v)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, v.Roles, tt.name)
+		})
+	}
+}
+
+func TestMaskJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		mask Mask
+	}{
+		{
+			name: "positive",
+			mask: Mask(Encode([]int{0, 9, 33, 62})),
+		},
+		{
+			name: "sign bit only",
+			mask: Mask(Encode([]int{63})),
+		},
+		{
+			name: "all bits",
+			mask: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.mask)
+			assert.NoError(t, err, tt.name)
+
+			var out Mask
+			assert.NoError(t, json.Unmarshal(b, &out), tt.name)
+			assert.Equal(t, tt.mask, out, tt.name)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package bitmask
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// BitMatrix is a fixed-size grid of bits, such as an adjacency matrix or an
+// occupancy map. Each row is stored as ceil(cols/64) consecutive words of one
+// []uint64, so row operations work a word at a time:
+//
+//	rows=3, cols=100 → 2 words per row
+//	words: [r0w0 r0w1 | r1w0 r1w1 | r2w0 r2w1]
+//	(1, 70) → words[1*2 + 70/64] = words[3], bit 70%64 = 6
+//
+// Bits beyond cols in the last word of a row are always zero. Methods panic if
+// a row or column is out of range. A BitMatrix is not safe for concurrent use.
+type BitMatrix struct {
+	rows, cols int
+	stride     int
+	words      []uint64
+}
+
+// NewBitMatrix returns a rows × cols matrix with every bit clear. It panics if
+// either dimension is negative.
+func NewBitMatrix(rows, cols int) *BitMatrix {
+	if rows < 0 || cols < 0 {
+		panic("bitmask: negative matrix dimension")
+	}
+
+	stride := (cols + wordBits - 1) / wordBits
+	return &BitMatrix{rows: rows, cols: cols, stride: stride, words: make([]uint64, rows*stride)}
+}
+
+// Rows returns the number of rows.
+func (m *BitMatrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns.
+func (m *BitMatrix) Cols() int {
+	return m.cols
+}
+
+// Get reports whether the bit at row r, column c is set.
+func (m *BitMatrix) Get(r, c int) bool {
+	i, bit := m.index(r, c)
+	return m.words[i]&bit != 0
+}
+
+// Set turns on the bit at row r, column c.
+func (m *BitMatrix) Set(r, c int) {
+	i, bit := m.index(r, c)
+	m.words[i] |= bit
+}
+
+// Clear turns off the bit at row r, column c.
+func (m *BitMatrix) Clear(r, c int) {
+	i, bit := m.index(r, c)
+	m.words[i] &^= bit
+}
+
+// Row returns a copy of row r as a BitSet of column indexes.
+//
+// Example:
+//
+//	adj.Row(2) => {0, 5} // node 2 has edges to nodes 0 and 5
+func (m *BitMatrix) Row(r int) *BitSet {
+	m.checkRow(r)
+	row := m.words[r*m.stride : (r+1)*m.stride]
+	return (&BitSet{words: append([]uint64(nil), row...)}).trim()
+}
+
+// Col returns a copy of column c as a BitSet of row indexes.
+func (m *BitMatrix) Col(c int) *BitSet {
+	m.checkCol(c)
+
+	out := NewBitSet(m.rows)
+	w, bit := c/wordBits, uint64(1)<<(c%wordBits)
+	for r := range m.rows {
+		if m.words[r*m.stride+w]&bit != 0 {
+			out.Set(r)
+		}
+	}
+
+	return out
+}
+
+// Count returns the number of set bits in the matrix.
+func (m *BitMatrix) Count() int {
+	n := 0
+	for _, w := range m.words {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+// And returns a new matrix with the bits set in both m and o. It panics if the
+// matrices have different dimensions.
+func (m *BitMatrix) And(o *BitMatrix) *BitMatrix {
+	m.checkSameSize(o)
+
+	out := NewBitMatrix(m.rows, m.cols)
+	for i, w := range m.words {
+		out.words[i] = w & o.words[i]
+	}
+
+	return out
+}
+
+// Or returns a new matrix with the bits set in m or o. It panics if the
+// matrices have different dimensions.
+func (m *BitMatrix) Or(o *BitMatrix) *BitMatrix {
+	m.checkSameSize(o)
+
+	out := NewBitMatrix(m.rows, m.cols)
+	for i, w := range m.words {
+		out.words[i] = w | o.words[i]
+	}
+
+	return out
+}
+
+// AndRow replaces row dst with row dst AND row src, in place.
+func (m *BitMatrix) AndRow(dst, src int) {
+	m.checkRow(dst)
+	m.checkRow(src)
+	for i := range m.stride {
+		m.words[dst*m.stride+i] &= m.words[src*m.stride+i]
+	}
+}
+
+// OrRow replaces row dst with row dst OR row src, in place. In an adjacency
+// matrix, it gives dst every edge src has; repeating it for each row that has
+// an edge to k, for each k, computes the transitive closure (Warshall's
+// algorithm) one word at a time.
+//
+// Example:
+//
+//	for k := range n {
+//		for i := range n {
+//			if reach.Get(i, k) {
+//				reach.OrRow(i, k)
+//			}
+//		}
+//	}
+func (m *BitMatrix) OrRow(dst, src int) {
+	m.checkRow(dst)
+	m.checkRow(src)
+	for i := range m.stride {
+		m.words[dst*m.stride+i] |= m.words[src*m.stride+i]
+	}
+}
+
+// Transpose returns a new cols × rows matrix with bit (r, c) of m at (c, r).
+func (m *BitMatrix) Transpose() *BitMatrix {
+	out := NewBitMatrix(m.cols, m.rows)
+	for r := range m.rows {
+		for i, w := range m.words[r*m.stride : (r+1)*m.stride] {
+			for ; w != 0; w &= w - 1 {
+				c := i*wordBits + bits.TrailingZeros64(w)
+				out.words[c*out.stride+r/wordBits] |= 1 << (r % wordBits)
+			}
+		}
+	}
+
+	return out
+}
+
+// Equal reports whether m and o have the same dimensions and bits.
+func (m *BitMatrix) Equal(o *BitMatrix) bool {
+	if m.rows != o.rows || m.cols != o.cols {
+		return false
+	}
+	for i, w := range m.words {
+		if w != o.words[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a copy of m.
+func (m *BitMatrix) Clone() *BitMatrix {
+	out := *m
+	out.words = append([]uint64(nil), m.words...)
+	return &out
+}
+
+// String draws the matrix one row per line, with '1' for set bits and '.' for
+// clear ones.
+func (m *BitMatrix) String() string {
+	b := make([]byte, 0, m.rows*(m.cols+1))
+	for r := range m.rows {
+		for c := range m.cols {
+			if m.Get(r, c) {
+				b = append(b, '1')
+			} else {
+				b = append(b, '.')
+			}
+		}
+		b = append(b, '\n')
+	}
+
+	return string(b)
+}
+
+func (m *BitMatrix) index(r, c int) (int, uint64) {
+	m.checkRow(r)
+	m.checkCol(c)
+	return r*m.stride + c/wordBits, 1 << (c % wordBits)
+}
+
+func (m *BitMatrix) checkRow(r int) {
+	if r < 0 || r >= m.rows {
+		panic(fmt.Sprintf("bitmask: row %d out of range [0, %d)", r, m.rows))
+	}
+}
+
+func (m *BitMatrix) checkCol(c int) {
+	if c < 0 || c >= m.cols {
+		panic(fmt.Sprintf("bitmask: column %d out of range [0, %d)", c, m.cols))
+	}
+}
+
+func (m *BitMatrix) checkSameSize(o *BitMatrix) {
+	if m.rows != o.rows || m.cols != o.cols {
+		panic(fmt.Sprintf("bitmask: matrix size mismatch: %d×%d and %d×%d", m.rows, m.cols, o.rows, o.cols))
+	}
+}
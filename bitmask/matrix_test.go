@@ -0,0 +1,117 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitMatrix(t *testing.T) {
+	m := NewBitMatrix(3, 100)
+	assert.Equal(t, 3, m.Rows())
+	assert.Equal(t, 100, m.Cols())
+	assert.Len(t, m.words, 6)
+
+	m.Set(0, 0)
+	m.Set(1, 70)
+	m.Set(1, 99)
+	m.Set(2, 63)
+	m.Set(2, 64)
+	assert.True(t, m.Get(1, 70))
+	assert.False(t, m.Get(0, 70))
+	assert.Equal(t, 5, m.Count())
+
+	m.Clear(1, 99)
+	assert.False(t, m.Get(1, 99))
+
+	assert.Equal(t, []int{70}, setBits(m.Row(1)))
+	assert.Equal(t, []int{63, 64}, setBits(m.Row(2)))
+	assert.Equal(t, []int{2}, setBits(m.Col(64)))
+	assert.Equal(t, []int{}, setBits(m.Col(1)))
+
+	row := m.Row(0)
+	row.Set(5)
+	assert.False(t, m.Get(0, 5), "Row returns a copy")
+}
+
+func TestBitMatrixPanics(t *testing.T) {
+	m := NewBitMatrix(2, 3)
+	assert.PanicsWithValue(t, "bitmask: row 2 out of range [0, 2)", func() { m.Set(2, 0) })
+	assert.PanicsWithValue(t, "bitmask: column 3 out of range [0, 3)", func() { m.Get(0, 3) })
+	assert.PanicsWithValue(t, "bitmask: column -1 out of range [0, 3)", func() { m.Col(-1) })
+	assert.PanicsWithValue(t, "bitmask: matrix size mismatch: 2×3 and 3×2", func() { m.And(NewBitMatrix(3, 2)) })
+	assert.PanicsWithValue(t, "bitmask: negative matrix dimension", func() { NewBitMatrix(-1, 2) })
+}
+
+func TestBitMatrixAndOr(t *testing.T) {
+	a := NewBitMatrix(2, 70)
+	a.Set(0, 1)
+	a.Set(1, 65)
+	a.Set(1, 69)
+	b := NewBitMatrix(2, 70)
+	b.Set(0, 2)
+	b.Set(1, 65)
+
+	and := a.And(b)
+	assert.Equal(t, 1, and.Count())
+	assert.True(t, and.Get(1, 65))
+
+	or := a.Or(b)
+	assert.Equal(t, 4, or.Count())
+	assert.True(t, or.Get(0, 2))
+	assert.Equal(t, 3, a.Count(), "inputs are unchanged")
+
+	a.OrRow(0, 1)
+	assert.Equal(t, []int{1, 65, 69}, setBits(a.Row(0)))
+	a.AndRow(1, 0)
+	assert.Equal(t, []int{65, 69}, setBits(a.Row(1)))
+	a.AndRow(0, 1)
+	assert.Equal(t, []int{65, 69}, setBits(a.Row(0)))
+}
+
+func TestBitMatrixTranspose(t *testing.T) {
+	m := NewBitMatrix(2, 130)
+	m.Set(0, 0)
+	m.Set(0, 129)
+	m.Set(1, 64)
+
+	tr := m.Transpose()
+	assert.Equal(t, 130, tr.Rows())
+	assert.Equal(t, 2, tr.Cols())
+	assert.True(t, tr.Get(0, 0))
+	assert.True(t, tr.Get(129, 0))
+	assert.True(t, tr.Get(64, 1))
+	assert.Equal(t, 3, tr.Count())
+	assert.True(t, m.Equal(tr.Transpose()))
+}
+
+func TestBitMatrixTransitiveClosure(t *testing.T) {
+	// 0 → 1 → 2 → 3, 4 isolated.
+	reach := NewBitMatrix(5, 5)
+	reach.Set(0, 1)
+	reach.Set(1, 2)
+	reach.Set(2, 3)
+
+	for k := range 5 {
+		for i := range 5 {
+			if reach.Get(i, k) {
+				reach.OrRow(i, k)
+			}
+		}
+	}
+
+	assert.Equal(t, ".111.\n..11.\n...1.\n.....\n.....\n", reach.String())
+}
+
+func TestBitMatrixEqualClone(t *testing.T) {
+	m := NewBitMatrix(2, 2)
+	m.Set(1, 0)
+
+	c := m.Clone()
+	assert.True(t, m.Equal(c))
+	c.Set(0, 0)
+	assert.False(t, m.Equal(c))
+	assert.False(t, m.Get(0, 0))
+	assert.False(t, m.Equal(NewBitMatrix(2, 3)))
+	assert.Equal(t, "..\n1.\n", m.String())
+}
@@ -0,0 +1,100 @@
+package bitmask
+
+// Morton codes (Z-order) interleave the bits of 2 or 3 coordinates into one
+// number, so points close together in space tend to have close codes. Sorting
+// by code gives a Z-shaped traversal, and a prefix of the code names a
+// quadtree or octree cell:
+//
+//	x = 0b11, y = 0b01
+//	Interleave2(x, y) = y1 x1 y0 x0 = 0 1 1 1 = 0b0111
+//
+// The bits are spread with the "magic numbers" method: each step moves halves
+// of the remaining groups apart with a shift and masks off what moved too far,
+// so a 32-bit coordinate takes five steps instead of a 32-iteration loop.
+
+// morton3Max is the largest coordinate Interleave3 keeps: 21 bits, so three of
+// them fit in 63 bits.
+const morton3Max = 1<<21 - 1
+
+// Interleave2 returns the 2D Morton code of (x, y): bit i of x goes to bit 2i,
+// and bit i of y to bit 2i+1.
+//
+// Example:
+//
+//	Interleave2(3, 1) => 0b0111 (7)
+//	Interleave2(5, 0) => 0b010001 (17)
+func Interleave2(x, y uint32) uint64 {
+	return spread2(x) | spread2(y)<<1
+}
+
+// Deinterleave2 returns the coordinates encoded in a 2D Morton code, undoing
+// Interleave2.
+func Deinterleave2(code uint64) (x, y uint32) {
+	return compact2(code), compact2(code >> 1)
+}
+
+// Interleave3 returns the 3D Morton code of (x, y, z): bit i of x goes to bit
+// 3i, of y to 3i+1, and of z to 3i+2. Only the low 21 bits of each coordinate
+// are used.
+//
+// Example:
+//
+//	Interleave3(1, 1, 1) => 0b111 (7)
+//	Interleave3(2, 0, 1) => 0b001100 (12)
+func Interleave3(x, y, z uint32) uint64 {
+	return spread3(x) | spread3(y)<<1 | spread3(z)<<2
+}
+
+// Deinterleave3 returns the coordinates encoded in a 3D Morton code, undoing
+// Interleave3.
+func Deinterleave3(code uint64) (x, y, z uint32) {
+	return compact3(code), compact3(code >> 1), compact3(code >> 2)
+}
+
+// spread2 inserts a 0 bit after each bit of v: abcd → 0a0b0c0d.
+func spread2(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+
+	return x
+}
+
+// compact2 keeps the even bits of x and packs them together, undoing spread2.
+func compact2(x uint64) uint32 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+
+	return uint32(x)
+}
+
+// spread3 inserts two 0 bits after each of the low 21 bits of v: abc → 00a00b00c.
+func spread3(v uint32) uint64 {
+	x := uint64(v) & morton3Max
+	x = (x | x<<32) & 0x001F00000000FFFF
+	x = (x | x<<16) & 0x001F0000FF0000FF
+	x = (x | x<<8) & 0x100F00F00F00F00F
+	x = (x | x<<4) & 0x10C30C30C30C30C3
+	x = (x | x<<2) & 0x1249249249249249
+
+	return x
+}
+
+// compact3 keeps every third bit of x, starting at bit 0, undoing spread3.
+func compact3(x uint64) uint32 {
+	x &= 0x1249249249249249
+	x = (x | x>>2) & 0x10C30C30C30C30C3
+	x = (x | x>>4) & 0x100F00F00F00F00F
+	x = (x | x>>8) & 0x001F0000FF0000FF
+	x = (x | x>>16) & 0x001F00000000FFFF
+	x = (x | x>>32) & morton3Max
+
+	return uint32(x)
+}
@@ -0,0 +1,139 @@
+package bitmask
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// interleaveLoop is the bit-by-bit reference the magic-number versions are
+// checked against.
+func interleaveLoop(dims int, coords ...uint32) uint64 {
+	var code uint64
+	for i := 0; i*dims < 64; i++ {
+		for d, c := range coords {
+			if i < 32 && c>>i&1 == 1 && i*dims+d < 64 {
+				code |= 1 << (i*dims + d)
+			}
+		}
+	}
+
+	return code
+}
+
+func TestInterleave2(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     uint32
+		expected uint64
+	}{
+		{
+			name:     "zero",
+			x:        0,
+			y:        0,
+			expected: 0,
+		},
+		{
+			name:     "small",
+			x:        3,
+			y:        1,
+			expected: 0b0111,
+		},
+		{
+			name:     "x only",
+			x:        5,
+			y:        0,
+			expected: 0b010001,
+		},
+		{
+			name:     "y only",
+			x:        0,
+			y:        5,
+			expected: 0b100010,
+		},
+		{
+			name:     "max",
+			x:        math.MaxUint32,
+			y:        math.MaxUint32,
+			expected: math.MaxUint64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := Interleave2(tt.x, tt.y)
+			assert.Equal(t, tt.expected, code, tt.name)
+			x, y := Deinterleave2(code)
+			assert.Equal(t, tt.x, x, tt.name)
+			assert.Equal(t, tt.y, y, tt.name)
+		})
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 1000 {
+		x, y := r.Uint32(), r.Uint32()
+		code := Interleave2(x, y)
+		assert.Equal(t, interleaveLoop(2, x, y), code)
+		gx, gy := Deinterleave2(code)
+		assert.Equal(t, x, gx)
+		assert.Equal(t, y, gy)
+	}
+}
+
+func TestInterleave3(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y, z  uint32
+		expected uint64
+	}{
+		{
+			name:     "ones",
+			x:        1,
+			y:        1,
+			z:        1,
+			expected: 0b111,
+		},
+		{
+			name:     "mixed",
+			x:        2,
+			y:        0,
+			z:        1,
+			expected: 0b001100,
+		},
+		{
+			name:     "max",
+			x:        morton3Max,
+			y:        morton3Max,
+			z:        morton3Max,
+			expected: 1<<63 - 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := Interleave3(tt.x, tt.y, tt.z)
+			assert.Equal(t, tt.expected, code, tt.name)
+			x, y, z := Deinterleave3(code)
+			assert.Equal(t, []uint32{tt.x, tt.y, tt.z}, []uint32{x, y, z}, tt.name)
+		})
+	}
+
+	assert.Equal(t, Interleave3(1, 0, 0), Interleave3(1<<21|1, 0, 0), "bits above 21 are dropped")
+
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 1000 {
+		x, y, z := r.Uint32()&morton3Max, r.Uint32()&morton3Max, r.Uint32()&morton3Max
+		code := Interleave3(x, y, z)
+		assert.Equal(t, interleaveLoop(3, x, y, z), code)
+		gx, gy, gz := Deinterleave3(code)
+		assert.Equal(t, []uint32{x, y, z}, []uint32{gx, gy, gz})
+	}
+}
+
+func BenchmarkInterleave2(b *testing.B) {
+	var sink uint64
+	for i := 0; i < b.N; i++ {
+		sink += Interleave2(uint32(i), uint32(i>>3))
+	}
+	_ = sink
+}
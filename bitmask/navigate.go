@@ -0,0 +1,114 @@
+package bitmask
+
+import "math/bits"
+
+// NextSetBit returns the lowest set bit at position from or above. ok is false
+// if there is none. A negative from searches from bit 0.
+//
+// It masks off the bits below from and takes `bits.TrailingZeros` of the rest,
+// so it costs the same wherever the next bit is.
+//
+// Example:
+//
+//	mask = 00101010 (decimal 42)
+//	NextSetBit(42, 2) => 3, true
+//	NextSetBit(42, 6) => 0, false
+func NextSetBit(mask int, from int) (id int, ok bool) {
+	from = max(from, 0)
+	if from >= bits.UintSize {
+		return 0, false
+	}
+
+	m := uint(mask) >> from << from
+	if m == 0 {
+		return 0, false
+	}
+
+	return bits.TrailingZeros(m), true
+}
+
+// PrevSetBit returns the highest set bit at position from or below. ok is false
+// if there is none. A from beyond the width of int searches from the top bit.
+//
+// Example:
+//
+//	mask = 00101010 (decimal 42)
+//	PrevSetBit(42, 4) => 3, true
+//	PrevSetBit(42, 0) => 0, false
+func PrevSetBit(mask int, from int) (id int, ok bool) {
+	if from < 0 {
+		return 0, false
+	}
+	from = min(from, bits.UintSize-1)
+
+	m := uint(mask) << (bits.UintSize - 1 - from)
+	if m == 0 {
+		return 0, false
+	}
+
+	return from - bits.LeadingZeros(m), true
+}
+
+// NextClearBit returns the lowest clear bit at position from or above, such as
+// the next free slot in an allocation mask. ok is false if every bit from there
+// to the top of the int is set. A negative from searches from bit 0.
+//
+// Example:
+//
+//	mask = 00101111 (decimal 47)
+//	NextClearBit(47, 0) => 4, true
+//	NextClearBit(47, 5) => 6, true
+func NextClearBit(mask int, from int) (id int, ok bool) {
+	return NextSetBit(^mask, from)
+}
+
+// LowestSet returns the lowest set bit. ok is false if mask is empty.
+//
+// Example:
+//
+//	mask = 00101000 (decimal 40)
+//	LowestSet(40) => 3, true
+//	LowestSet(0)  => 0, false
+func LowestSet(mask int) (id int, ok bool) {
+	if mask == 0 {
+		return 0, false
+	}
+
+	return bits.TrailingZeros(uint(mask)), true
+}
+
+// HighestSet returns the highest set bit. ok is false if mask is empty. For a
+// negative mask it is the sign bit, bits.UintSize-1.
+//
+// Example:
+//
+//	mask = 00101000 (decimal 40)
+//	HighestSet(40) => 5, true
+func HighestSet(mask int) (id int, ok bool) {
+	if mask == 0 {
+		return 0, false
+	}
+
+	return bits.Len(uint(mask)) - 1, true
+}
+
+// TrailingZeros returns the number of clear bits below the lowest set bit, or
+// bits.UintSize if mask is empty.
+//
+// Example:
+//
+//	TrailingZeros(40) => 3
+func TrailingZeros(mask int) int {
+	return bits.TrailingZeros(uint(mask))
+}
+
+// LeadingZeros returns the number of clear bits above the highest set bit,
+// counting from the top of the int, or bits.UintSize if mask is empty. A
+// negative mask has none.
+//
+// Example:
+//
+//	LeadingZeros(40) => 58 (on 64-bit platforms)
+func LeadingZeros(mask int) int {
+	return bits.LeadingZeros(uint(mask))
+}
@@ -0,0 +1,289 @@
+package bitmask
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextSetBit(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		from     int
+		expected int
+		ok       bool
+	}{
+		{
+			name:     "at from",
+			mask:     42,
+			from:     1,
+			expected: 1,
+			ok:       true,
+		},
+		{
+			name:     "above from",
+			mask:     42,
+			from:     2,
+			expected: 3,
+			ok:       true,
+		},
+		{
+			name:     "negative from",
+			mask:     42,
+			from:     -5,
+			expected: 1,
+			ok:       true,
+		},
+		{
+			name:     "none above",
+			mask:     42,
+			from:     6,
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "empty",
+			mask:     0,
+			from:     0,
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "top bit",
+			mask:     -1,
+			from:     bits.UintSize - 1,
+			expected: bits.UintSize - 1,
+			ok:       true,
+		},
+		{
+			name:     "past the end",
+			mask:     -1,
+			from:     bits.UintSize,
+			expected: 0,
+			ok:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := NextSetBit(tt.mask, tt.from)
+			assert.Equal(t, tt.expected, id, tt.name)
+			assert.Equal(t, tt.ok, ok, tt.name)
+		})
+	}
+}
+
+func TestPrevSetBit(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		from     int
+		expected int
+		ok       bool
+	}{
+		{
+			name:     "at from",
+			mask:     42,
+			from:     5,
+			expected: 5,
+			ok:       true,
+		},
+		{
+			name:     "below from",
+			mask:     42,
+			from:     4,
+			expected: 3,
+			ok:       true,
+		},
+		{
+			name:     "none below",
+			mask:     42,
+			from:     0,
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "negative from",
+			mask:     42,
+			from:     -1,
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "empty",
+			mask:     0,
+			from:     10,
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "past the end",
+			mask:     42,
+			from:     1000,
+			expected: 5,
+			ok:       true,
+		},
+		{
+			name:     "top bit",
+			mask:     -1,
+			from:     1000,
+			expected: bits.UintSize - 1,
+			ok:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := PrevSetBit(tt.mask, tt.from)
+			assert.Equal(t, tt.expected, id, tt.name)
+			assert.Equal(t, tt.ok, ok, tt.name)
+		})
+	}
+}
+
+func TestNextClearBit(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		from     int
+		expected int
+		ok       bool
+	}{
+		{
+			name:     "first gap",
+			mask:     47,
+			from:     0,
+			expected: 4,
+			ok:       true,
+		},
+		{
+			name:     "at from",
+			mask:     47,
+			from:     4,
+			expected: 4,
+			ok:       true,
+		},
+		{
+			name:     "after from",
+			mask:     47,
+			from:     5,
+			expected: 6,
+			ok:       true,
+		},
+		{
+			name:     "empty",
+			mask:     0,
+			from:     0,
+			expected: 0,
+			ok:       true,
+		},
+		{
+			name:     "full",
+			mask:     -1,
+			from:     0,
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "negative from",
+			mask:     1,
+			from:     -3,
+			expected: 1,
+			ok:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := NextClearBit(tt.mask, tt.from)
+			assert.Equal(t, tt.expected, id, tt.name)
+			assert.Equal(t, tt.ok, ok, tt.name)
+		})
+	}
+}
+
+func TestLowestHighestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		mask    int
+		lowest  int
+		highest int
+		ok      bool
+	}{
+		{
+			name: "empty",
+			mask: 0,
+		},
+		{
+			name:    "one bit",
+			mask:    1 << 7,
+			lowest:  7,
+			highest: 7,
+			ok:      true,
+		},
+		{
+			name:    "several bits",
+			mask:    40,
+			lowest:  3,
+			highest: 5,
+			ok:      true,
+		},
+		{
+			name:    "negative",
+			mask:    -8,
+			lowest:  3,
+			highest: bits.UintSize - 1,
+			ok:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lowest, ok := LowestSet(tt.mask)
+			assert.Equal(t, tt.lowest, lowest, tt.name)
+			assert.Equal(t, tt.ok, ok, tt.name)
+
+			highest, ok := HighestSet(tt.mask)
+			assert.Equal(t, tt.highest, highest, tt.name)
+			assert.Equal(t, tt.ok, ok, tt.name)
+		})
+	}
+}
+
+func TestTrailingLeadingZeros(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		trailing int
+		leading  int
+	}{
+		{
+			name:     "empty",
+			mask:     0,
+			trailing: bits.UintSize,
+			leading:  bits.UintSize,
+		},
+		{
+			name:     "bit 0",
+			mask:     1,
+			trailing: 0,
+			leading:  bits.UintSize - 1,
+		},
+		{
+			name:     "several bits",
+			mask:     40,
+			trailing: 3,
+			leading:  bits.UintSize - 6,
+		},
+		{
+			name:     "negative",
+			mask:     -1,
+			trailing: 0,
+			leading:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.trailing, TrailingZeros(tt.mask), tt.name)
+			assert.Equal(t, tt.leading, LeadingZeros(tt.mask), tt.name)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package bitmask
+
+const raceEnabled = false
@@ -0,0 +1,165 @@
+package bitmask
+
+import (
+	"iter"
+	"sync"
+)
+
+// MaskChange describes one update of an ObservableMask.
+type MaskChange struct {
+	Old, New int
+}
+
+// Diff returns the IDs the update turned on and off.
+func (c MaskChange) Diff() Changes {
+	return DiffChanges(c.Old, c.New)
+}
+
+// Bits returns an iterator over the bits the update changed and their new
+// values, in ascending order of bit.
+//
+// Example:
+//
+//	for bit, on := range change.Bits() {
+//		log.Printf("flag %d is now %v", bit, on)
+//	}
+func (c MaskChange) Bits() iter.Seq2[int, bool] {
+	return func(yield func(int, bool) bool) {
+		for bit := range Bits(c.Old ^ c.New) {
+			if !yield(bit, HasBit(c.New, bit)) {
+				return
+			}
+		}
+	}
+}
+
+// ObservableMask is an AtomicMask that notifies subscribers of every change,
+// for propagating feature flags to the parts of a process that care.
+//
+// Reads are lock-free. Updates are serialized, so subscribers see changes in
+// the order they were made, and each MaskChange's Old is the previous one's New.
+// Updates that leave the mask unchanged, such as setting a bit that is already
+// set, send nothing.
+//
+// Example:
+//
+//	var flags bitmask.ObservableMask
+//	changes, cancel := flags.Subscribe(16)
+//	defer cancel()
+//
+//	go func() {
+//		for c := range changes {
+//			log.Printf("feature flags: %v", c.Diff())
+//		}
+//	}()
+//	flags.Set(FeatureBeta)
+//
+// The zero value is an empty mask with no subscribers, ready to use.
+type ObservableMask struct {
+	mask AtomicMask
+
+	mu     sync.Mutex
+	subs   map[chan MaskChange]struct{}
+	closed bool
+}
+
+// Load returns the current mask.
+func (m *ObservableMask) Load() int {
+	return m.mask.Load()
+}
+
+// HasBit reports whether the bit at position id is currently set.
+func (m *ObservableMask) HasBit(id int) bool {
+	return m.mask.HasBit(id)
+}
+
+// Set turns on the bit at position id and returns the mask as it was before.
+func (m *ObservableMask) Set(id int) (old int) {
+	return m.update(func() int { return m.mask.Set(id) })
+}
+
+// Clear turns off the bit at position id and returns the mask as it was before.
+func (m *ObservableMask) Clear(id int) (old int) {
+	return m.update(func() int { return m.mask.Clear(id) })
+}
+
+// Toggle flips the bit at position id and returns the mask as it was before.
+func (m *ObservableMask) Toggle(id int) (old int) {
+	return m.update(func() int { return m.mask.Toggle(id) })
+}
+
+// Store replaces the mask and returns the mask as it was before.
+func (m *ObservableMask) Store(mask int) (old int) {
+	return m.update(func() int {
+		old := m.mask.Load()
+		m.mask.Store(mask)
+		return old
+	})
+}
+
+// Subscribe returns a channel that receives every later change, and a function
+// that unsubscribes and closes the channel.
+//
+// Changes are sent without blocking: if the channel's buffer of size buffer is
+// full, the change is dropped for that subscriber, so a slow subscriber can't
+// hold up updates. Subscribers that must not miss a change should use a buffer
+// large enough for bursts, and can always Load the current mask.
+func (m *ObservableMask) Subscribe(buffer int) (<-chan MaskChange, func()) {
+	ch := make(chan MaskChange, buffer)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if m.subs == nil {
+		m.subs = make(map[chan MaskChange]struct{})
+	}
+	m.subs[ch] = struct{}{}
+
+	return ch, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if _, ok := m.subs[ch]; ok {
+			delete(m.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close closes every subscriber's channel. Later Subscribe calls get a closed
+// channel; updates still apply but notify no one.
+func (m *ObservableMask) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for ch := range m.subs {
+		close(ch)
+	}
+	m.subs = nil
+	m.closed = true
+}
+
+// update applies fn, which returns the previous mask, and notifies subscribers
+// if the mask changed.
+func (m *ObservableMask) update(fn func() int) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old := fn()
+	change := MaskChange{Old: old, New: m.mask.Load()}
+	if change.Old == change.New {
+		return old
+	}
+	for ch := range m.subs {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+
+	return old
+}
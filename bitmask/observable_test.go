@@ -0,0 +1,109 @@
+package bitmask
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskChange(t *testing.T) {
+	c := MaskChange{Old: 0b1010, New: 0b0110}
+	assert.Equal(t, Changes{Added: []int{2}, Removed: []int{3}}, c.Diff())
+
+	var bits []int
+	var values []bool
+	for bit, on := range c.Bits() {
+		bits = append(bits, bit)
+		values = append(values, on)
+	}
+	assert.Equal(t, []int{2, 3}, bits)
+	assert.Equal(t, []bool{true, false}, values)
+}
+
+func TestObservableMask(t *testing.T) {
+	var m ObservableMask
+	changes, cancel := m.Subscribe(10)
+
+	assert.Equal(t, 0, m.Set(1))
+	assert.Equal(t, 2, m.Set(1), "no change, no event")
+	assert.Equal(t, 2, m.Toggle(3))
+	assert.Equal(t, 10, m.Clear(1))
+	assert.Equal(t, 8, m.Store(5))
+	assert.Equal(t, 5, m.Load())
+	assert.True(t, m.HasBit(2))
+
+	cancel()
+	cancel()
+	m.Set(7)
+
+	var got []MaskChange
+	for c := range changes {
+		got = append(got, c)
+	}
+	assert.Equal(t, []MaskChange{
+		{Old: 0, New: 2},
+		{Old: 2, New: 10},
+		{Old: 10, New: 8},
+		{Old: 8, New: 5},
+	}, got)
+}
+
+func TestObservableMaskSlowSubscriber(t *testing.T) {
+	var m ObservableMask
+	slow, cancelSlow := m.Subscribe(1)
+	fast, cancelFast := m.Subscribe(10)
+	defer cancelSlow()
+	defer cancelFast()
+
+	m.Set(0)
+	m.Set(1)
+	m.Set(2)
+
+	assert.Equal(t, MaskChange{Old: 0, New: 1}, <-slow)
+	assert.Len(t, slow, 0, "later changes dropped for the full subscriber")
+	assert.Len(t, fast, 3)
+}
+
+func TestObservableMaskClose(t *testing.T) {
+	var m ObservableMask
+	ch, cancel := m.Subscribe(1)
+	m.Close()
+	cancel()
+
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	late, _ := m.Subscribe(1)
+	_, ok = <-late
+	assert.False(t, ok)
+
+	m.Set(4)
+	assert.Equal(t, 16, m.Load(), "updates still apply")
+}
+
+func TestObservableMaskConcurrent(t *testing.T) {
+	var m ObservableMask
+	changes, cancel := m.Subscribe(1000)
+
+	var wg sync.WaitGroup
+	for id := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Set(id)
+		}()
+	}
+	wg.Wait()
+	cancel()
+
+	prev := 0
+	n := 0
+	for c := range changes {
+		assert.Equal(t, prev, c.Old, "each change starts where the last ended")
+		prev = c.New
+		n++
+	}
+	assert.Equal(t, 50, n)
+	assert.Equal(t, m.Load(), prev)
+}
@@ -0,0 +1,124 @@
+package bitmask
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Permissions is a set of permissions of type P, where each P constant is a bit
+// position. It gives RBAC code named operations instead of raw bit arithmetic,
+// and the type parameter keeps permissions of different kinds from being mixed.
+//
+// Example:
+//
+//	type Perm int
+//
+//	const (
+//		Read Perm = iota
+//		Write
+//		Admin
+//	)
+//
+//	var p bitmask.Permissions[Perm]
+//	p.Grant(Read, Write)
+//	p.HasAll(Read, Write) => true
+//	p.HasAny(Admin)       => false
+//	p.Mask()              => 3, for storage
+//
+// The zero value has no permissions. Permissions is not safe for concurrent use.
+type Permissions[P ~int] struct {
+	mask int
+}
+
+// NewPermissions returns a set holding the given permissions.
+func NewPermissions[P ~int](perms ...P) Permissions[P] {
+	var p Permissions[P]
+	p.Grant(perms...)
+	return p
+}
+
+// PermissionsFromMask returns a set holding the permissions whose bits are set
+// in mask, as stored by Mask.
+func PermissionsFromMask[P ~int](mask int) Permissions[P] {
+	return Permissions[P]{mask: mask}
+}
+
+// Grant adds the given permissions. It panics if a permission is outside
+// [0, MaxBit].
+func (p *Permissions[P]) Grant(perms ...P) {
+	p.mask |= permMask(perms)
+}
+
+// Revoke removes the given permissions. It panics if a permission is outside
+// [0, MaxBit].
+func (p *Permissions[P]) Revoke(perms ...P) {
+	p.mask &^= permMask(perms)
+}
+
+// HasAll reports whether every one of the given permissions is granted. With no
+// arguments it returns true. A permission outside [0, MaxBit] can never be
+// granted, so it makes HasAll return false.
+func (p Permissions[P]) HasAll(perms ...P) bool {
+	for _, perm := range perms {
+		if !permInRange(perm) {
+			return false
+		}
+	}
+
+	return IsSubset(permMask(perms), p.mask)
+}
+
+// HasAny reports whether at least one of the given permissions is granted. With
+// no arguments it returns false. Permissions outside [0, MaxBit] are never
+// granted.
+func (p Permissions[P]) HasAny(perms ...P) bool {
+	for _, perm := range perms {
+		if permInRange(perm) && HasBit(p.mask, int(perm)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// List returns the granted permissions in ascending order.
+func (p Permissions[P]) List() []P {
+	perms := make([]P, 0, Count(p.mask))
+	for id := range Bits(p.mask) {
+		perms = append(perms, P(id))
+	}
+
+	return perms
+}
+
+// Mask returns the permissions as an int mask, for storage.
+func (p Permissions[P]) Mask() int {
+	return p.mask
+}
+
+// String lists the granted permissions separated by "|", formatted with %v so
+// that a P with a String method prints its names: "Read|Write".
+func (p Permissions[P]) String() string {
+	names := make([]string, 0, Count(p.mask))
+	for _, perm := range p.List() {
+		names = append(names, fmt.Sprint(perm))
+	}
+
+	return strings.Join(names, "|")
+}
+
+func permInRange[P ~int](perm P) bool {
+	return perm >= 0 && int(perm) <= MaxBit
+}
+
+func permMask[P ~int](perms []P) int {
+	var mask int
+	for _, perm := range perms {
+		if !permInRange(perm) {
+			panic(fmt.Sprintf("bitmask: permission %d out of range [0, %d]", int(perm), MaxBit))
+		}
+		mask |= 1 << perm
+	}
+
+	return mask
+}
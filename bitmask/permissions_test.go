@@ -0,0 +1,114 @@
+package bitmask
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPerm int
+
+const (
+	permRead testPerm = iota
+	permWrite
+	permDelete
+	permAdmin
+)
+
+func (p testPerm) String() string {
+	return [...]string{"read", "write", "delete", "admin"}[p]
+}
+
+func TestPermissions(t *testing.T) {
+	var p Permissions[testPerm]
+	assert.Empty(t, p.List())
+	assert.Equal(t, "", p.String())
+
+	p.Grant(permRead, permWrite)
+	p.Grant(permWrite)
+	assert.Equal(t, []testPerm{permRead, permWrite}, p.List())
+	assert.Equal(t, 3, p.Mask())
+	assert.Equal(t, "read|write", p.String())
+	assert.Equal(t, "read|write", fmt.Sprint(p))
+
+	p.Revoke(permRead, permAdmin)
+	assert.Equal(t, []testPerm{permWrite}, p.List())
+}
+
+func TestPermissionsChecks(t *testing.T) {
+	p := NewPermissions(permRead, permDelete)
+
+	tests := []struct {
+		name   string
+		perms  []testPerm
+		hasAll bool
+		hasAny bool
+	}{
+		{
+			name:   "all granted",
+			perms:  []testPerm{permRead, permDelete},
+			hasAll: true,
+			hasAny: true,
+		},
+		{
+			name:   "some granted",
+			perms:  []testPerm{permRead, permWrite},
+			hasAll: false,
+			hasAny: true,
+		},
+		{
+			name:   "none granted",
+			perms:  []testPerm{permWrite, permAdmin},
+			hasAll: false,
+			hasAny: false,
+		},
+		{
+			name:   "no arguments",
+			perms:  nil,
+			hasAll: true,
+			hasAny: false,
+		},
+		{
+			name:   "past the top bit",
+			perms:  []testPerm{64},
+			hasAll: false,
+			hasAny: false,
+		},
+		{
+			name:   "granted and out of range",
+			perms:  []testPerm{permRead, 64},
+			hasAll: false,
+			hasAny: true,
+		},
+		{
+			name:   "negative",
+			perms:  []testPerm{-1},
+			hasAll: false,
+			hasAny: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.hasAll, p.HasAll(tt.perms...), "HasAll")
+			assert.Equal(t, tt.hasAny, p.HasAny(tt.perms...), "HasAny")
+		})
+	}
+}
+
+func TestPermissionsOutOfRange(t *testing.T) {
+	var p Permissions[testPerm]
+	assert.PanicsWithValue(t, fmt.Sprintf("bitmask: permission -1 out of range [0, %d]", MaxBit), func() { p.Grant(-1) })
+	assert.PanicsWithValue(t, fmt.Sprintf("bitmask: permission 64 out of range [0, %d]", MaxBit), func() { p.Grant(permRead, 64) })
+	assert.PanicsWithValue(t, fmt.Sprintf("bitmask: permission 63 out of range [0, %d]", MaxBit), func() { p.Revoke(63) })
+	assert.Zero(t, p.Mask(), "unchanged after a panic")
+}
+
+func TestPermissionsFromMask(t *testing.T) {
+	p := PermissionsFromMask[testPerm](0b1001)
+	assert.Equal(t, []testPerm{permRead, permAdmin}, p.List())
+	assert.Equal(t, p, NewPermissions(permAdmin, permRead))
+
+	type plain int
+	assert.Equal(t, "0|2", NewPermissions[plain](0, 2).String(), "without a String method")
+}
@@ -0,0 +1,47 @@
+package bitmask
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// decodeBuf is a pooled buffer with room for every bit of an int. Its release
+// function is created once with the buffer, so handing it out doesn't allocate.
+type decodeBuf struct {
+	ids     []int
+	release func()
+}
+
+var decodePool sync.Pool
+
+// init sets New here because the release functions it creates refer back to
+// decodePool, which a variable initializer can't do.
+func init() {
+	decodePool.New = func() any {
+		b := &decodeBuf{ids: make([]int, 0, bits.UintSize)}
+		b.release = func() { decodePool.Put(b) }
+		return b
+	}
+}
+
+// DecodePooled is like Decode but returns the IDs in a buffer borrowed from a
+// pool, so decoding doesn't allocate once the pool is warm. Call release when
+// done with ids; ids must not be used, and release must not be called again,
+// after that.
+//
+// It is meant for hot paths decoding millions of masks per second. Where the
+// caller can keep a buffer of its own, DecodeAppend is simpler.
+//
+// Example:
+//
+//	ids, release := bitmask.DecodePooled(mask)
+//	defer release()
+//	for _, id := range ids {
+//		...
+//	}
+func DecodePooled(mask int) (ids []int, release func()) {
+	b := decodePool.Get().(*decodeBuf)
+	b.ids = DecodeAppend(b.ids[:0], mask)
+
+	return b.ids, b.release
+}
@@ -0,0 +1,101 @@
+package bitmask
+
+import (
+	"math/bits"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodePooled(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		expected []int
+	}{
+		{
+			name:     "empty",
+			mask:     0,
+			expected: []int{},
+		},
+		{
+			name:     "small",
+			mask:     42,
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:     "all bits",
+			mask:     -1,
+			expected: seq(0, bits.UintSize),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, release := DecodePooled(tt.mask)
+			assert.Equal(t, tt.expected, ids, tt.name)
+			release()
+		})
+	}
+}
+
+func TestDecodePooledNoAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+
+	_, release := DecodePooled(42)
+	release()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, release := DecodePooled(42)
+		release()
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestDecodePooledConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				ids, release := DecodePooled(1 << i)
+				assert.Equal(t, []int{i}, ids)
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkDecodePooled(b *testing.B) {
+	mask := Encode([]int{1, 7, 13, 22, 40, 61})
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := 0
+			ids, release := DecodePooled(mask)
+			for _, id := range ids {
+				n += id
+			}
+			release()
+			_ = n
+		}
+	})
+}
+
+func BenchmarkDecodeParallel(b *testing.B) {
+	mask := Encode([]int{1, 7, 13, 22, 40, 61})
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := 0
+			for _, id := range Decode(mask) {
+				n += id
+			}
+			_ = n
+		}
+	})
+}
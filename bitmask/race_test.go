@@ -0,0 +1,7 @@
+//go:build race
+
+package bitmask
+
+// raceEnabled reports whether the race detector is on. Under it, sync.Pool
+// randomly drops items, so allocation counts are not meaningful.
+const raceEnabled = true
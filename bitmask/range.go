@@ -0,0 +1,123 @@
+package bitmask
+
+import "math/bits"
+
+// SetRange turns on bits lo through hi-1 (the half-open range [lo, hi), as with
+// slice indices). The range is clipped to the bits of an int, and an empty
+// range leaves the mask unchanged.
+//
+// The range mask is built in one step: `(1 << hi) - 1` has every bit below hi
+// set, and `&^ ((1 << lo) - 1)` removes those below lo.
+//
+// Example:
+//
+//	SetRange(0, 2, 5)
+//	  (1 << 5) - 1 = 00011111
+//	  (1 << 2) - 1 = 00000011
+//	  AND NOT      = 00011100 (decimal 28)
+func SetRange(mask, lo, hi int) int {
+	return mask | rangeMask(lo, hi)
+}
+
+// ClearRange turns off bits lo through hi-1.
+//
+// Example:
+//
+//	ClearRange(0b11111111, 2, 5) => 0b11100011
+func ClearRange(mask, lo, hi int) int {
+	return mask &^ rangeMask(lo, hi)
+}
+
+// ToggleRange flips bits lo through hi-1.
+//
+// Example:
+//
+//	ToggleRange(0b00001010, 0, 4) => 0b00000101
+func ToggleRange(mask, lo, hi int) int {
+	return mask ^ rangeMask(lo, hi)
+}
+
+// InvertWithin flips the bits below width and leaves the rest alone: the
+// complement of the mask within a domain of width flags. Plain `^mask` would
+// also set every bit above the domain, making the result negative.
+//
+// It is ToggleRange(mask, 0, width).
+//
+// Example:
+//
+//	selected = 00000101 (flags 0, 2 of 8)
+//	InvertWithin(selected, 8) = 11111010 (decimal 250), not ^5 = -6
+func InvertWithin(mask, width int) int {
+	return mask ^ rangeMask(0, width)
+}
+
+// rangeMask returns a mask with bits [lo, hi) set, clipped to the width of int.
+// Shifting a uint by its width or more gives 0, so hi at the top of the range
+// still yields all ones below it.
+func rangeMask(lo, hi int) int {
+	lo, hi = max(lo, 0), min(hi, bits.UintSize)
+	if lo >= hi {
+		return 0
+	}
+
+	return int((uint(1)<<hi - 1) &^ (uint(1)<<lo - 1))
+}
+
+// SetRange turns on bits lo through hi-1, growing the set if needed. Whole
+// words inside the range are written at once rather than bit by bit. It panics
+// if lo is negative.
+//
+// Example:
+//
+//	b.SetRange(60, 200) // words[0] gets bits 60-63, words[1] and [2] are filled, words[3] gets bits 0-7
+func (b *BitSet) SetRange(lo, hi int) {
+	if lo < 0 {
+		panic("bitmask: negative bit index")
+	}
+	if lo >= hi {
+		return
+	}
+	b.grow(wordIndex(hi-1) + 1)
+	b.eachRangeWord(lo, hi, func(w *uint64, m uint64) { *w |= m })
+}
+
+// ClearRange turns off bits lo through hi-1. Bits beyond the end of the set are
+// already clear, so it never grows the set. It panics if lo is negative.
+func (b *BitSet) ClearRange(lo, hi int) {
+	if lo < 0 {
+		panic("bitmask: negative bit index")
+	}
+	if lo >= hi {
+		return
+	}
+	hi = min(hi, len(b.words)*wordBits)
+	b.eachRangeWord(lo, hi, func(w *uint64, m uint64) { *w &^= m })
+}
+
+// ToggleRange flips bits lo through hi-1, growing the set if needed. It panics
+// if lo is negative.
+func (b *BitSet) ToggleRange(lo, hi int) {
+	if lo < 0 {
+		panic("bitmask: negative bit index")
+	}
+	if lo >= hi {
+		return
+	}
+	b.grow(wordIndex(hi-1) + 1)
+	b.eachRangeWord(lo, hi, func(w *uint64, m uint64) { *w ^= m })
+}
+
+// eachRangeWord calls fn for every word overlapping [lo, hi) with the mask of
+// the range's bits in that word. Only the first and last words get partial masks.
+func (b *BitSet) eachRangeWord(lo, hi int, fn func(w *uint64, m uint64)) {
+	for i := lo / wordBits; i*wordBits < hi; i++ {
+		m := ^uint64(0)
+		if start := i * wordBits; lo > start {
+			m &^= 1<<(lo-start) - 1
+		}
+		if end := (i + 1) * wordBits; hi < end {
+			m &= 1<<(hi%wordBits) - 1
+		}
+		fn(&b.words[i], m)
+	}
+}
@@ -0,0 +1,218 @@
+package bitmask
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		lo, hi   int
+		expected int
+	}{
+		{
+			name:     "middle",
+			lo:       2,
+			hi:       5,
+			expected: 0b11100,
+		},
+		{
+			name:     "from zero",
+			lo:       0,
+			hi:       3,
+			expected: 0b111,
+		},
+		{
+			name:     "empty",
+			lo:       4,
+			hi:       4,
+			expected: 0,
+		},
+		{
+			name:     "reversed",
+			lo:       5,
+			hi:       2,
+			expected: 0,
+		},
+		{
+			name:     "negative lo is clipped",
+			lo:       -3,
+			hi:       2,
+			expected: 0b11,
+		},
+		{
+			name:     "whole int",
+			lo:       0,
+			hi:       bits.UintSize,
+			expected: -1,
+		},
+		{
+			name:     "hi past the end is clipped",
+			lo:       bits.UintSize - 1,
+			hi:       1000,
+			expected: -1 << (bits.UintSize - 1),
+		},
+		{
+			name:     "entirely past the end",
+			lo:       bits.UintSize,
+			hi:       1000,
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rangeMask(tt.lo, tt.hi), tt.name)
+		})
+	}
+}
+
+func TestRangeOperations(t *testing.T) {
+	assert.Equal(t, 28, SetRange(0, 2, 5))
+	assert.Equal(t, 0b10011101, SetRange(0b10000001, 2, 5))
+	assert.Equal(t, 0b11100011, ClearRange(0b11111111, 2, 5))
+	assert.Equal(t, 0b0101, ToggleRange(0b1010, 0, 4))
+	assert.Equal(t, 42, ToggleRange(42, 3, 3))
+}
+
+func TestInvertWithin(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		width    int
+		expected int
+	}{
+		{
+			name:     "byte",
+			mask:     0b0000_0101,
+			width:    8,
+			expected: 0b1111_1010,
+		},
+		{
+			name:     "16 flags",
+			mask:     0,
+			width:    16,
+			expected: 0xffff,
+		},
+		{
+			name:     "bits above width are kept",
+			mask:     0b1_0000_0001,
+			width:    4,
+			expected: 0b1_0000_1110,
+		},
+		{
+			name:     "zero width",
+			mask:     42,
+			width:    0,
+			expected: 42,
+		},
+		{
+			name:     "full width",
+			mask:     5,
+			width:    bits.UintSize,
+			expected: ^5,
+		},
+		{
+			name:     "width past the end",
+			mask:     5,
+			width:    1000,
+			expected: ^5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, InvertWithin(tt.mask, tt.width), tt.name)
+			assert.Equal(t, tt.mask, InvertWithin(InvertWithin(tt.mask, tt.width), tt.width), tt.name)
+		})
+	}
+}
+
+func TestBitSetRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    []int
+		apply    func(b *BitSet)
+		expected []int
+	}{
+		{
+			name:     "set within a word",
+			start:    nil,
+			apply:    func(b *BitSet) { b.SetRange(2, 5) },
+			expected: []int{2, 3, 4},
+		},
+		{
+			name:     "set across words",
+			start:    []int{0},
+			apply:    func(b *BitSet) { b.SetRange(62, 130) },
+			expected: append([]int{0}, seq(62, 130)...),
+		},
+		{
+			name:     "set ending on a word boundary",
+			start:    nil,
+			apply:    func(b *BitSet) { b.SetRange(60, 64) },
+			expected: []int{60, 61, 62, 63},
+		},
+		{
+			name:     "clear across words",
+			start:    seq(0, 200),
+			apply:    func(b *BitSet) { b.ClearRange(10, 190) },
+			expected: append(seq(0, 10), seq(190, 200)...),
+		},
+		{
+			name:     "clear past the end",
+			start:    []int{1, 5},
+			apply:    func(b *BitSet) { b.ClearRange(3, 1000) },
+			expected: []int{1},
+		},
+		{
+			name:     "toggle across words",
+			start:    []int{63, 64, 100},
+			apply:    func(b *BitSet) { b.ToggleRange(63, 66) },
+			expected: []int{65, 100},
+		},
+		{
+			name:     "empty range",
+			start:    []int{1},
+			apply:    func(b *BitSet) { b.SetRange(10, 10); b.ToggleRange(8, 2); b.ClearRange(1, 1) },
+			expected: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bitSetOf(tt.start...)
+			tt.apply(b)
+			assert.Equal(t, tt.expected, setBits(b), tt.name)
+		})
+	}
+
+	var b BitSet
+	b.ClearRange(0, 1000)
+	assert.Empty(t, b.words, "ClearRange doesn't grow the set")
+	assert.PanicsWithValue(t, "bitmask: negative bit index", func() { b.SetRange(-1, 5) })
+}
+
+func seq(lo, hi int) []int {
+	s := make([]int, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		s = append(s, i)
+	}
+	return s
+}
+
+func BenchmarkBitSetSetRange(b *testing.B) {
+	var s BitSet
+	for i := 0; i < b.N; i++ {
+		s.SetRange(3, 100_000)
+	}
+}
+
+func BenchmarkBitSetSetRangeBitByBit(b *testing.B) {
+	var s BitSet
+	for i := 0; i < b.N; i++ {
+		for id := 3; id < 100_000; id++ {
+			s.Set(id)
+		}
+	}
+}
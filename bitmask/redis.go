@@ -0,0 +1,45 @@
+package bitmask
+
+import "math/bits"
+
+// ToRedisBytes returns the set in the layout Redis uses for bitmap strings, so
+// the bytes can be stored with SET and queried with GETBIT, BITCOUNT, and
+// BITPOS, or read back from GET.
+//
+// Redis numbers bits from the most significant bit of the first byte: offset 0
+// is 0x80 of byte 0, offset 7 is 0x01 of byte 0, offset 8 is 0x80 of byte 1. That
+// is the reverse of the bit order within each byte used by ToHex and
+// MarshalBinary, which is where translation bugs usually come from:
+//
+//	IDs {0, 9}
+//	ToHex:        01 02   (bit 0 = 0x01)
+//	ToRedisBytes: 80 40   (bit 0 = 0x80)
+//
+// Trailing zero bytes are dropped; an empty set is an empty slice.
+//
+// Example:
+//
+//	var b bitmask.BitSet
+//	b.Set(0)
+//	b.Set(9)
+//	rdb.Set(ctx, "active", b.ToRedisBytes(), 0)
+//	rdb.GetBit(ctx, "active", 9) => 1
+func (b *BitSet) ToRedisBytes() []byte {
+	out := b.bytes()
+	for i, c := range out {
+		out[i] = bits.Reverse8(c)
+	}
+
+	return out
+}
+
+// FromRedisBytes replaces the contents of b with a Redis bitmap string, as
+// returned by GET on a key written with SETBIT. Trailing zero bytes, which
+// Redis keeps after SETBIT key n 0, are ignored.
+func (b *BitSet) FromRedisBytes(data []byte) {
+	le := make([]byte, len(data))
+	for i, c := range data {
+		le[i] = bits.Reverse8(c)
+	}
+	b.fromBytes(le)
+}
@@ -0,0 +1,62 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSetRedisBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		expected []byte
+	}{
+		{
+			name:     "empty",
+			ids:      nil,
+			expected: []byte{},
+		},
+		{
+			name:     "offset 0 is the top bit",
+			ids:      []int{0},
+			expected: []byte{0x80},
+		},
+		{
+			name:     "offset 7 is the bottom bit",
+			ids:      []int{7},
+			expected: []byte{0x01},
+		},
+		{
+			name:     "second byte",
+			ids:      []int{0, 9},
+			expected: []byte{0x80, 0x40},
+		},
+		{
+			name:     "across words",
+			ids:      []int{63, 64},
+			expected: []byte{0, 0, 0, 0, 0, 0, 0, 0x01, 0x80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bitSetOf(tt.ids...)
+			assert.Equal(t, tt.expected, b.ToRedisBytes(), tt.name)
+
+			got := bitSetOf(1000)
+			got.FromRedisBytes(tt.expected)
+			assert.True(t, b.Equal(got), tt.name)
+		})
+	}
+}
+
+func TestBitSetFromRedisBytes(t *testing.T) {
+	// "SETBIT k 3 1" then "SETBIT k 20 0" leaves a 3-byte string.
+	var b BitSet
+	b.FromRedisBytes([]byte{0x10, 0x00, 0x00})
+	assert.Equal(t, []int{3}, setBits(&b))
+	assert.Equal(t, []byte{0x10}, b.ToRedisBytes())
+
+	b.FromRedisBytes(nil)
+	assert.True(t, b.IsEmpty())
+}
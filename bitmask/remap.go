@@ -0,0 +1,50 @@
+package bitmask
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRemapCollision is returned (wrapped) when RemapBits would move two set bits
+// to the same position.
+var ErrRemapCollision = errors.New("remap collision")
+
+// RemapBits moves each set bit of mask from its old position to
+// mapping[old], for migrating stored masks after flag IDs are renumbered. Bits
+// not in mapping stay where they are.
+//
+// It returns an error wrapping ErrRemapCollision if two set bits would land on
+// the same position, and a *RangeError if any mapping target is negative or
+// above MaxBit, whether or not that bit is set in mask.
+//
+// Example:
+//
+//	// FlagBeta moves from bit 1 to bit 4; FlagAudit stays at bit 0.
+//	RemapBits(0b011, map[int]int{1: 4}) => 0b10001, nil
+//	RemapBits(0b011, map[int]int{1: 0}) => 0, bitmask: remap collision: bits 0 and 1 both map to 0
+func RemapBits(mask int, mapping map[int]int) (int, error) {
+	c := newStrictConfig(nil)
+	for _, to := range mapping {
+		if err := c.check(to); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		out  int
+		from = make(map[int]int, Count(mask))
+	)
+	for bit := range Bits(mask) {
+		to, ok := mapping[bit]
+		if !ok {
+			to = bit
+		}
+		if prev, ok := from[to]; ok {
+			return 0, fmt.Errorf("bitmask: %w: bits %d and %d both map to %d", ErrRemapCollision, prev, bit, to)
+		}
+		from[to] = bit
+		out |= 1 << to
+	}
+
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemapBits(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		mapping  map[int]int
+		expected int
+		err      string
+	}{
+		{
+			name:     "no mapping",
+			mask:     42,
+			mapping:  nil,
+			expected: 42,
+		},
+		{
+			name:     "move one bit",
+			mask:     0b011,
+			mapping:  map[int]int{1: 4},
+			expected: 0b10001,
+		},
+		{
+			name:     "swap",
+			mask:     0b01,
+			mapping:  map[int]int{0: 1, 1: 0},
+			expected: 0b10,
+		},
+		{
+			name:     "unset source ignored",
+			mask:     0b100,
+			mapping:  map[int]int{0: 2},
+			expected: 0b100,
+		},
+		{
+			name:     "empty mask",
+			mask:     0,
+			mapping:  map[int]int{3: 7},
+			expected: 0,
+		},
+		{
+			name:    "collision with unmapped bit",
+			mask:    0b011,
+			mapping: map[int]int{1: 0},
+			err:     "bitmask: remap collision: bits 0 and 1 both map to 0",
+		},
+		{
+			name:    "two bits to one target",
+			mask:    0b110,
+			mapping: map[int]int{1: 5, 2: 5},
+			err:     "bitmask: remap collision: bits 1 and 2 both map to 5",
+		},
+		{
+			name:    "target out of range",
+			mask:    0,
+			mapping: map[int]int{1: 70},
+			err:     "bitmask: ID 70 out of range [0, 62]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemapBits(tt.mask, tt.mapping)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.Zero(t, got, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, got, tt.name)
+		})
+	}
+
+	_, err := RemapBits(3, map[int]int{0: 1})
+	assert.ErrorIs(t, err, ErrRemapCollision)
+	_, err = RemapBits(0, map[int]int{0: -1})
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
@@ -0,0 +1,128 @@
+package bitmask
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// maxRLEBits bounds the size of a set UnmarshalRLE will build, 2^30 bits or
+// 128 MiB, since a few bytes of RLE can describe an arbitrarily large set.
+// BitSet.UnmarshalText applies the same bound to the IDs it reads.
+const maxRLEBits = 1 << 30
+
+// MarshalRLE encodes the set as alternating run lengths, which is much smaller
+// than MarshalBinary for sets made of long stretches of set or clear bits:
+//
+//	[0x01][uvarint n][n × uvarint run length]
+//
+// Runs alternate clear, set, clear, set, ..., starting with a clear run (of
+// length 0 if bit 0 is set). The clear run after the last set bit is not
+// written, so n is always even and equal sets encode to the same bytes.
+//
+// Example:
+//
+//	bits 0-2 clear, 3-999 set, 1000-1004 clear, 1005 set
+//	runs: 3, 997, 5, 1 → [0x01][0x04][0x03][0xe5 0x07][0x05][0x01]
+func (b *BitSet) MarshalRLE() ([]byte, error) {
+	var runs []uint64
+	for pos := 0; ; {
+		start, ok := b.nextSet(pos)
+		if !ok {
+			break
+		}
+		end := b.nextClear(start)
+		runs = append(runs, uint64(start-pos), uint64(end-start))
+		pos = end
+	}
+
+	out := make([]byte, 0, 2+2*len(runs))
+	out = append(out, binaryVersion)
+	out = binary.AppendUvarint(out, uint64(len(runs)))
+	for _, r := range runs {
+		out = binary.AppendUvarint(out, r)
+	}
+
+	return out, nil
+}
+
+// UnmarshalRLE decodes the format written by MarshalRLE, replacing the contents
+// of b. Only the canonical form is accepted: an even number of runs, none empty
+// except the first. Sets of more than 2^30 bits are rejected.
+func (b *BitSet) UnmarshalRLE(data []byte) error {
+	payload, err := checkVersion(data)
+	if err != nil {
+		return err
+	}
+
+	r := binaryReader{data: payload}
+	n := r.uvarint()
+	if r.err == nil && (n%2 != 0 || n > uint64(len(r.data))) {
+		r.fail("bad RLE run count %d", n)
+	}
+
+	var (
+		out BitSet
+		pos uint64
+	)
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		length := r.uvarint()
+		switch {
+		case r.err != nil:
+		case length == 0 && i > 0:
+			r.fail("empty RLE run %d", i)
+		case length > maxRLEBits-pos:
+			r.fail("RLE runs exceed %d bits", maxRLEBits)
+		case i%2 == 1:
+			out.SetRange(int(pos), int(pos+length))
+		}
+		pos += length
+	}
+	if r.err == nil && len(r.data) > 0 {
+		r.fail("%d trailing bytes", len(r.data))
+	}
+	if r.err != nil {
+		return r.err
+	}
+	*b = out
+
+	return nil
+}
+
+// nextSet returns the lowest set bit at or above from.
+func (b *BitSet) nextSet(from int) (int, bool) {
+	i := from / wordBits
+	if i >= len(b.words) {
+		return 0, false
+	}
+
+	w := b.words[i] >> (from % wordBits) << (from % wordBits)
+	for {
+		if w != 0 {
+			return i*wordBits + bits.TrailingZeros64(w), true
+		}
+		if i++; i == len(b.words) {
+			return 0, false
+		}
+		w = b.words[i]
+	}
+}
+
+// nextClear returns the lowest clear bit at or above from. Bits past the end of
+// the set are clear.
+func (b *BitSet) nextClear(from int) int {
+	i := from / wordBits
+	if i >= len(b.words) {
+		return from
+	}
+
+	w := ^b.words[i] >> (from % wordBits) << (from % wordBits)
+	for {
+		if w != 0 {
+			return i*wordBits + bits.TrailingZeros64(w)
+		}
+		if i++; i == len(b.words) {
+			return i * wordBits
+		}
+		w = ^b.words[i]
+	}
+}
@@ -0,0 +1,163 @@
+package bitmask
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSetRLE(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      *BitSet
+		expected []byte
+	}{
+		{
+			name:     "empty",
+			set:      &BitSet{},
+			expected: []byte{1, 0},
+		},
+		{
+			name:     "starts at zero",
+			set:      bitSetOf(0, 1, 2),
+			expected: []byte{1, 2, 0, 3},
+		},
+		{
+			name:     "two runs",
+			set:      bitSetOf(append(seq(3, 1000), 1005)...),
+			expected: []byte{1, 4, 3, 0xe5, 0x07, 5, 1},
+		},
+		{
+			name:     "run to a word boundary",
+			set:      bitSetOf(seq(60, 128)...),
+			expected: []byte{1, 2, 60, 68},
+		},
+		{
+			name:     "trailing empty words",
+			set:      &BitSet{words: []uint64{0b10, 0, 0}},
+			expected: []byte{1, 2, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.set.MarshalRLE()
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, data, tt.name)
+
+			got := bitSetOf(5000)
+			assert.NoError(t, got.UnmarshalRLE(data), tt.name)
+			assert.True(t, tt.set.Equal(got), tt.name)
+		})
+	}
+
+	dense := &BitSet{}
+	dense.SetRange(100, 1_000_000)
+	rle, _ := dense.MarshalRLE()
+	binary, _ := dense.MarshalBinary()
+	assert.Len(t, rle, 6)
+	assert.Greater(t, len(binary), 100_000)
+}
+
+func TestBitSetUnmarshalRLEErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "empty",
+			data:     nil,
+			expected: "bitmask: invalid encoding: empty input",
+		},
+		{
+			name:     "odd run count",
+			data:     []byte{1, 1, 5},
+			expected: "bitmask: invalid encoding: bad RLE run count 1",
+		},
+		{
+			name:     "truncated",
+			data:     []byte{1, 2, 5},
+			expected: "bitmask: invalid encoding: bad RLE run count 2",
+		},
+		{
+			name:     "bad varint",
+			data:     []byte{1, 2, 5, 0xff},
+			expected: "bitmask: invalid encoding: bad uvarint",
+		},
+		{
+			name:     "empty set run",
+			data:     []byte{1, 2, 5, 0},
+			expected: "bitmask: invalid encoding: empty RLE run 1",
+		},
+		{
+			name:     "too large",
+			data:     []byte{1, 2, 0, 0x81, 0x80, 0x80, 0x80, 0x04},
+			expected: "bitmask: invalid encoding: RLE runs exceed 1073741824 bits",
+		},
+		{
+			name:     "trailing bytes",
+			data:     []byte{1, 2, 0, 1, 9},
+			expected: "bitmask: invalid encoding: 1 trailing bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bitSetOf(3)
+			assert.EqualError(t, b.UnmarshalRLE(tt.data), tt.expected, tt.name)
+			assert.Equal(t, []int{3}, setBits(b), "unchanged on error")
+		})
+	}
+}
+
+func FuzzBitSetRLE(f *testing.F) {
+	f.Add([]byte{1, 0})
+	f.Add([]byte{1, 2, 0, 3})
+	f.Add([]byte{1, 4, 3, 0xe5, 0x07, 5, 1})
+	f.Add([]byte{1, 2, 60, 68})
+	f.Add([]byte{0xff, 0xfe, 0x00})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var b BitSet
+		if err := b.UnmarshalRLE(data); err != nil {
+			return
+		}
+
+		// Only canonical encodings decode, so re-encoding gives the input back.
+		out, err := b.MarshalRLE()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, data) {
+			t.Fatalf("re-encoded %x as %x", data, out)
+		}
+
+		var again BitSet
+		if err := again.UnmarshalRLE(out); err != nil || !again.Equal(&b) {
+			t.Fatalf("round trip failed: %v", err)
+		}
+	})
+}
+
+func FuzzBitSetRLEFromBits(f *testing.F) {
+	f.Add([]byte{0xff, 0x00, 0x0f})
+	f.Add([]byte{})
+	f.Add(bytes.Repeat([]byte{0xaa}, 20))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		var b BitSet
+		b.fromBytes(raw)
+
+		data, err := b.MarshalRLE()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got BitSet
+		if err := got.UnmarshalRLE(data); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&b) {
+			t.Fatalf("round trip of %x changed the set", raw)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package bitmask
+
+import "math/bits"
+
+// RotateLeft rotates the low width bits of the mask left by k positions: bits
+// shifted past the top of the width come back in at bit 0. Bits at or above
+// width are cleared first. A negative k rotates right. It panics if width is
+// outside [1, bits.UintSize].
+//
+// Example:
+//
+//	RotateLeft(0b1001_0110, 3, 8)
+//	  shifted left by 3:    1_0110_000
+//	  top 3 bits wrapped:   0000_0100
+//	  result:               1011_0100 (decimal 180)
+func RotateLeft(mask, k, width int) int {
+	return rotate(mask, k, width, false)
+}
+
+// RotateRight rotates the low width bits of the mask right by k positions: bits
+// shifted out at bit 0 come back in at the top of the width.
+//
+// Example:
+//
+//	RotateRight(0b0000_0001, 1, 8) => 0b1000_0000
+func RotateRight(mask, k, width int) int {
+	return rotate(mask, k, width, true)
+}
+
+// rotate rotates the low width bits left by k, or right if right is set, after
+// reducing k to a left rotation in [0, width).
+func rotate(mask, k, width int, right bool) int {
+	w := widthMask(width)
+	m := uint(mask) & w
+
+	k %= width
+	if right {
+		k = -k
+	}
+	if k < 0 {
+		k += width
+	}
+	if k == 0 {
+		return int(m)
+	}
+
+	return int((m<<k | m>>(width-k)) & w)
+}
+
+// widthMask returns a uint with the low width bits set.
+func widthMask(width int) uint {
+	if width < 1 || width > bits.UintSize {
+		panic("bitmask: rotate width out of range")
+	}
+
+	return ^uint(0) >> (bits.UintSize - width)
+}
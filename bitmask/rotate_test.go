@@ -0,0 +1,94 @@
+package bitmask
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		mask  int
+		k     int
+		width int
+		left  int
+		right int
+	}{
+		{
+			name:  "byte",
+			mask:  0b1001_0110,
+			k:     3,
+			width: 8,
+			left:  0b1011_0100,
+			right: 0b1101_0010,
+		},
+		{
+			name:  "wrap single bit",
+			mask:  0b1000_0001,
+			k:     1,
+			width: 8,
+			left:  0b0000_0011,
+			right: 0b1100_0000,
+		},
+		{
+			name:  "bits above width are cleared",
+			mask:  0b1_0000_0001,
+			k:     1,
+			width: 8,
+			left:  0b10,
+			right: 0b1000_0000,
+		},
+		{
+			name:  "k of zero",
+			mask:  0b1010,
+			k:     0,
+			width: 4,
+			left:  0b1010,
+			right: 0b1010,
+		},
+		{
+			name:  "k larger than width",
+			mask:  0b0001,
+			k:     5,
+			width: 4,
+			left:  0b0010,
+			right: 0b1000,
+		},
+		{
+			name:  "negative k",
+			mask:  0b0001,
+			k:     -1,
+			width: 4,
+			left:  0b1000,
+			right: 0b0010,
+		},
+		{
+			name:  "16 bits",
+			mask:  0x8001,
+			k:     4,
+			width: 16,
+			left:  0x0018,
+			right: 0x1800,
+		},
+		{
+			name:  "full word",
+			mask:  1,
+			k:     1,
+			width: bits.UintSize,
+			left:  2,
+			right: -1 << (bits.UintSize - 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.left, RotateLeft(tt.mask, tt.k, tt.width), "left")
+			assert.Equal(t, tt.right, RotateRight(tt.mask, tt.k, tt.width), "right")
+		})
+	}
+
+	assert.PanicsWithValue(t, "bitmask: rotate width out of range", func() { RotateLeft(1, 1, 0) })
+	assert.PanicsWithValue(t, "bitmask: rotate width out of range", func() { RotateRight(1, 1, bits.UintSize+1) })
+	assert.PanicsWithValue(t, "bitmask: rotate width out of range", func() { RotateRight(1, 1, 0) })
+}
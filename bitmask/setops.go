@@ -0,0 +1,135 @@
+package bitmask
+
+// Union returns a mask with every bit that is set in a or b, using bitwise OR.
+//
+// Example:
+//
+//	a = 00001010 (IDs 1, 3)
+//	b = 00001100 (IDs 2, 3)
+//	Union(a, b) = 00001110 (IDs 1, 2, 3)
+func Union(a, b int) int {
+	return a | b
+}
+
+// Intersect returns a mask with the bits set in both a and b, using bitwise AND.
+//
+// Example:
+//
+//	a = 00001010 (IDs 1, 3)
+//	b = 00001100 (IDs 2, 3)
+//	Intersect(a, b) = 00001000 (ID 3)
+func Intersect(a, b int) int {
+	return a & b
+}
+
+// Difference returns a mask with the bits of a that are not set in b, using
+// bitwise AND NOT (`a &^ b`).
+//
+// Example:
+//
+//	a = 00001010 (IDs 1, 3)
+//	b = 00001100 (IDs 2, 3)
+//	Difference(a, b) = 00000010 (ID 1)
+func Difference(a, b int) int {
+	return a &^ b
+}
+
+// SymmetricDifference returns a mask with the bits set in exactly one of a and
+// b, using bitwise XOR.
+//
+// Example:
+//
+//	a = 00001010 (IDs 1, 3)
+//	b = 00001100 (IDs 2, 3)
+//	SymmetricDifference(a, b) = 00000110 (IDs 1, 2)
+func SymmetricDifference(a, b int) int {
+	return a ^ b
+}
+
+// UnionAll returns the union of all masks, or 0 if there are none.
+//
+// Example:
+//
+//	UnionAll(0b0001, 0b0100, 0b1000) => 0b1101
+func UnionAll(masks ...int) int {
+	var mask int
+
+	for _, m := range masks {
+		mask |= m
+	}
+
+	return mask
+}
+
+// IntersectAll returns the intersection of all masks, or 0 if there are none.
+//
+// Example:
+//
+//	IntersectAll(0b1101, 0b0111, 0b0101) => 0b0101
+func IntersectAll(masks ...int) int {
+	if len(masks) == 0 {
+		return 0
+	}
+
+	mask := masks[0]
+	for _, m := range masks[1:] {
+		mask &= m
+	}
+
+	return mask
+}
+
+// IsSubset reports whether every bit set in a is also set in b, i.e. a has
+// nothing left once b's bits are removed (`a &^ b == 0`). The empty mask is a
+// subset of every mask.
+//
+// Example:
+//
+//	a = 00001000 (ID 3)
+//	b = 00001010 (IDs 1, 3)
+//	IsSubset(a, b) => true
+//	IsSubset(b, a) => false
+func IsSubset(a, b int) bool {
+	return a&^b == 0
+}
+
+// IsSuperset reports whether every bit set in b is also set in a. It is
+// IsSubset with the arguments swapped.
+//
+// Example:
+//
+//	granted  = 00001011 (read, write, admin)
+//	required = 00000011 (read, write)
+//	IsSuperset(granted, required) => true
+func IsSuperset(a, b int) bool {
+	return b&^a == 0
+}
+
+// Intersects reports whether a and b have at least one bit in common.
+//
+// Example:
+//
+//	Intersects(0b0110, 0b0011) => true
+//	Intersects(0b0100, 0b0011) => false
+func Intersects(a, b int) bool {
+	return a&b != 0
+}
+
+// ContainsAll reports whether every one of the given bit positions is set in
+// the mask. With no IDs it returns true. An ID outside [0, MaxBit] can never
+// be set, so it makes ContainsAll return false.
+//
+// Example:
+//
+//	ContainsAll(42, 1, 5) => true
+//	ContainsAll(42, 1, 2) => false
+//	ContainsAll(0, 64)    => false
+func ContainsAll(mask int, ids ...int) bool {
+	for _, id := range ids {
+		if id < 0 || id > MaxBit || !HasBit(mask, id) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,235 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOperations(t *testing.T) {
+	a := Encode([]int{1, 3})
+	b := Encode([]int{2, 3})
+
+	tests := []struct {
+		name     string
+		result   int
+		expected []int
+	}{
+		{
+			name:     "union",
+			result:   Union(a, b),
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "intersect",
+			result:   Intersect(a, b),
+			expected: []int{3},
+		},
+		{
+			name:     "difference",
+			result:   Difference(a, b),
+			expected: []int{1},
+		},
+		{
+			name:     "reverse difference",
+			result:   Difference(b, a),
+			expected: []int{2},
+		},
+		{
+			name:     "symmetric difference",
+			result:   SymmetricDifference(a, b),
+			expected: []int{1, 2},
+		},
+		{
+			name:     "disjoint intersect",
+			result:   Intersect(Encode([]int{0}), Encode([]int{1})),
+			expected: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Decode(tt.result), tt.name)
+		})
+	}
+}
+
+func TestUnionAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		masks    []int
+		expected int
+	}{
+		{
+			name:     "none",
+			masks:    nil,
+			expected: 0,
+		},
+		{
+			name:     "one",
+			masks:    []int{42},
+			expected: 42,
+		},
+		{
+			name:     "many",
+			masks:    []int{0b0001, 0b0100, 0b1000},
+			expected: 0b1101,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, UnionAll(tt.masks...), tt.name)
+		})
+	}
+}
+
+func TestIntersectAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		masks    []int
+		expected int
+	}{
+		{
+			name:     "none",
+			masks:    nil,
+			expected: 0,
+		},
+		{
+			name:     "one",
+			masks:    []int{42},
+			expected: 42,
+		},
+		{
+			name:     "many",
+			masks:    []int{0b1101, 0b0111, 0b0101},
+			expected: 0b0101,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IntersectAll(tt.masks...), tt.name)
+		})
+	}
+}
+
+func TestSetPredicates(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       int
+		subset     bool
+		superset   bool
+		intersects bool
+	}{
+		{
+			name:       "proper subset",
+			a:          0b0010,
+			b:          0b1010,
+			subset:     true,
+			superset:   false,
+			intersects: true,
+		},
+		{
+			name:       "proper superset",
+			a:          0b1011,
+			b:          0b0011,
+			subset:     false,
+			superset:   true,
+			intersects: true,
+		},
+		{
+			name:       "equal",
+			a:          42,
+			b:          42,
+			subset:     true,
+			superset:   true,
+			intersects: true,
+		},
+		{
+			name:       "overlapping",
+			a:          0b0110,
+			b:          0b0011,
+			subset:     false,
+			superset:   false,
+			intersects: true,
+		},
+		{
+			name:       "disjoint",
+			a:          0b0100,
+			b:          0b0011,
+			subset:     false,
+			superset:   false,
+			intersects: false,
+		},
+		{
+			name:       "empty",
+			a:          0,
+			b:          0b0011,
+			subset:     true,
+			superset:   false,
+			intersects: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.subset, IsSubset(tt.a, tt.b), "IsSubset")
+			assert.Equal(t, tt.superset, IsSuperset(tt.a, tt.b), "IsSuperset")
+			assert.Equal(t, tt.intersects, Intersects(tt.a, tt.b), "Intersects")
+		})
+	}
+}
+
+func TestContainsAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     int
+		ids      []int
+		expected bool
+	}{
+		{
+			name:     "all set",
+			mask:     42,
+			ids:      []int{1, 5},
+			expected: true,
+		},
+		{
+			name:     "one missing",
+			mask:     42,
+			ids:      []int{1, 2},
+			expected: false,
+		},
+		{
+			name:     "no IDs",
+			mask:     0,
+			ids:      nil,
+			expected: true,
+		},
+		{
+			name:     "repeated",
+			mask:     8,
+			ids:      []int{3, 3},
+			expected: true,
+		},
+		{
+			name:     "ID past the top bit",
+			mask:     0,
+			ids:      []int{64},
+			expected: false,
+		},
+		{
+			name:     "ID past the top bit of a full mask",
+			mask:     -1,
+			ids:      []int{0, 64},
+			expected: false,
+		},
+		{
+			name:     "negative ID",
+			mask:     -1,
+			ids:      []int{-1},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ContainsAll(tt.mask, tt.ids...), tt.name)
+		})
+	}
+}
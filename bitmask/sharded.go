@@ -0,0 +1,129 @@
+package bitmask
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// ShardedMask spreads an unbounded ID space over several int masks of a fixed
+// width, for flags stored across multiple integer database columns:
+//
+//	shard = id / width
+//	bit   = id % width
+//
+// With width 62, IDs 0-61 live in shard 0, 62-123 in shard 1, and so on.
+// Shards are added as higher IDs are set; Shards returns one int per column.
+//
+// Example:
+//
+//	m := bitmask.NewShardedMask(62)
+//	m.Set(3)
+//	m.Set(70)
+//	m.Shards() // [8, 256]: flags_0 = 8, flags_1 = 256
+//
+// A ShardedMask is not safe for concurrent use.
+type ShardedMask struct {
+	width  int
+	shards []int
+}
+
+// NewShardedMask returns an empty ShardedMask whose shards hold width bits
+// each. It panics unless width is in [1, MaxBit+1], so every shard stays a
+// non-negative int.
+func NewShardedMask(width int) *ShardedMask {
+	if width < 1 || width > MaxBit+1 {
+		panic(fmt.Sprintf("bitmask: shard width %d out of range [1, %d]", width, MaxBit+1))
+	}
+
+	return &ShardedMask{width: width}
+}
+
+// ShardedMaskFromShards returns a ShardedMask holding the given shards, as
+// loaded from storage. It returns a *RangeError, with the ID the offending
+// bit would have, if a shard has a bit set at or above width. It panics on a
+// width NewShardedMask rejects.
+func ShardedMaskFromShards(width int, shards []int) (*ShardedMask, error) {
+	m := NewShardedMask(width)
+	for i, shard := range shards {
+		if high := uint64(shard) >> width; high != 0 {
+			return nil, &RangeError{ID: (i+1)*width + bits.TrailingZeros64(high), Max: (i+1)*width - 1}
+		}
+	}
+	m.shards = append([]int(nil), shards...)
+
+	return m, nil
+}
+
+// Width returns the number of bits in each shard.
+func (m *ShardedMask) Width() int {
+	return m.width
+}
+
+// Locate returns the shard holding id and its bit within that shard.
+func (m *ShardedMask) Locate(id int) (shard, bit int) {
+	if id < 0 {
+		panic("bitmask: negative bit index")
+	}
+
+	return id / m.width, id % m.width
+}
+
+// Set turns on bit id, adding shards as needed. It panics if id is negative.
+func (m *ShardedMask) Set(id int) {
+	shard, bit := m.Locate(id)
+	for len(m.shards) <= shard {
+		m.shards = append(m.shards, 0)
+	}
+	m.shards[shard] = SetBit(m.shards[shard], bit)
+}
+
+// Clear turns off bit id.
+func (m *ShardedMask) Clear(id int) {
+	shard, bit := m.Locate(id)
+	if shard < len(m.shards) {
+		m.shards[shard] = ClearBit(m.shards[shard], bit)
+	}
+}
+
+// Has reports whether bit id is set.
+func (m *ShardedMask) Has(id int) bool {
+	shard, bit := m.Locate(id)
+	return shard < len(m.shards) && HasBit(m.shards[shard], bit)
+}
+
+// Decode returns the set IDs in ascending order.
+func (m *ShardedMask) Decode() []int {
+	var ids []int
+	for i, shard := range m.shards {
+		for bit := range Bits(shard) {
+			ids = append(ids, i*m.width+bit)
+		}
+	}
+
+	return ids
+}
+
+// Count returns the number of set IDs.
+func (m *ShardedMask) Count() int {
+	n := 0
+	for _, shard := range m.shards {
+		n += Count(shard)
+	}
+
+	return n
+}
+
+// Shard returns shard i, which is 0 past the last shard that was written.
+func (m *ShardedMask) Shard(i int) int {
+	if i < 0 || i >= len(m.shards) {
+		return 0
+	}
+
+	return m.shards[i]
+}
+
+// Shards returns a copy of the shards, one per storage column. Trailing empty
+// shards are kept, so the length only grows.
+func (m *ShardedMask) Shards() []int {
+	return append([]int(nil), m.shards...)
+}
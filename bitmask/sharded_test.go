@@ -0,0 +1,115 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedMask(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		ids    []int
+		shards []int
+	}{
+		{
+			name:   "empty",
+			width:  62,
+			shards: nil,
+		},
+		{
+			name:   "one shard",
+			width:  62,
+			ids:    []int{0, 3, 61},
+			shards: []int{1<<61 | 1<<3 | 1},
+		},
+		{
+			name:   "across shards",
+			width:  62,
+			ids:    []int{3, 70, 200},
+			shards: []int{8, 256, 0, 1 << 14},
+		},
+		{
+			name:   "narrow shards",
+			width:  8,
+			ids:    []int{7, 8, 17},
+			shards: []int{128, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewShardedMask(tt.width)
+			for _, id := range tt.ids {
+				m.Set(id)
+			}
+			assert.Equal(t, tt.shards, m.Shards(), tt.name)
+			assert.Equal(t, tt.ids, m.Decode(), tt.name)
+			assert.Equal(t, len(tt.ids), m.Count(), tt.name)
+			for _, id := range tt.ids {
+				assert.True(t, m.Has(id), tt.name)
+			}
+
+			loaded, err := ShardedMaskFromShards(tt.width, tt.shards)
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.ids, loaded.Decode(), tt.name)
+		})
+	}
+}
+
+func TestShardedMaskUpdates(t *testing.T) {
+	m := NewShardedMask(10)
+	assert.Equal(t, 10, m.Width())
+
+	m.Set(25)
+	assert.Equal(t, []int{0, 0, 32}, m.Shards())
+	shard, bit := m.Locate(25)
+	assert.Equal(t, 2, shard)
+	assert.Equal(t, 5, bit)
+
+	assert.False(t, m.Has(5))
+	assert.False(t, m.Has(1000))
+	m.Clear(1000)
+	m.Clear(25)
+	assert.Equal(t, []int{0, 0, 0}, m.Shards(), "shards are kept once added")
+	assert.Equal(t, 0, m.Shard(2))
+	assert.Equal(t, 0, m.Shard(-1))
+
+	shards := m.Shards()
+	shards[0] = 7
+	assert.Equal(t, 0, m.Shard(0), "Shards returns a copy")
+
+	assert.PanicsWithValue(t, "bitmask: negative bit index", func() { m.Set(-1) })
+	assert.PanicsWithValue(t, "bitmask: shard width 0 out of range [1, 63]", func() { NewShardedMask(0) })
+	assert.PanicsWithValue(t, "bitmask: shard width 64 out of range [1, 63]", func() { NewShardedMask(64) })
+}
+
+func TestShardedMaskFromShardsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		shards   []int
+		expected string
+	}{
+		{
+			name:     "bit above width",
+			width:    8,
+			shards:   []int{1, 1 << 9},
+			expected: "bitmask: ID 17 out of range [0, 15]",
+		},
+		{
+			name:     "negative shard",
+			width:    62,
+			shards:   []int{-1},
+			expected: "bitmask: ID 62 out of range [0, 61]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ShardedMaskFromShards(tt.width, tt.shards)
+			assert.Nil(t, m, tt.name)
+			assert.EqualError(t, err, tt.expected, tt.name)
+			assert.ErrorIs(t, err, ErrOutOfRange, tt.name)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package bitmask
+
+import (
+	"iter"
+	"maps"
+	"math/bits"
+	"slices"
+)
+
+// SparseMask is a set of non-negative IDs stored as a map of 64-bit pages, only
+// for the pages that have bits set. Setting bit 1,000,000 allocates one page
+// rather than the 15,625 words a BitSet would need to reach it, which suits a
+// few IDs scattered across a huge range.
+//
+// Page n holds IDs 64n to 64n+63:
+//
+//	id 5         → pages[0], bit 5
+//	id 1_000_000 → pages[15625], bit 0
+//
+// For dense sets BitSet is smaller and faster. The zero value is an empty set
+// ready to use. A SparseMask is not safe for concurrent use.
+type SparseMask struct {
+	pages map[int]uint64
+}
+
+// SparseMaskFromBitSet returns a SparseMask holding the same bits as b.
+func SparseMaskFromBitSet(b *BitSet) *SparseMask {
+	s := &SparseMask{}
+	for i, w := range b.words {
+		if w != 0 {
+			s.page()[i] = w
+		}
+	}
+
+	return s
+}
+
+// BitSet returns a BitSet holding the same bits as s.
+func (s *SparseMask) BitSet() *BitSet {
+	b := &BitSet{}
+	for i, w := range s.pages {
+		b.grow(i + 1)
+		b.words[i] = w
+	}
+
+	return b
+}
+
+// Set turns on bit id. It panics if id is negative.
+func (s *SparseMask) Set(id int) {
+	s.page()[wordIndex(id)] |= 1 << (id % wordBits)
+}
+
+// Clear turns off bit id, freeing its page once the page is empty.
+func (s *SparseMask) Clear(id int) {
+	i := wordIndex(id)
+	w, ok := s.pages[i]
+	if !ok {
+		return
+	}
+	if w &^= 1 << (id % wordBits); w == 0 {
+		delete(s.pages, i)
+	} else {
+		s.pages[i] = w
+	}
+}
+
+// Toggle flips bit id.
+func (s *SparseMask) Toggle(id int) {
+	if s.Test(id) {
+		s.Clear(id)
+	} else {
+		s.Set(id)
+	}
+}
+
+// Test reports whether bit id is set.
+func (s *SparseMask) Test(id int) bool {
+	return s.pages[wordIndex(id)]&(1<<(id%wordBits)) != 0
+}
+
+// Count returns the number of set bits.
+func (s *SparseMask) Count() int {
+	n := 0
+	for _, w := range s.pages {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+// IsEmpty reports whether no bits are set.
+func (s *SparseMask) IsEmpty() bool {
+	return len(s.pages) == 0
+}
+
+// Bits returns an iterator over the set bits in ascending order. Pages are
+// sorted when iteration starts; the set must not be modified during iteration.
+//
+// Example:
+//
+//	s.Set(1_000_000); s.Set(3)
+//	for id := range s.Bits() {
+//		fmt.Println(id) // 3, 1000000
+//	}
+func (s *SparseMask) Bits() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, i := range slices.Sorted(maps.Keys(s.pages)) {
+			for w := s.pages[i]; w != 0; w &= w - 1 {
+				if !yield(i*wordBits + bits.TrailingZeros64(w)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Equal reports whether s and o have exactly the same bits set.
+func (s *SparseMask) Equal(o *SparseMask) bool {
+	return maps.Equal(s.pages, o.pages)
+}
+
+// Clone returns a copy of s.
+func (s *SparseMask) Clone() *SparseMask {
+	return &SparseMask{pages: maps.Clone(s.pages)}
+}
+
+// page returns the page map, allocating it on first use.
+func (s *SparseMask) page() map[int]uint64 {
+	if s.pages == nil {
+		s.pages = make(map[int]uint64)
+	}
+
+	return s.pages
+}
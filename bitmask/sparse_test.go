@@ -0,0 +1,71 @@
+package bitmask
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseMask(t *testing.T) {
+	var s SparseMask
+	assert.True(t, s.IsEmpty())
+	assert.False(t, s.Test(1_000_000))
+
+	ids := []int{1_000_000, 3, 64, 63, 1 << 40}
+	for _, id := range ids {
+		s.Set(id)
+	}
+	for _, id := range ids {
+		assert.True(t, s.Test(id), "bit %d", id)
+	}
+	for _, id := range []int{0, 65, 999_999, 1<<40 + 1} {
+		assert.False(t, s.Test(id), "bit %d", id)
+	}
+	assert.Len(t, s.pages, 4, "one page per 64 IDs in use")
+	assert.Equal(t, 5, s.Count())
+	assert.Equal(t, []int{3, 63, 64, 1_000_000, 1 << 40}, slices.Collect(s.Bits()))
+
+	s.Clear(64)
+	s.Clear(12345)
+	assert.False(t, s.Test(64))
+	assert.Len(t, s.pages, 3, "empty pages are freed")
+
+	s.Toggle(3)
+	s.Toggle(4)
+	assert.Equal(t, []int{4, 63, 1_000_000, 1 << 40}, slices.Collect(s.Bits()))
+
+	for id := range s.Bits() {
+		assert.Equal(t, 4, id, "iteration stops early")
+		break
+	}
+
+	assert.PanicsWithValue(t, "bitmask: negative bit index", func() { s.Set(-1) })
+}
+
+func TestSparseMaskBitSet(t *testing.T) {
+	b := bitSetOf(1, 70, 300)
+	s := SparseMaskFromBitSet(b)
+	assert.Len(t, s.pages, 3)
+	assert.Equal(t, []int{1, 70, 300}, slices.Collect(s.Bits()))
+	assert.True(t, b.Equal(s.BitSet()))
+
+	assert.True(t, SparseMaskFromBitSet(&BitSet{}).IsEmpty())
+	assert.True(t, (&SparseMask{}).BitSet().IsEmpty())
+}
+
+func TestSparseMaskEqualClone(t *testing.T) {
+	var a SparseMask
+	a.Set(5)
+	a.Set(500)
+
+	b := a.Clone()
+	assert.True(t, a.Equal(b))
+	b.Set(6)
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Test(6))
+
+	b.Clear(6)
+	assert.True(t, a.Equal(b), "clearing the last extra bit frees its page")
+	assert.True(t, (&SparseMask{}).Equal(&SparseMask{}))
+}
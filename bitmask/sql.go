@@ -0,0 +1,47 @@
+package bitmask
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// Value implements driver.Valuer, storing the mask as a BIGINT.
+func (m Mask) Value() (driver.Value, error) {
+	return int64(m), nil
+}
+
+// Scan implements sql.Scanner for integer columns. Drivers that return numbers
+// as text (such as MySQL with some settings) are handled too, and NULL scans
+// as an empty mask.
+//
+// Example:
+//
+//	var roles bitmask.Mask
+//	err := db.QueryRow("SELECT roles FROM users WHERE id = $1", id).Scan(&roles)
+func (m *Mask) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case int64:
+		*m = Mask(v)
+	case []byte:
+		return m.scanText(string(v))
+	case string:
+		return m.scanText(v)
+	default:
+		return fmt.Errorf("bitmask: cannot scan %T into Mask", src)
+	}
+
+	return nil
+}
+
+func (m *Mask) scanText(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("bitmask: cannot scan %q into Mask: %w", s, err)
+	}
+	*m = Mask(n)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package bitmask
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ driver.Valuer = Mask(0)
+	_ sql.Scanner   = (*Mask)(nil)
+)
+
+func TestMaskValue(t *testing.T) {
+	v, err := Mask(42).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+	assert.True(t, driver.IsValue(v))
+}
+
+func TestMaskScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      any
+		expected Mask
+		err      string
+	}{
+		{
+			name:     "int64",
+			src:      int64(42),
+			expected: 42,
+		},
+		{
+			name:     "bytes",
+			src:      []byte("42"),
+			expected: 42,
+		},
+		{
+			name:     "string",
+			src:      "1024",
+			expected: 1024,
+		},
+		{
+			name:     "null",
+			src:      nil,
+			expected: 0,
+		},
+		{
+			name: "bad text",
+			src:  []byte("x"),
+			err:  `bitmask: cannot scan "x" into Mask: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name: "unsupported type",
+			src:  1.5,
+			err:  "bitmask: cannot scan float64 into Mask",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Mask(7)
+			err := m.Scan(tt.src)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, m, tt.name)
+		})
+	}
+}
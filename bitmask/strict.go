@@ -0,0 +1,116 @@
+package bitmask
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MaxBit is the highest ID the strict functions accept by default.
+//
+// The top bit of an int is its sign bit: setting it makes the mask negative,
+// which breaks ordering and makes Decode loop forever, so it is excluded. On
+// 64-bit platforms MaxBit is 62.
+const MaxBit = strconv.IntSize - 2
+
+// ErrOutOfRange is returned (wrapped in a *RangeError) when an ID doesn't fit in a mask.
+var ErrOutOfRange = errors.New("bit ID out of range")
+
+// RangeError reports an ID that is negative or above the allowed maximum.
+type RangeError struct {
+	ID  int
+	Max int
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("bitmask: ID %d out of range [0, %d]", e.ID, e.Max)
+}
+
+// Unwrap returns ErrOutOfRange.
+func (e *RangeError) Unwrap() error {
+	return ErrOutOfRange
+}
+
+// StrictOption configures EncodeStrict and SetBitStrict.
+type StrictOption func(*strictConfig)
+
+type strictConfig struct {
+	max int
+}
+
+// WithMaxBit lowers the highest accepted ID, for masks that only have room for a
+// fixed number of flags, such as a SMALLINT column holding 15. Values above
+// MaxBit are capped at MaxBit.
+//
+// Example:
+//
+//	EncodeStrict([]int{1, 9}, WithMaxBit(7)) => error: bitmask: ID 9 out of range [0, 7]
+func WithMaxBit(max int) StrictOption {
+	return func(c *strictConfig) {
+		c.max = min(max, MaxBit)
+	}
+}
+
+func newStrictConfig(opts []StrictOption) strictConfig {
+	c := strictConfig{max: MaxBit}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+func (c strictConfig) check(id int) error {
+	if id < 0 || id > c.max {
+		return &RangeError{ID: id, Max: c.max}
+	}
+
+	return nil
+}
+
+// EncodeStrict is like Encode but returns an error naming the first ID that is
+// negative or above MaxBit (or the limit set with WithMaxBit), where Encode would
+// silently produce a wrong mask.
+//
+// Example:
+//
+//	EncodeStrict([]int{1, 3, 5})  => 42, nil
+//	EncodeStrict([]int{1, 70})    => 0, bitmask: ID 70 out of range [0, 62]
+func EncodeStrict(ids []int, opts ...StrictOption) (int, error) {
+	c := newStrictConfig(opts)
+
+	var mask int
+	for _, id := range ids {
+		if err := c.check(id); err != nil {
+			return 0, err
+		}
+		mask |= 1 << id
+	}
+
+	return mask, nil
+}
+
+// MustOf is like Of but panics if an ID is negative or above MaxBit. Use it for
+// masks built from constants, where an out-of-range ID is a programming error.
+//
+// Example:
+//
+//	var defaultRoles = MustOf(RoleViewer, RoleCommenter)
+func MustOf(ids ...int) int {
+	mask, err := EncodeStrict(ids)
+	if err != nil {
+		panic(err)
+	}
+
+	return mask
+}
+
+// SetBitStrict is like SetBit but returns an error, and the mask unchanged, if
+// id is negative or above MaxBit (or the limit set with WithMaxBit).
+func SetBitStrict(mask int, id int, opts ...StrictOption) (int, error) {
+	if err := newStrictConfig(opts).check(id); err != nil {
+		return mask, err
+	}
+
+	return mask | (1 << id), nil
+}
@@ -0,0 +1,132 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		opts     []StrictOption
+		expected int
+		err      string
+	}{
+		{
+			name:     "valid",
+			ids:      []int{1, 3, 5},
+			expected: 42,
+		},
+		{
+			name:     "highest allowed",
+			ids:      []int{MaxBit},
+			expected: 1 << MaxBit,
+		},
+		{
+			name: "sign bit",
+			ids:  []int{MaxBit + 1},
+			err:  "bitmask: ID 63 out of range [0, 62]",
+		},
+		{
+			name: "negative",
+			ids:  []int{1, -1, 100},
+			err:  "bitmask: ID -1 out of range [0, 62]",
+		},
+		{
+			name: "custom max",
+			ids:  []int{1, 9},
+			opts: []StrictOption{WithMaxBit(7)},
+			err:  "bitmask: ID 9 out of range [0, 7]",
+		},
+		{
+			name:     "custom max fits",
+			ids:      []int{0, 7},
+			opts:     []StrictOption{WithMaxBit(7)},
+			expected: 129,
+		},
+		{
+			name: "custom max is capped",
+			ids:  []int{63},
+			opts: []StrictOption{WithMaxBit(1000)},
+			err:  "bitmask: ID 63 out of range [0, 62]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if MaxBit != 62 && tt.err != "" {
+				t.Skip("error messages assume a 64-bit int")
+			}
+			mask, err := EncodeStrict(tt.ids, tt.opts...)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.ErrorIs(t, err, ErrOutOfRange)
+				assert.Equal(t, 0, mask)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, mask, tt.name)
+		})
+	}
+}
+
+func TestSetBitStrict(t *testing.T) {
+	mask, err := SetBitStrict(34, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, mask)
+
+	mask, err = SetBitStrict(42, -2)
+	assert.Equal(t, 42, mask, "mask unchanged on error")
+	var rangeErr *RangeError
+	assert.ErrorAs(t, err, &rangeErr)
+	assert.Equal(t, -2, rangeErr.ID)
+	assert.Equal(t, MaxBit, rangeErr.Max)
+
+	_, err = SetBitStrict(0, 16, WithMaxBit(15))
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		expected int
+	}{
+		{
+			name:     "no ids",
+			ids:      nil,
+			expected: 0,
+		},
+		{
+			name:     "multiple bits",
+			ids:      []int{1, 3, 5},
+			expected: 42,
+		},
+		{
+			name:     "duplicate ids",
+			ids:      []int{1, 1, 3},
+			expected: 10,
+		},
+		{
+			name:     "max bit",
+			ids:      []int{MaxBit},
+			expected: 1 << MaxBit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Of(tt.ids...), "Of")
+			assert.Equal(t, tt.expected, MustOf(tt.ids...), "MustOf")
+		})
+	}
+}
+
+func TestMustOf(t *testing.T) {
+	assert.Equal(t, 42, MustOf(1, 3, 5))
+	assert.Equal(t, 0, MustOf())
+	assert.Equal(t, 1<<MaxBit, MustOf(MaxBit))
+
+	assert.PanicsWithError(t, "bitmask: ID 70 out of range [0, 62]", func() { MustOf(1, 70) })
+	assert.PanicsWithError(t, "bitmask: ID -1 out of range [0, 62]", func() { MustOf(-1) })
+}
@@ -0,0 +1,61 @@
+package bitmask
+
+import "math/bits"
+
+// Unsigned is the set of unsigned integer types that can hold a bitmask.
+//
+// It matches golang.org/x/exp/constraints.Unsigned without pulling in the
+// dependency.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// EncodeUnsigned is Encode for a mask of any unsigned type, so masks can match the
+// width of a wire format or database column instead of the platform's int.
+//
+// The type parameter has to be given explicitly since it can't be inferred from the IDs.
+// IDs at or above the width of T don't fit and are ignored.
+//
+// Example:
+//
+//	EncodeUnsigned[uint8]([]int{1, 3, 5}) => 42 (binary: 00101010)
+//	EncodeUnsigned[uint8]([]int{1, 8})    => 2  (bit 8 doesn't fit in a uint8)
+func EncodeUnsigned[T Unsigned](ids []int) T {
+	var mask T
+
+	for _, id := range ids {
+		mask |= 1 << id
+	}
+
+	return mask
+}
+
+// DecodeUnsigned is Decode for a mask of any unsigned type.
+//
+// Example:
+//
+//	DecodeUnsigned(uint16(0b1000000000000101)) => []int{0, 2, 15}
+func DecodeUnsigned[T Unsigned](mask T) []int {
+	ids := make([]int, 0, bits.OnesCount64(uint64(mask)))
+
+	for bit := 0; mask != 0; bit++ {
+		if mask&1 == 1 {
+			ids = append(ids, bit)
+		}
+		mask >>= 1
+	}
+
+	return ids
+}
+
+// HasBitUnsigned is HasBit for a mask of any unsigned type.
+// It reports false for IDs at or above the width of T.
+func HasBitUnsigned[T Unsigned](mask T, id int) bool {
+	return (mask & (1 << id)) != 0
+}
+
+// ToggleBitUnsigned is ToggleBit for a mask of any unsigned type.
+// Toggling an ID at or above the width of T leaves the mask unchanged.
+func ToggleBitUnsigned[T Unsigned](mask T, id int) T {
+	return mask ^ (1 << id)
+}
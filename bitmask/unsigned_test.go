@@ -0,0 +1,75 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type permission uint16
+
+func TestEncodeUnsigned(t *testing.T) {
+	assert.Equal(t, uint8(42), EncodeUnsigned[uint8]([]int{1, 3, 5}))
+	assert.Equal(t, uint8(2), EncodeUnsigned[uint8]([]int{1, 8}), "bits beyond the width are dropped")
+	assert.Equal(t, uint16(1<<15), EncodeUnsigned[uint16]([]int{15}))
+	assert.Equal(t, uint32(1<<31|1), EncodeUnsigned[uint32]([]int{0, 31}))
+	assert.Equal(t, uint64(1<<63), EncodeUnsigned[uint64]([]int{63}))
+	assert.Equal(t, permission(6), EncodeUnsigned[permission]([]int{1, 2}))
+	assert.Equal(t, uint(0), EncodeUnsigned[uint](nil))
+}
+
+func TestDecodeUnsigned(t *testing.T) {
+	tests := []struct {
+		name     string
+		decoded  []int
+		expected []int
+	}{
+		{
+			name:     "uint8",
+			decoded:  DecodeUnsigned(uint8(42)),
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:     "uint16 high bit",
+			decoded:  DecodeUnsigned(uint16(0b1000000000000101)),
+			expected: []int{0, 2, 15},
+		},
+		{
+			name:     "uint64 high bit",
+			decoded:  DecodeUnsigned(uint64(1 << 63)),
+			expected: []int{63},
+		},
+		{
+			name:     "named type",
+			decoded:  DecodeUnsigned(permission(6)),
+			expected: []int{1, 2},
+		},
+		{
+			name:     "zero",
+			decoded:  DecodeUnsigned(uint32(0)),
+			expected: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.decoded, tt.name)
+		})
+	}
+}
+
+func TestUnsignedRoundTrip(t *testing.T) {
+	ids := []int{0, 7, 13, 31}
+	assert.Equal(t, ids, DecodeUnsigned(EncodeUnsigned[uint32](ids)))
+}
+
+func TestHasAndToggleBitUnsigned(t *testing.T) {
+	mask := uint8(42)
+	assert.True(t, HasBitUnsigned(mask, 3))
+	assert.False(t, HasBitUnsigned(mask, 2))
+	assert.False(t, HasBitUnsigned(mask, 9))
+
+	assert.Equal(t, uint8(34), ToggleBitUnsigned(mask, 3))
+	assert.Equal(t, uint8(46), ToggleBitUnsigned(mask, 2))
+	assert.Equal(t, mask, ToggleBitUnsigned(mask, 8))
+	assert.Equal(t, uint64(1<<40), ToggleBitUnsigned(uint64(0), 40))
+}
@@ -0,0 +1,159 @@
+package bitmask
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ yaml.Marshaler   = Mask(0)
+	_ yaml.Unmarshaler = (*Mask)(nil)
+	_ yaml.Marshaler   = (*YAMLMask)(nil)
+	_ yaml.Unmarshaler = (*YAMLMask)(nil)
+)
+
+// DefaultFlags, if set, is the FlagSet plain Mask values use for flag names in
+// YAML. It is nil by default, in which case a Mask is written as an integer
+// and only an integer can be read. Prefer FlagMask or FlagSet.YAML, which bind
+// the FlagSet to the value instead of to the whole process.
+var DefaultFlags *FlagSet
+
+// MarshalYAML writes the mask using DefaultFlags; see FlagMask.
+func (m Mask) MarshalYAML() (any, error) {
+	return marshalMaskYAML(DefaultFlags, m), nil
+}
+
+// UnmarshalYAML reads an integer, or a list of names registered in
+// DefaultFlags; see FlagMask.
+func (m *Mask) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalMaskYAML(DefaultFlags, value, m)
+}
+
+// FlagSource names the FlagSet a FlagMask uses. Implement it on an empty
+// struct type; its zero value is used, so the method must not depend on
+// receiver state.
+type FlagSource interface {
+	Flags() *FlagSet
+}
+
+// FlagMask is a Mask that reads and writes flag names from the FlagSet that S
+// returns, for YAML configuration structs. In YAML a mask is either an integer
+// or a list of flag names:
+//
+//	features: [audit, beta]
+//	legacy: 1024
+//
+// Marshaling writes a flow list of names when every set bit has a name, and an
+// integer otherwise. Binding the FlagSet to the type, rather than to shared
+// state, keeps one package's registrations from changing how another
+// package's masks are read or written. FlagSet.YAML does the same for a single
+// value.
+//
+// Example:
+//
+//	var features = bitmask.NewFlagSet()
+//
+//	type featureFlags struct{}
+//
+//	func (featureFlags) Flags() *bitmask.FlagSet { return features }
+//
+//	type Config struct {
+//		Features bitmask.FlagMask[featureFlags] `yaml:"features"`
+//	}
+//
+// An unknown name is an error listing the valid ones, and null leaves the mask
+// unchanged.
+type FlagMask[S FlagSource] Mask
+
+// MarshalYAML writes the mask as a list of names, or as an integer if a set bit
+// has no name.
+func (m FlagMask[S]) MarshalYAML() (any, error) {
+	var src S
+	return marshalMaskYAML(src.Flags(), Mask(m)), nil
+}
+
+// UnmarshalYAML reads an integer or a list of names.
+func (m *FlagMask[S]) UnmarshalYAML(value *yaml.Node) error {
+	var src S
+	return unmarshalMaskYAML(src.Flags(), value, (*Mask)(m))
+}
+
+// YAMLMask marshals a Mask to and from YAML using a FlagSet; see FlagSet.YAML.
+type YAMLMask struct {
+	mask  *Mask
+	flags *FlagSet
+}
+
+// YAML returns a value that reads and writes *m in YAML using fs's names, for
+// a mask that isn't a FlagMask field.
+//
+// Example:
+//
+//	var features bitmask.Mask
+//	err := node.Decode(featureFlags.YAML(&features))
+func (fs *FlagSet) YAML(m *Mask) *YAMLMask {
+	return &YAMLMask{mask: m, flags: fs}
+}
+
+// MarshalYAML writes the mask as a list of names, or as an integer if a set bit
+// has no name.
+func (y *YAMLMask) MarshalYAML() (any, error) {
+	return marshalMaskYAML(y.flags, *y.mask), nil
+}
+
+// UnmarshalYAML reads an integer or a list of names.
+func (y *YAMLMask) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalMaskYAML(y.flags, value, y.mask)
+}
+
+// marshalMaskYAML writes m as a flow list of names from fs, or as an integer if
+// fs is nil or lacks a name for one of m's bits.
+func marshalMaskYAML(fs *FlagSet, m Mask) *yaml.Node {
+	if fs == nil || fs.Unknown(int(m)) != 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(int(m))}
+	}
+
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+	for _, name := range fs.DecodeNames(int(m)) {
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+	}
+
+	return seq
+}
+
+// unmarshalMaskYAML reads an integer, or a list of names resolved through fs,
+// into *m. A nil fs accepts only integers.
+func unmarshalMaskYAML(fs *FlagSet, value *yaml.Node, m *Mask) error {
+	switch {
+	case value.Kind == yaml.ScalarNode && value.ShortTag() == "!!null":
+		return nil
+	case value.Kind == yaml.ScalarNode:
+		var n int
+		if err := value.Decode(&n); err != nil {
+			return fmt.Errorf("bitmask: mask must be a list of flag names or an integer: %w", err)
+		}
+		*m = Mask(n)
+		return nil
+	case value.Kind != yaml.SequenceNode:
+		return fmt.Errorf("bitmask: mask must be a list of flag names or an integer (line %d)", value.Line)
+	case fs == nil:
+		return fmt.Errorf("bitmask: no FlagSet to resolve flag names (line %d); use FlagMask or FlagSet.YAML", value.Line)
+	}
+
+	names := make([]string, 0, len(value.Content))
+	for _, item := range value.Content {
+		if item.Kind != yaml.ScalarNode {
+			return fmt.Errorf("bitmask: flag name must be a string (line %d)", item.Line)
+		}
+		names = append(names, item.Value)
+	}
+	mask, err := fs.EncodeNames(names)
+	if err != nil {
+		return err
+	}
+	*m = Mask(mask)
+
+	return nil
+}
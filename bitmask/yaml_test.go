@@ -0,0 +1,166 @@
+package bitmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var testFeatures = func() *FlagSet {
+	fs := NewFlagSet()
+	fs.MustRegister(0, "audit")
+	fs.MustRegister(1, "beta")
+	fs.MustRegister(2, "tracing")
+	return fs
+}()
+
+type testFeatureFlags struct{}
+
+func (testFeatureFlags) Flags() *FlagSet { return testFeatures }
+
+type testFeatureMask = FlagMask[testFeatureFlags]
+
+func TestFlagMaskMarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     testFeatureMask
+		expected string
+	}{
+		{
+			name:     "names",
+			mask:     0b101,
+			expected: "features: [audit, tracing]\n",
+		},
+		{
+			name:     "empty",
+			mask:     0,
+			expected: "features: []\n",
+		},
+		{
+			name:     "unnamed bit",
+			mask:     0b1001,
+			expected: "features: 9\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := yaml.Marshal(map[string]testFeatureMask{"features": tt.mask})
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, string(out), tt.name)
+
+			var got map[string]testFeatureMask
+			assert.NoError(t, yaml.Unmarshal(out, &got), tt.name)
+			assert.Equal(t, tt.mask, got["features"], tt.name)
+		})
+	}
+}
+
+func TestFlagMaskUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected testFeatureMask
+		err      string
+	}{
+		{
+			name:     "flow list",
+			input:    "features: [beta, audit]",
+			expected: 0b011,
+		},
+		{
+			name:     "block list",
+			input:    "features:\n  - tracing\n  - beta\n",
+			expected: 0b110,
+		},
+		{
+			name:     "integer",
+			input:    "features: 1024",
+			expected: 1024,
+		},
+		{
+			name:     "hex integer",
+			input:    "features: 0x0f",
+			expected: 15,
+		},
+		{
+			name:     "null",
+			input:    "features: ~",
+			expected: 99,
+		},
+		{
+			name:  "unknown name",
+			input: "features: [audit, nope]",
+			err:   `bitmask: unknown flag "nope" (valid: audit, beta, tracing)`,
+		},
+		{
+			name:  "string scalar",
+			input: "features: audit",
+			err:   "bitmask: mask must be a list of flag names or an integer: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str `audit` into int",
+		},
+		{
+			name:  "mapping",
+			input: "features: {audit: true}",
+			err:   "bitmask: mask must be a list of flag names or an integer (line 1)",
+		},
+		{
+			name:  "nested list",
+			input: "features: [audit, [beta]]",
+			err:   "bitmask: flag name must be a string (line 1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := struct {
+				Features testFeatureMask `yaml:"features"`
+			}{Features: 99}
+			err := yaml.Unmarshal([]byte(tt.input), &cfg)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.Equal(t, testFeatureMask(99), cfg.Features, "unchanged on error")
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, cfg.Features, tt.name)
+		})
+	}
+}
+
+func TestFlagSetYAML(t *testing.T) {
+	m := Mask(0b011)
+	out, err := yaml.Marshal(testFeatures.YAML(&m))
+	assert.NoError(t, err)
+	assert.Equal(t, "[audit, beta]\n", string(out))
+
+	var got Mask
+	assert.NoError(t, yaml.Unmarshal([]byte("[tracing]"), testFeatures.YAML(&got)))
+	assert.Equal(t, Mask(0b100), got)
+
+	other := NewFlagSet()
+	other.MustRegister(0, "read")
+	assert.NoError(t, yaml.Unmarshal([]byte("[read]"), other.YAML(&got)))
+	assert.Equal(t, Mask(0b001), got, "each value uses its own FlagSet")
+}
+
+func TestMaskYAML(t *testing.T) {
+	out, err := yaml.Marshal(map[string]Mask{"features": 0b101})
+	assert.NoError(t, err)
+	assert.Equal(t, "features: 5\n", string(out), "integer without DefaultFlags")
+
+	var got map[string]Mask
+	assert.NoError(t, yaml.Unmarshal([]byte("features: 7"), &got))
+	assert.Equal(t, Mask(7), got["features"])
+
+	err = yaml.Unmarshal([]byte("features: [audit]"), &got)
+	assert.EqualError(t, err, "bitmask: no FlagSet to resolve flag names (line 1); use FlagMask or FlagSet.YAML")
+
+	// Opting in to DefaultFlags.
+	t.Cleanup(func() { DefaultFlags = nil })
+	DefaultFlags = testFeatures
+
+	out, err = yaml.Marshal(map[string]Mask{"features": 0b101})
+	assert.NoError(t, err)
+	assert.Equal(t, "features: [audit, tracing]\n", string(out))
+	assert.NoError(t, yaml.Unmarshal([]byte("features: [beta]"), &got))
+	assert.Equal(t, Mask(0b010), got["features"])
+}
@@ -0,0 +1,64 @@
+package ds
+
+import "sync/atomic"
+
+type mpscNode[T any] struct {
+	next  atomic.Pointer[mpscNode[T]]
+	value T
+}
+
+// MPSCQueue is an unbounded, lock-free multi-producer single-consumer queue.
+//
+// Any number of goroutines may call Push concurrently; Push never blocks and costs
+// one allocation plus one atomic swap. Only a single goroutine may call Pop at a time.
+//
+// It is the intrusive queue described by Dmitry Vyukov: producers swap themselves
+// in as the new head and then link the previous head to them. Between those two
+// steps the consumer can briefly see the queue as empty even though an item is
+// on its way, so Pop returning false means "nothing ready yet", not "nothing pushed".
+//
+// Use NewMPSCQueue to create one; the zero value is not usable.
+type MPSCQueue[T any] struct {
+	head atomic.Pointer[mpscNode[T]] // last pushed node, shared by producers
+	tail *mpscNode[T]                // consumer-owned; tail.next is the next value
+}
+
+// NewMPSCQueue returns an empty queue.
+func NewMPSCQueue[T any]() *MPSCQueue[T] {
+	stub := &mpscNode[T]{}
+	q := &MPSCQueue[T]{tail: stub}
+	q.head.Store(stub)
+
+	return q
+}
+
+// Push adds v to the back of the queue. It is safe for concurrent use.
+func (q *MPSCQueue[T]) Push(v T) {
+	n := &mpscNode[T]{value: v}
+	prev := q.head.Swap(n)
+	prev.next.Store(n)
+}
+
+// Pop removes and returns the value at the front of the queue, or false if no
+// value is ready. It must only be called from a single consumer goroutine.
+func (q *MPSCQueue[T]) Pop() (T, bool) {
+	next := q.tail.next.Load()
+	if next == nil {
+		var zero T
+		return zero, false
+	}
+
+	// next becomes the new stub; clear its value so the queue doesn't keep it alive.
+	v := next.value
+	var zero T
+	next.value = zero
+	q.tail = next
+
+	return v, true
+}
+
+// Empty reports whether no value is ready to be popped.
+// Like Pop, it must only be called from the consumer goroutine.
+func (q *MPSCQueue[T]) Empty() bool {
+	return q.tail.next.Load() == nil
+}
@@ -0,0 +1,125 @@
+package ds
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMPSCQueueOrder(t *testing.T) {
+	q := NewMPSCQueue[int]()
+	assert.True(t, q.Empty())
+
+	_, ok := q.Pop()
+	assert.False(t, ok)
+
+	for i := 1; i <= 3; i++ {
+		q.Push(i)
+	}
+	assert.False(t, q.Empty())
+
+	for i := 1; i <= 3; i++ {
+		v, ok := q.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	_, ok = q.Pop()
+	assert.False(t, ok)
+}
+
+func TestMPSCQueueConcurrentProducers(t *testing.T) {
+	const (
+		producers = 8
+		perWorker = 5000
+	)
+
+	q := NewMPSCQueue[[2]int]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				q.Push([2]int{p, i})
+			}
+		}(p)
+	}
+
+	// Values from a single producer must arrive in the order it pushed them.
+	last := make([]int, producers)
+	for i := range last {
+		last[i] = -1
+	}
+
+	received := 0
+	for received < producers*perWorker {
+		v, ok := q.Pop()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if v[1] != last[v[0]]+1 {
+			t.Fatalf("producer %d: got %d after %d", v[0], v[1], last[v[0]])
+		}
+		last[v[0]] = v[1]
+		received++
+	}
+	wg.Wait()
+
+	assert.True(t, q.Empty())
+}
+
+func BenchmarkMPSCQueue(b *testing.B) {
+	q := NewMPSCQueue[int]()
+
+	var (
+		done     atomic.Bool
+		consumer sync.WaitGroup
+	)
+	consumer.Add(1)
+	go func() {
+		defer consumer.Done()
+		for {
+			if _, ok := q.Pop(); !ok {
+				if done.Load() && q.Empty() {
+					return
+				}
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			q.Push(i)
+		}
+	})
+	done.Store(true)
+	consumer.Wait()
+}
+
+func BenchmarkBufferedChannel(b *testing.B) {
+	ch := make(chan int, 1024)
+
+	var consumer sync.WaitGroup
+	consumer.Add(1)
+	go func() {
+		defer consumer.Done()
+		for range ch {
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			ch <- i
+		}
+	})
+	close(ch)
+	consumer.Wait()
+}
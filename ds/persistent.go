@@ -0,0 +1,261 @@
+package ds
+
+import (
+	"iter"
+	"slices"
+)
+
+// PersistentList is an immutable singly linked list.
+//
+// Prepend returns a new list that shares every existing node with the old one,
+// so keeping many versions around (e.g. an undo history) costs one node per version.
+//
+// The zero value is an empty list ready to use.
+type PersistentList[T any] struct {
+	node *listNode[T]
+}
+
+type listNode[T any] struct {
+	value T
+	next  *listNode[T]
+	size  int
+}
+
+// NewPersistentList returns a list holding values in the given order.
+func NewPersistentList[T any](values ...T) PersistentList[T] {
+	var l PersistentList[T]
+	for i := len(values) - 1; i >= 0; i-- {
+		l = l.Prepend(values[i])
+	}
+
+	return l
+}
+
+// Prepend returns a new list with v in front of l. l itself is unchanged. O(1).
+func (l PersistentList[T]) Prepend(v T) PersistentList[T] {
+	return PersistentList[T]{node: &listNode[T]{value: v, next: l.node, size: l.Len() + 1}}
+}
+
+// Head returns the first value, or false if the list is empty.
+func (l PersistentList[T]) Head() (T, bool) {
+	if l.node == nil {
+		var zero T
+		return zero, false
+	}
+
+	return l.node.value, true
+}
+
+// Tail returns the list without its first value. The tail of an empty list is empty.
+func (l PersistentList[T]) Tail() PersistentList[T] {
+	if l.node == nil {
+		return l
+	}
+
+	return PersistentList[T]{node: l.node.next}
+}
+
+// Len returns the number of values in the list. O(1).
+func (l PersistentList[T]) Len() int {
+	if l.node == nil {
+		return 0
+	}
+
+	return l.node.size
+}
+
+// All returns an iterator over the list's values from head to tail.
+func (l PersistentList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.node; n != nil; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the list's values as a new slice.
+func (l PersistentList[T]) Values() []T {
+	values := make([]T, 0, l.Len())
+	for v := range l.All() {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// vectorNode is either a branch (children set) or a leaf (values set) of the vector trie.
+type vectorNode[T any] struct {
+	children []*vectorNode[T]
+	values   []T
+}
+
+// PersistentVector is an immutable indexed sequence.
+//
+// It is a 32-way trie with a separate tail buffer, the layout popularised by
+// Clojure: Append and Set copy only the path from the root to the affected leaf
+// (at most a handful of 32-element nodes), and every other node is shared with
+// the previous version. Get is O(log32 n), which is effectively constant.
+//
+// The zero value is an empty vector ready to use.
+type PersistentVector[T any] struct {
+	count int
+	shift uint
+	root  *vectorNode[T]
+	tail  []T
+}
+
+// NewPersistentVector returns a vector holding values in the given order.
+func NewPersistentVector[T any](values ...T) PersistentVector[T] {
+	var v PersistentVector[T]
+	for _, value := range values {
+		v = v.Append(value)
+	}
+
+	return v
+}
+
+// Len returns the number of values in the vector.
+func (v PersistentVector[T]) Len() int {
+	return v.count
+}
+
+// tailOffset returns the index of the first value stored in the tail.
+func (v PersistentVector[T]) tailOffset() int {
+	if v.count < vectorWidth {
+		return 0
+	}
+
+	return ((v.count - 1) >> vectorBits) << vectorBits
+}
+
+// Get returns the value at index i. It panics if i is out of range.
+func (v PersistentVector[T]) Get(i int) T {
+	if i < 0 || i >= v.count {
+		panic("ds: PersistentVector index out of range")
+	}
+	if off := v.tailOffset(); i >= off {
+		return v.tail[i-off]
+	}
+
+	node := v.root
+	for level := v.shift; level > 0; level -= vectorBits {
+		node = node.children[(i>>level)&vectorMask]
+	}
+
+	return node.values[i&vectorMask]
+}
+
+// Append returns a new vector with value added at the end. v itself is unchanged.
+func (v PersistentVector[T]) Append(value T) PersistentVector[T] {
+	if len(v.tail) < vectorWidth {
+		tail := make([]T, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = value
+
+		return PersistentVector[T]{count: v.count + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	// The tail is full: push it into the trie as a leaf and start a new one.
+	leaf := &vectorNode[T]{values: v.tail}
+	root, shift := v.root, v.shift
+	switch {
+	case root == nil:
+		root, shift = &vectorNode[T]{children: []*vectorNode[T]{leaf}}, vectorBits
+	case v.count>>vectorBits > 1<<shift:
+		// The trie is full at this height: grow a new root.
+		root = &vectorNode[T]{children: []*vectorNode[T]{root, newVectorPath(shift, leaf)}}
+		shift += vectorBits
+	default:
+		root = v.pushTail(shift, root, leaf)
+	}
+
+	return PersistentVector[T]{count: v.count + 1, shift: shift, root: root, tail: []T{value}}
+}
+
+func (v PersistentVector[T]) pushTail(level uint, parent, leaf *vectorNode[T]) *vectorNode[T] {
+	i := ((v.count - 1) >> level) & vectorMask
+	node := &vectorNode[T]{children: slices.Clone(parent.children)}
+	if i == len(node.children) {
+		node.children = append(node.children, nil)
+	}
+
+	switch {
+	case level == vectorBits:
+		node.children[i] = leaf
+	case parent.children != nil && i < len(parent.children):
+		node.children[i] = v.pushTail(level-vectorBits, parent.children[i], leaf)
+	default:
+		node.children[i] = newVectorPath(level-vectorBits, leaf)
+	}
+
+	return node
+}
+
+func newVectorPath[T any](level uint, leaf *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return leaf
+	}
+
+	return &vectorNode[T]{children: []*vectorNode[T]{newVectorPath(level-vectorBits, leaf)}}
+}
+
+// Set returns a new vector with the value at index i replaced. v itself is unchanged.
+// It panics if i is out of range.
+func (v PersistentVector[T]) Set(i int, value T) PersistentVector[T] {
+	if i < 0 || i >= v.count {
+		panic("ds: PersistentVector index out of range")
+	}
+
+	if off := v.tailOffset(); i >= off {
+		tail := slices.Clone(v.tail)
+		tail[i-off] = value
+
+		return PersistentVector[T]{count: v.count, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	return PersistentVector[T]{count: v.count, shift: v.shift, root: setVectorPath(v.shift, v.root, i, value), tail: v.tail}
+}
+
+func setVectorPath[T any](level uint, node *vectorNode[T], i int, value T) *vectorNode[T] {
+	if level == 0 {
+		leaf := &vectorNode[T]{values: slices.Clone(node.values)}
+		leaf.values[i&vectorMask] = value
+
+		return leaf
+	}
+
+	branch := &vectorNode[T]{children: slices.Clone(node.children)}
+	j := (i >> level) & vectorMask
+	branch.children[j] = setVectorPath(level-vectorBits, node.children[j], i, value)
+
+	return branch
+}
+
+// All returns an iterator over the vector's indexes and values in order.
+func (v PersistentVector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < v.count; i++ {
+			if !yield(i, v.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the vector's values as a new slice.
+func (v PersistentVector[T]) Values() []T {
+	values := make([]T, 0, v.count)
+	for _, value := range v.All() {
+		values = append(values, value)
+	}
+
+	return values
+}
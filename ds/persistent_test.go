@@ -0,0 +1,120 @@
+package ds
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentList(t *testing.T) {
+	var empty PersistentList[int]
+	assert.Equal(t, 0, empty.Len())
+	_, ok := empty.Head()
+	assert.False(t, ok)
+	assert.Equal(t, 0, empty.Tail().Len())
+
+	base := NewPersistentList(2, 3)
+	one := base.Prepend(1)
+	other := base.Prepend(9)
+
+	assert.Equal(t, []int{2, 3}, base.Values(), "original unchanged")
+	assert.Equal(t, []int{1, 2, 3}, one.Values())
+	assert.Equal(t, []int{9, 2, 3}, other.Values())
+	assert.Equal(t, 3, one.Len())
+
+	head, ok := one.Head()
+	assert.True(t, ok)
+	assert.Equal(t, 1, head)
+	assert.Equal(t, base.Values(), one.Tail().Values())
+	assert.Same(t, base.node, one.Tail().node, "tail is shared, not copied")
+}
+
+func TestPersistentVectorAppendGet(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{
+			name: "empty",
+			n:    0,
+		},
+		{
+			name: "tail only",
+			n:    vectorWidth,
+		},
+		{
+			name: "one level",
+			n:    vectorWidth*vectorWidth + 1,
+		},
+		{
+			name: "two levels",
+			n:    vectorWidth*vectorWidth*vectorWidth + 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v PersistentVector[int]
+			for i := 0; i < tt.n; i++ {
+				v = v.Append(i * 10)
+			}
+
+			assert.Equal(t, tt.n, v.Len(), tt.name)
+			for i := 0; i < tt.n; i++ {
+				if v.Get(i) != i*10 {
+					t.Fatalf("Get(%d) = %d, want %d", i, v.Get(i), i*10)
+				}
+			}
+		})
+	}
+}
+
+func TestPersistentVectorVersionsAreIndependent(t *testing.T) {
+	v1 := NewPersistentVector(0, 1, 2)
+	v2 := v1.Append(3)
+	v3 := v1.Append(4)
+	v4 := v2.Set(0, 100)
+
+	assert.Equal(t, []int{0, 1, 2}, v1.Values())
+	assert.Equal(t, []int{0, 1, 2, 3}, v2.Values())
+	assert.Equal(t, []int{0, 1, 2, 4}, v3.Values())
+	assert.Equal(t, []int{100, 1, 2, 3}, v4.Values())
+}
+
+func TestPersistentVectorSetInTrie(t *testing.T) {
+	values := make([]int, 2000)
+	for i := range values {
+		values[i] = i
+	}
+	v := NewPersistentVector(values...)
+
+	for _, i := range []int{0, 31, 32, 1023, 1024, 1999} {
+		updated := v.Set(i, -1)
+
+		expected := slices.Clone(values)
+		expected[i] = -1
+		assert.Equal(t, expected, updated.Values(), "set %d", i)
+		assert.Equal(t, i, v.Get(i), "original unchanged at %d", i)
+	}
+}
+
+func TestPersistentVectorOutOfRange(t *testing.T) {
+	v := NewPersistentVector(1, 2)
+
+	assert.Panics(t, func() { v.Get(2) })
+	assert.Panics(t, func() { v.Get(-1) })
+	assert.Panics(t, func() { v.Set(5, 0) })
+}
+
+func TestPersistentVectorAll(t *testing.T) {
+	v := NewPersistentVector("a", "b", "c")
+
+	var got []string
+	for i, s := range v.All() {
+		got = append(got, s)
+		if i == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, got)
+}
@@ -0,0 +1,129 @@
+package ds
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Pool is a typed wrapper around sync.Pool.
+//
+// New constructs a value when the pool is empty and must be set before Get is
+// called. Reset, if set, is called on every value passed to Put so that pooled
+// values are always handed out clean (e.g. a buffer truncated to length zero).
+//
+// Like sync.Pool, idle values may be dropped at any garbage collection, and a
+// Pool must not be copied after first use.
+//
+// Example:
+//
+//	buffers := ds.Pool[*bytes.Buffer]{
+//		New:   func() *bytes.Buffer { return new(bytes.Buffer) },
+//		Reset: func(b *bytes.Buffer) { b.Reset() },
+//	}
+//	buf := buffers.Get()
+//	defer buffers.Put(buf)
+type Pool[T any] struct {
+	New   func() T
+	Reset func(T)
+
+	pool sync.Pool
+}
+
+// NewPool returns a Pool that constructs values with newFn and cleans them with reset.
+// reset may be nil.
+func NewPool[T any](newFn func() T, reset func(T)) *Pool[T] {
+	return &Pool[T]{New: newFn, Reset: reset}
+}
+
+// Get returns a pooled value, or a new one from New if the pool is empty.
+func (p *Pool[T]) Get() T {
+	if v, ok := p.pool.Get().(T); ok {
+		return v
+	}
+
+	return p.New()
+}
+
+// Put resets v and returns it to the pool.
+func (p *Pool[T]) Put(v T) {
+	if p.Reset != nil {
+		p.Reset(v)
+	}
+	p.pool.Put(v)
+}
+
+// PoolStats is a snapshot of a BoundedPool's counters.
+type PoolStats struct {
+	Gets    uint64 // calls to Get
+	Puts    uint64 // calls to Put
+	Misses  uint64 // Gets that had to construct a new value
+	Dropped uint64 // Puts discarded because the pool was full
+	Idle    int    // values currently held by the pool
+}
+
+// BoundedPool is a pool that holds at most a fixed number of idle values.
+//
+// Unlike Pool, idle values are never released by the garbage collector, and
+// values returned while the pool is full are dropped. This gives a predictable
+// upper bound on retained memory, which matters for large buffers.
+//
+// Use NewBoundedPool to create one; the zero value is not usable.
+type BoundedPool[T any] struct {
+	newFn func() T
+	reset func(T)
+	idle  chan T
+
+	gets, puts, misses, dropped atomic.Uint64
+}
+
+// NewBoundedPool returns a pool holding at most capacity idle values.
+// reset may be nil. It panics if capacity is negative.
+func NewBoundedPool[T any](capacity int, newFn func() T, reset func(T)) *BoundedPool[T] {
+	if capacity < 0 {
+		panic("ds: negative BoundedPool capacity")
+	}
+
+	return &BoundedPool[T]{newFn: newFn, reset: reset, idle: make(chan T, capacity)}
+}
+
+// Get returns an idle value, or a new one if none is available. It never blocks.
+func (p *BoundedPool[T]) Get() T {
+	p.gets.Add(1)
+	select {
+	case v := <-p.idle:
+		return v
+	default:
+		p.misses.Add(1)
+		return p.newFn()
+	}
+}
+
+// Put resets v and keeps it for reuse, or drops it if the pool is full. It never blocks.
+func (p *BoundedPool[T]) Put(v T) {
+	p.puts.Add(1)
+	if p.reset != nil {
+		p.reset(v)
+	}
+	select {
+	case p.idle <- v:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+// Cap returns the maximum number of idle values the pool holds.
+func (p *BoundedPool[T]) Cap() int {
+	return cap(p.idle)
+}
+
+// Stats returns a snapshot of the pool's counters.
+// Counters are read independently, so under concurrent use they may be slightly inconsistent.
+func (p *BoundedPool[T]) Stats() PoolStats {
+	return PoolStats{
+		Gets:    p.gets.Load(),
+		Puts:    p.puts.Load(),
+		Misses:  p.misses.Load(),
+		Dropped: p.dropped.Load(),
+		Idle:    len(p.idle),
+	}
+}
@@ -0,0 +1,94 @@
+package ds
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	created := 0
+	p := NewPool(
+		func() *bytes.Buffer {
+			created++
+			return new(bytes.Buffer)
+		},
+		func(b *bytes.Buffer) { b.Reset() },
+	)
+
+	buf := p.Get()
+	assert.Equal(t, 1, created)
+	buf.WriteString("dirty")
+
+	p.Put(buf)
+	assert.Equal(t, 0, buf.Len(), "reset on put")
+
+	// sync.Pool may or may not hand the same value back; either way it must be clean.
+	assert.Equal(t, 0, p.Get().Len())
+}
+
+func TestPoolWithoutReset(t *testing.T) {
+	p := Pool[[]byte]{New: func() []byte { return make([]byte, 0, 16) }}
+
+	b := p.Get()
+	assert.Equal(t, 16, cap(b))
+	p.Put(b)
+}
+
+func TestBoundedPool(t *testing.T) {
+	next := 0
+	p := NewBoundedPool(2, func() int {
+		next++
+		return next
+	}, nil)
+	assert.Equal(t, 2, p.Cap())
+
+	a, b, c := p.Get(), p.Get(), p.Get()
+	assert.Equal(t, []int{1, 2, 3}, []int{a, b, c})
+
+	p.Put(a)
+	p.Put(b)
+	p.Put(c)
+
+	assert.Equal(t, PoolStats{Gets: 3, Puts: 3, Misses: 3, Dropped: 1, Idle: 2}, p.Stats())
+
+	assert.Equal(t, a, p.Get())
+	assert.Equal(t, PoolStats{Gets: 4, Puts: 3, Misses: 3, Dropped: 1, Idle: 1}, p.Stats())
+}
+
+func TestBoundedPoolReset(t *testing.T) {
+	p := NewBoundedPool(1, func() *[]int { return new([]int) }, func(s *[]int) { *s = (*s)[:0] })
+
+	s := p.Get()
+	*s = append(*s, 1, 2, 3)
+	p.Put(s)
+
+	assert.Empty(t, *p.Get())
+}
+
+func TestBoundedPoolConcurrent(t *testing.T) {
+	p := NewBoundedPool(4, func() []byte { return make([]byte, 8) }, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				p.Put(p.Get())
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(8000), stats.Gets)
+	assert.Equal(t, uint64(8000), stats.Puts)
+	assert.LessOrEqual(t, stats.Idle, 4)
+}
+
+func TestNewBoundedPoolNegativeCapacity(t *testing.T) {
+	assert.Panics(t, func() { NewBoundedPool(-1, func() int { return 0 }, nil) })
+}
@@ -0,0 +1,139 @@
+// Package ds provides generic data structures that complement the standard library.
+//
+// The types here favour simple, cache-friendly layouts (sorted slices, paged arrays)
+// over pointer-heavy structures, since for the set sizes most services deal with
+// a contiguous slice beats a tree or a hash map on both memory and iteration speed.
+package ds
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedSet is a set of ordered values kept in a sorted slice.
+//
+// Lookups use binary search (O(log n)), while inserts and removals shift the
+// tail of the slice (O(n)). For small-to-medium sets this is usually faster than
+// a map because the whole set sits in a few cache lines, and ordered iteration
+// is free: it is just a walk over the slice.
+//
+// See BenchmarkSortedSetContains and BenchmarkMapContains for the crossover point.
+//
+// The zero value is an empty set ready to use.
+type SortedSet[T cmp.Ordered] struct {
+	items []T
+}
+
+// NewSortedSet returns a set holding the given values. Duplicates are dropped.
+//
+// Example:
+//
+//	NewSortedSet(5, 1, 3, 1).Values() => []int{1, 3, 5}
+func NewSortedSet[T cmp.Ordered](values ...T) *SortedSet[T] {
+	items := slices.Clone(values)
+	slices.Sort(items)
+
+	return &SortedSet[T]{items: slices.Compact(items)}
+}
+
+// Add inserts v into the set, keeping the slice sorted.
+// It returns false if v was already present.
+func (s *SortedSet[T]) Add(v T) bool {
+	i, found := slices.BinarySearch(s.items, v)
+	if found {
+		return false
+	}
+	s.items = slices.Insert(s.items, i, v)
+
+	return true
+}
+
+// Remove deletes v from the set. It returns false if v was not present.
+func (s *SortedSet[T]) Remove(v T) bool {
+	i, found := slices.BinarySearch(s.items, v)
+	if !found {
+		return false
+	}
+	s.items = slices.Delete(s.items, i, i+1)
+
+	return true
+}
+
+// Contains reports whether v is in the set.
+func (s *SortedSet[T]) Contains(v T) bool {
+	_, found := slices.BinarySearch(s.items, v)
+	return found
+}
+
+// Len returns the number of values in the set.
+func (s *SortedSet[T]) Len() int {
+	return len(s.items)
+}
+
+// At returns the i-th smallest value. It panics if i is out of range.
+func (s *SortedSet[T]) At(i int) T {
+	return s.items[i]
+}
+
+// Min returns the smallest value, or false if the set is empty.
+func (s *SortedSet[T]) Min() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return s.items[0], true
+}
+
+// Max returns the largest value, or false if the set is empty.
+func (s *SortedSet[T]) Max() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return s.items[len(s.items)-1], true
+}
+
+// Range returns the values v with lo <= v < hi, in ascending order.
+// The returned slice is a copy and safe to modify.
+//
+// Example:
+//
+//	NewSortedSet(1, 3, 5, 7).Range(2, 6) => []int{3, 5}
+func (s *SortedSet[T]) Range(lo, hi T) []T {
+	start, _ := slices.BinarySearch(s.items, lo)
+	end, _ := slices.BinarySearch(s.items, hi)
+	if start >= end {
+		return []T{}
+	}
+
+	return slices.Clone(s.items[start:end])
+}
+
+// Values returns a copy of the set's values in ascending order.
+func (s *SortedSet[T]) Values() []T {
+	if len(s.items) == 0 {
+		return []T{}
+	}
+
+	return slices.Clone(s.items)
+}
+
+// All returns an iterator over the set's values in ascending order.
+// The set must not be modified during iteration.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all values, keeping the allocated capacity.
+func (s *SortedSet[T]) Clear() {
+	s.items = s.items[:0]
+}
@@ -0,0 +1,200 @@
+package ds
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSortedSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		expected []int
+	}{
+		{
+			name:     "empty",
+			values:   nil,
+			expected: []int{},
+		},
+		{
+			name:     "unsorted",
+			values:   []int{5, 1, 3},
+			expected: []int{1, 3, 5},
+		},
+		{
+			name:     "duplicates",
+			values:   []int{2, 2, 1, 2},
+			expected: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSortedSet(tt.values...)
+			assert.Equal(t, tt.expected, s.Values(), tt.name)
+			assert.Equal(t, len(tt.expected), s.Len(), tt.name)
+		})
+	}
+}
+
+func TestSortedSetAddRemove(t *testing.T) {
+	var s SortedSet[string]
+
+	assert.True(t, s.Add("b"))
+	assert.True(t, s.Add("a"))
+	assert.True(t, s.Add("c"))
+	assert.False(t, s.Add("a"))
+	assert.Equal(t, []string{"a", "b", "c"}, s.Values())
+
+	assert.True(t, s.Contains("b"))
+	assert.True(t, s.Remove("b"))
+	assert.False(t, s.Remove("b"))
+	assert.False(t, s.Contains("b"))
+	assert.Equal(t, []string{"a", "c"}, s.Values())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestSortedSetMinMax(t *testing.T) {
+	var s SortedSet[int]
+
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.Max()
+	assert.False(t, ok)
+
+	s.Add(4)
+	s.Add(-2)
+	s.Add(9)
+
+	lowest, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, -2, lowest)
+
+	highest, ok := s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, highest)
+	assert.Equal(t, 4, s.At(1))
+}
+
+func TestSortedSetRange(t *testing.T) {
+	s := NewSortedSet(1, 3, 5, 7)
+
+	tests := []struct {
+		name     string
+		lo, hi   int
+		expected []int
+	}{
+		{
+			name:     "inner",
+			lo:       2,
+			hi:       6,
+			expected: []int{3, 5},
+		},
+		{
+			name:     "inclusive low exclusive high",
+			lo:       3,
+			hi:       7,
+			expected: []int{3, 5},
+		},
+		{
+			name:     "empty",
+			lo:       8,
+			hi:       10,
+			expected: []int{},
+		},
+		{
+			name:     "inverted",
+			lo:       6,
+			hi:       2,
+			expected: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.Range(tt.lo, tt.hi), tt.name)
+		})
+	}
+}
+
+func TestSortedSetAll(t *testing.T) {
+	s := NewSortedSet(3, 1, 2)
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(s.All()))
+
+	var first []int
+	for v := range s.All() {
+		first = append(first, v)
+		break
+	}
+	assert.Equal(t, []int{1}, first)
+}
+
+var benchSizes = []int{8, 64, 512, 4096, 32768}
+
+func BenchmarkSortedSetContains(b *testing.B) {
+	for _, n := range benchSizes {
+		s := &SortedSet[int]{}
+		for i := 0; i < n; i++ {
+			s.Add(i * 2)
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.Contains(i % (n * 2))
+			}
+		})
+	}
+}
+
+func BenchmarkMapContains(b *testing.B) {
+	for _, n := range benchSizes {
+		m := make(map[int]struct{}, n)
+		for i := 0; i < n; i++ {
+			m[i*2] = struct{}{}
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = m[i%(n*2)]
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSetIterate(b *testing.B) {
+	for _, n := range benchSizes {
+		s := &SortedSet[int]{}
+		for i := 0; i < n; i++ {
+			s.Add(i)
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum := 0
+				for v := range s.All() {
+					sum += v
+				}
+				_ = sum
+			}
+		})
+	}
+}
+
+func BenchmarkMapIterateSorted(b *testing.B) {
+	for _, n := range benchSizes {
+		m := make(map[int]struct{}, n)
+		for i := 0; i < n; i++ {
+			m[i] = struct{}{}
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				keys := make([]int, 0, len(m))
+				for k := range m {
+					keys = append(keys, k)
+				}
+				slices.Sort(keys)
+			}
+		})
+	}
+}
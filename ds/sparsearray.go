@@ -0,0 +1,157 @@
+package ds
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+	"unsafe"
+)
+
+const (
+	sparsePageBits = 8
+	sparsePageSize = 1 << sparsePageBits // entries per page
+	sparsePageMask = sparsePageSize - 1
+)
+
+// sparsePage holds a fixed-size run of values plus a presence bitmap,
+// so that zero values can be told apart from missing ones.
+type sparsePage[V any] struct {
+	present [sparsePageSize / 64]uint64
+	count   int
+	values  [sparsePageSize]V
+}
+
+// SparseArray maps uint64 indexes to values using fixed-size pages.
+//
+// Only pages that hold at least one value are allocated, so an index space as
+// large as snowflake-style IDs costs memory proportional to the number of
+// occupied pages rather than the largest index. Indexes that are close to each
+// other share a page, which keeps lookups for clustered IDs cache-friendly.
+//
+// The zero value is an empty array ready to use.
+type SparseArray[V any] struct {
+	pages map[uint64]*sparsePage[V]
+	count int
+}
+
+// NewSparseArray returns an empty SparseArray.
+func NewSparseArray[V any]() *SparseArray[V] {
+	return &SparseArray[V]{}
+}
+
+// Set stores v at index i.
+func (a *SparseArray[V]) Set(i uint64, v V) {
+	if a.pages == nil {
+		a.pages = make(map[uint64]*sparsePage[V])
+	}
+
+	key, slot := i>>sparsePageBits, i&sparsePageMask
+	p, ok := a.pages[key]
+	if !ok {
+		p = &sparsePage[V]{}
+		a.pages[key] = p
+	}
+
+	word, bit := slot/64, uint64(1)<<(slot%64)
+	if p.present[word]&bit == 0 {
+		p.present[word] |= bit
+		p.count++
+		a.count++
+	}
+	p.values[slot] = v
+}
+
+// Get returns the value at index i and whether it was set.
+func (a *SparseArray[V]) Get(i uint64) (V, bool) {
+	p, ok := a.pages[i>>sparsePageBits]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	slot := i & sparsePageMask
+	if p.present[slot/64]&(1<<(slot%64)) == 0 {
+		var zero V
+		return zero, false
+	}
+
+	return p.values[slot], true
+}
+
+// Delete removes the value at index i. It returns false if i was not set.
+// Pages left empty are released.
+func (a *SparseArray[V]) Delete(i uint64) bool {
+	key, slot := i>>sparsePageBits, i&sparsePageMask
+	p, ok := a.pages[key]
+	if !ok {
+		return false
+	}
+
+	word, bit := slot/64, uint64(1)<<(slot%64)
+	if p.present[word]&bit == 0 {
+		return false
+	}
+
+	var zero V
+	p.present[word] &^= bit
+	p.values[slot] = zero
+	p.count--
+	a.count--
+	if p.count == 0 {
+		delete(a.pages, key)
+	}
+
+	return true
+}
+
+// Len returns the number of indexes that hold a value.
+func (a *SparseArray[V]) Len() int {
+	return a.count
+}
+
+// Pages returns the number of allocated pages.
+func (a *SparseArray[V]) Pages() int {
+	return len(a.pages)
+}
+
+// MemoryUsage returns an estimate, in bytes, of the memory held by the array's pages.
+// It does not include memory referenced by the values themselves (e.g. string contents).
+func (a *SparseArray[V]) MemoryUsage() int {
+	var p sparsePage[V]
+	pageBytes := int(unsafe.Sizeof(p))
+	// Each map entry costs a key, a pointer, and roughly one byte of bucket metadata.
+	entryBytes := int(unsafe.Sizeof(uint64(0))) + int(unsafe.Sizeof(&p)) + 1
+
+	return len(a.pages) * (pageBytes + entryBytes)
+}
+
+// All returns an iterator over the set indexes and their values in ascending index order.
+// The array must not be modified during iteration.
+func (a *SparseArray[V]) All() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		keys := make([]uint64, 0, len(a.pages))
+		for k := range a.pages {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			p := a.pages[k]
+			for w, word := range p.present {
+				for word != 0 {
+					slot := w*64 + bits.TrailingZeros64(word)
+					if !yield(k<<sparsePageBits|uint64(slot), p.values[slot]) {
+						return
+					}
+					word &= word - 1
+				}
+			}
+		}
+	}
+}
+
+// Clear removes all values and releases every page.
+func (a *SparseArray[V]) Clear() {
+	a.pages = nil
+	a.count = 0
+}
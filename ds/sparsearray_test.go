@@ -0,0 +1,134 @@
+package ds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseArraySetGet(t *testing.T) {
+	tests := []struct {
+		name  string
+		index uint64
+		value string
+	}{
+		{
+			name:  "zero index",
+			index: 0,
+			value: "zero",
+		},
+		{
+			name:  "page boundary",
+			index: sparsePageSize,
+			value: "boundary",
+		},
+		{
+			name:  "snowflake sized",
+			index: 1541815603606036480,
+			value: "snowflake",
+		},
+		{
+			name:  "max index",
+			index: math.MaxUint64,
+			value: "max",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewSparseArray[string]()
+			a.Set(tt.index, tt.value)
+
+			got, ok := a.Get(tt.index)
+			assert.True(t, ok, tt.name)
+			assert.Equal(t, tt.value, got, tt.name)
+
+			_, ok = a.Get(tt.index ^ 1)
+			assert.False(t, ok, tt.name)
+			assert.Equal(t, 1, a.Len(), tt.name)
+			assert.Equal(t, 1, a.Pages(), tt.name)
+		})
+	}
+}
+
+func TestSparseArrayZeroValueIsPresent(t *testing.T) {
+	var a SparseArray[int]
+	a.Set(42, 0)
+
+	got, ok := a.Get(42)
+	assert.True(t, ok)
+	assert.Equal(t, 0, got)
+
+	_, ok = a.Get(43)
+	assert.False(t, ok)
+}
+
+func TestSparseArrayOverwrite(t *testing.T) {
+	var a SparseArray[int]
+	a.Set(7, 1)
+	a.Set(7, 2)
+
+	got, _ := a.Get(7)
+	assert.Equal(t, 2, got)
+	assert.Equal(t, 1, a.Len())
+}
+
+func TestSparseArrayDelete(t *testing.T) {
+	var a SparseArray[int]
+	a.Set(1, 10)
+	a.Set(2, 20)
+
+	assert.True(t, a.Delete(1))
+	assert.False(t, a.Delete(1))
+	assert.False(t, a.Delete(1<<40))
+	assert.Equal(t, 1, a.Len())
+	assert.Equal(t, 1, a.Pages())
+
+	assert.True(t, a.Delete(2))
+	assert.Equal(t, 0, a.Len())
+	assert.Equal(t, 0, a.Pages(), "empty pages are released")
+	assert.Equal(t, 0, a.MemoryUsage())
+}
+
+func TestSparseArrayAll(t *testing.T) {
+	var a SparseArray[string]
+	a.Set(1<<40, "c")
+	a.Set(300, "b")
+	a.Set(3, "a")
+	a.Set(math.MaxUint64, "d")
+
+	var (
+		indexes []uint64
+		values  []string
+	)
+	for i, v := range a.All() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	assert.Equal(t, []uint64{3, 300, 1 << 40, math.MaxUint64}, indexes)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, values)
+
+	count := 0
+	for range a.All() {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestSparseArrayMemoryUsage(t *testing.T) {
+	var a SparseArray[int64]
+	a.Set(0, 1)
+	one := a.MemoryUsage()
+	assert.Greater(t, one, sparsePageSize*8)
+
+	a.Set(1, 1)
+	assert.Equal(t, one, a.MemoryUsage(), "same page")
+
+	a.Set(1<<32, 1)
+	assert.Equal(t, 2*one, a.MemoryUsage())
+
+	a.Clear()
+	assert.Equal(t, 0, a.Len())
+	assert.Equal(t, 0, a.MemoryUsage())
+}
@@ -0,0 +1,337 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadDotenv reads KEY=VALUE pairs from the given files (".env" if none are given)
+// and sets them as environment variables.
+//
+// Variables that are already set in the environment are left alone, so values
+// from the real deployment environment always win over the file. Use
+// OverloadDotenv to let the files take precedence instead. When several files
+// define the same key, the first file wins.
+//
+// See ParseDotenv for the file syntax.
+func LoadDotenv(paths ...string) error {
+	return loadDotenv(false, paths)
+}
+
+// OverloadDotenv is like LoadDotenv but overrides variables that are already set.
+// When several files define the same key, the last file wins.
+func OverloadDotenv(paths ...string) error {
+	return loadDotenv(true, paths)
+}
+
+func loadDotenv(override bool, paths []string) error {
+	if len(paths) == 0 {
+		paths = []string{".env"}
+	}
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("env: %w", err)
+		}
+		vars, err := parseDotenv(f, path)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, kv := range vars {
+			if _, exists := os.LookupEnv(kv[0]); exists && !override {
+				continue
+			}
+			if err := os.Setenv(kv[0], kv[1]); err != nil {
+				return fmt.Errorf("env: setting %s: %w", kv[0], err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseDotenv parses a dotenv file without touching the environment.
+//
+// The syntax follows the common dotenv conventions:
+//
+//	# full-line comment
+//	export NAME=value          # "export " is optional; inline comments need a space before #
+//	EMPTY=
+//	SINGLE='kept $literally'   # no escapes, no expansion
+//	DOUBLE="line1\nline2"      # \n \r \t \" \\ \$ escapes, may span several lines
+//	URL=http://${HOST}:$PORT   # expansion in unquoted and double-quoted values
+//
+// Variables are expanded from keys defined earlier in the same file, falling back
+// to the process environment. Undefined variables expand to the empty string.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	vars, err := parseDotenv(r, "dotenv")
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(vars))
+	for _, kv := range vars {
+		m[kv[0]] = kv[1]
+	}
+
+	return m, nil
+}
+
+// parseDotenv returns the key/value pairs of r in file order. name is used in error messages.
+func parseDotenv(r io.Reader, name string) ([][2]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("env: reading %s: %w", name, err)
+	}
+
+	p := dotenvParser{src: strings.ReplaceAll(string(data), "\r\n", "\n"), name: name, line: 1, seen: map[string]string{}}
+
+	var vars [][2]string
+	for {
+		key, value, ok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return vars, nil
+		}
+		p.seen[key] = value
+		vars = append(vars, [2]string{key, value})
+	}
+}
+
+type dotenvParser struct {
+	src  string
+	pos  int
+	name string
+	line int
+	seen map[string]string
+}
+
+func (p *dotenvParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("env: %s:%d: %s", p.name, p.line, fmt.Sprintf(format, args...))
+}
+
+// readLine consumes and returns the rest of the current line, without the newline.
+func (p *dotenvParser) readLine() string {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end < 0 {
+		line := p.src[p.pos:]
+		p.pos = len(p.src)
+		return line
+	}
+
+	line := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	p.line++
+
+	return line
+}
+
+// next parses the next assignment. ok is false at end of input.
+func (p *dotenvParser) next() (key, value string, ok bool, err error) {
+	for p.pos < len(p.src) {
+		startLine := p.line
+		start := p.pos
+
+		// Peek at the line to skip blanks and comments.
+		line := strings.TrimSpace(p.readLine())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		k, _, found := strings.Cut(line, "=")
+		k = strings.TrimSpace(k)
+		if !found {
+			p.line = startLine
+			return "", "", false, p.errorf("missing '=' in %q", line)
+		}
+		if !validKey(k) {
+			p.line = startLine
+			return "", "", false, p.errorf("invalid variable name %q", k)
+		}
+
+		// Rewind to just after '=' and parse the value, which may span lines.
+		p.pos, p.line = start+strings.IndexByte(p.src[start:], '=')+1, startLine
+		value, err := p.value()
+		if err != nil {
+			return "", "", false, err
+		}
+
+		return k, value, true, nil
+	}
+
+	return "", "", false, nil
+}
+
+func (p *dotenvParser) value() (string, error) {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos == len(p.src) {
+		return "", nil
+	}
+
+	switch p.src[p.pos] {
+	case '\'':
+		end := strings.IndexByte(p.src[p.pos+1:], '\'')
+		if end < 0 {
+			return "", p.errorf("unterminated single-quoted value")
+		}
+		v := p.src[p.pos+1 : p.pos+1+end]
+		p.line += strings.Count(v, "\n")
+		p.pos += end + 2
+		return v, p.trailing()
+	case '"':
+		p.pos++
+		v, err := p.doubleQuoted()
+		if err != nil {
+			return "", err
+		}
+		return v, p.trailing()
+	}
+
+	v := p.readLine()
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = v[:i]
+	}
+
+	return p.expand(strings.TrimSpace(v)), nil
+}
+
+// doubleQuoted parses a value up to the closing quote, applying escapes and expansion.
+func (p *dotenvParser) doubleQuoted() (string, error) {
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if p.pos+1 == len(p.src) {
+				break
+			}
+			p.pos++
+			switch e := p.src[p.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+			p.pos++
+			continue
+		case '$':
+			name, n := scanVarRef(p.src[p.pos:])
+			if n > 0 {
+				b.WriteString(p.lookup(name))
+				p.pos += n
+				continue
+			}
+		case '\n':
+			p.line++
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	return "", p.errorf("unterminated double-quoted value")
+}
+
+// trailing consumes the rest of the line after a quoted value, allowing only a comment.
+func (p *dotenvParser) trailing() error {
+	rest := strings.TrimSpace(p.readLine())
+	if rest != "" && rest[0] != '#' {
+		return p.errorf("unexpected %q after quoted value", rest)
+	}
+
+	return nil
+}
+
+func (p *dotenvParser) expand(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '$' {
+			if name, n := scanVarRef(s[i:]); n > 0 {
+				b.WriteString(p.lookup(name))
+				i += n
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+
+	return b.String()
+}
+
+func (p *dotenvParser) lookup(name string) string {
+	if v, ok := p.seen[name]; ok {
+		return v
+	}
+
+	return os.Getenv(name)
+}
+
+// scanVarRef parses "$NAME" or "${NAME}" at the start of s.
+// It returns the variable name and the number of bytes consumed, or 0 if s holds no reference.
+func scanVarRef(s string) (string, int) {
+	if len(s) < 2 || s[0] != '$' {
+		return "", 0
+	}
+
+	if s[1] == '{' {
+		end := strings.IndexByte(s, '}')
+		if end < 0 || !validKey(s[2:end]) {
+			return "", 0
+		}
+		return s[2:end], end + 1
+	}
+
+	n := 1
+	for n < len(s) && isKeyByte(s[n], n == 1) {
+		n++
+	}
+	if n == 1 {
+		return "", 0
+	}
+
+	return s[1:n], n
+}
+
+func validKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for i := 0; i < len(k); i++ {
+		if !isKeyByte(k[i], i == 0) && k[i] != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isKeyByte(c byte, first bool) bool {
+	switch {
+	case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return true
+	case c >= '0' && c <= '9':
+		return !first
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("DOTENV_FROM_ENV", "outside")
+
+	input := `
+# comment
+PLAIN=value
+export EXPORTED=yes
+SPACED = padded value  # trailing comment
+EMPTY=
+HASH=a#b
+SINGLE='no $PLAIN \n expansion'
+DOUBLE="tab\there \"quoted\" \$PLAIN"
+MULTI="line1
+line2"
+EXPANDED=${PLAIN}-$PLAIN-$DOTENV_FROM_ENV-$UNDEFINED_DOTENV_VAR
+QUOTED_EXPANDED="<$EXPORTED>"
+WINDOWS=crlf` + "\r\n"
+
+	vars, err := ParseDotenv(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PLAIN":           "value",
+		"EXPORTED":        "yes",
+		"SPACED":          "padded value",
+		"EMPTY":           "",
+		"HASH":            "a#b",
+		"SINGLE":          `no $PLAIN \n expansion`,
+		"DOUBLE":          "tab\there \"quoted\" $PLAIN",
+		"MULTI":           "line1\nline2",
+		"EXPANDED":        "value-value-outside-",
+		"QUOTED_EXPANDED": "<yes>",
+		"WINDOWS":         "crlf",
+	}, vars)
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "missing equals",
+			input:    "A=1\nJUSTTEXT\n",
+			expected: `env: dotenv:2: missing '=' in "JUSTTEXT"`,
+		},
+		{
+			name:     "invalid name",
+			input:    "1ABC=x",
+			expected: `env: dotenv:1: invalid variable name "1ABC"`,
+		},
+		{
+			name:     "unterminated double quote",
+			input:    "A=\"open\n\n",
+			expected: "env: dotenv:3: unterminated double-quoted value",
+		},
+		{
+			name:     "unterminated single quote",
+			input:    "A='open",
+			expected: "env: dotenv:1: unterminated single-quoted value",
+		},
+		{
+			name:     "garbage after quote",
+			input:    `A="x" y`,
+			expected: `env: dotenv:1: unexpected "y" after quoted value`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDotenv(strings.NewReader(tt.input))
+			assert.EqualError(t, err, tt.expected, tt.name)
+		})
+	}
+}
+
+func writeDotenv(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadDotenv(t *testing.T) {
+	t.Setenv("DOTENV_KEEP", "from-env")
+	t.Setenv("DOTENV_NEW", "")
+	os.Unsetenv("DOTENV_NEW")
+
+	first := writeDotenv(t, "first.env", "DOTENV_KEEP=from-file\nDOTENV_NEW=first\n")
+	second := writeDotenv(t, "second.env", "DOTENV_NEW=second\n")
+
+	assert.NoError(t, LoadDotenv(first, second))
+	assert.Equal(t, "from-env", os.Getenv("DOTENV_KEEP"), "existing variables are kept")
+	assert.Equal(t, "first", os.Getenv("DOTENV_NEW"), "first file wins")
+}
+
+func TestOverloadDotenv(t *testing.T) {
+	t.Setenv("DOTENV_KEEP", "from-env")
+
+	first := writeDotenv(t, "first.env", "DOTENV_KEEP=first\n")
+	second := writeDotenv(t, "second.env", "DOTENV_KEEP=second\n")
+
+	assert.NoError(t, OverloadDotenv(first, second))
+	assert.Equal(t, "second", os.Getenv("DOTENV_KEEP"), "last file wins")
+}
+
+func TestLoadDotenvErrors(t *testing.T) {
+	assert.ErrorContains(t, LoadDotenv(filepath.Join(t.TempDir(), "missing.env")), "missing.env")
+
+	bad := writeDotenv(t, "bad.env", "NOPE\n")
+	assert.ErrorContains(t, LoadDotenv(bad), "bad.env:1: missing '='")
+}
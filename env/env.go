@@ -0,0 +1,106 @@
+// Package env reads typed configuration from environment variables.
+//
+// Every service ends up with a block of os.Getenv calls glued to strconv parsing,
+// each with its own idea of how to report a bad value. This package replaces that
+// with typed lookups whose errors always name the offending variable:
+//
+//	port, err := env.Get("PORT", 8080)
+//	timeout := env.MustGet("TIMEOUT", 5*time.Second)
+//	hosts := env.MustGet("HOSTS", []string{"localhost"})
+//
+// For whole service configurations, Parse fills a struct from `env` tags.
+package env
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Value lists the types Get and MustGet can parse.
+type Value interface {
+	string | int | int64 | uint | uint64 | bool | float64 | time.Duration | []string
+}
+
+// Error reports an environment variable whose value could not be parsed.
+type Error struct {
+	Key   string // variable name
+	Value string // raw value as found in the environment
+	Err   error  // underlying parse error
+}
+
+func (e *Error) Error() string {
+	if e.Err == ErrRequired {
+		return fmt.Sprintf("env: required variable %s is not set", e.Key)
+	}
+
+	return fmt.Sprintf("env: invalid value %q for %s: %v", e.Value, e.Key, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Get returns the value of the environment variable key parsed as T,
+// or def if the variable is unset or empty.
+//
+// Parsing rules:
+//   - bool accepts the values understood by strconv.ParseBool ("1", "true", "F", ...).
+//   - time.Duration uses time.ParseDuration ("250ms", "1h30m").
+//   - []string splits on commas and trims surrounding spaces from each element.
+//
+// A malformed value returns def together with an *Error naming the variable.
+//
+// Example:
+//
+//	// PORT=9000
+//	env.Get("PORT", 8080) => 9000, nil
+//	// PORT=abc
+//	env.Get("PORT", 8080) => 8080, env: invalid value "abc" for PORT: ...
+func Get[T Value](key string, def T) (T, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def, nil
+	}
+
+	v, err := parse[T](raw, ",")
+	if err != nil {
+		return def, &Error{Key: key, Value: raw, Err: err}
+	}
+
+	return v, nil
+}
+
+// MustGet is like Get but panics if the variable holds a malformed value.
+// It is intended for package-level configuration and main functions.
+func MustGet[T Value](key string, def T) T {
+	v, err := Get(key, def)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// parse converts raw to T. sep is the separator used for slices.
+func parse[T Value](raw, sep string) (T, error) {
+	var out T
+	err := setValue(reflect.ValueOf(&out).Elem(), raw, sep)
+
+	return out, err
+}
+
+// splitList splits raw on sep, trimming spaces and dropping empty elements.
+func splitList(raw, sep string) []string {
+	parts := strings.Split(raw, sep)
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+
+	return out
+}
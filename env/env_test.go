@@ -0,0 +1,93 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:     "unset uses default",
+			value:    "",
+			expected: 8080,
+		},
+		{
+			name:     "valid",
+			value:    "9000",
+			expected: 9000,
+		},
+		{
+			name:     "invalid keeps default",
+			value:    "abc",
+			expected: 8080,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_PORT", tt.value)
+
+			got, err := Get("TEST_PORT", 8080)
+			assert.Equal(t, tt.expected, got, tt.name)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "TEST_PORT")
+				return
+			}
+			assert.NoError(t, err, tt.name)
+		})
+	}
+}
+
+func TestGetTypes(t *testing.T) {
+	t.Setenv("TEST_STRING", "hello")
+	t.Setenv("TEST_BOOL", "true")
+	t.Setenv("TEST_INT64", "-42")
+	t.Setenv("TEST_UINT", "7")
+	t.Setenv("TEST_FLOAT", "2.5")
+	t.Setenv("TEST_DURATION", "1m30s")
+	t.Setenv("TEST_LIST", " a, b ,,c ")
+
+	assert.Equal(t, "hello", MustGet("TEST_STRING", ""))
+	assert.Equal(t, true, MustGet("TEST_BOOL", false))
+	assert.Equal(t, int64(-42), MustGet("TEST_INT64", int64(0)))
+	assert.Equal(t, uint(7), MustGet("TEST_UINT", uint(0)))
+	assert.Equal(t, uint64(7), MustGet("TEST_UINT", uint64(0)))
+	assert.Equal(t, 2.5, MustGet("TEST_FLOAT", 0.0))
+	assert.Equal(t, 90*time.Second, MustGet("TEST_DURATION", time.Duration(0)))
+	assert.Equal(t, []string{"a", "b", "c"}, MustGet("TEST_LIST", []string(nil)))
+}
+
+func TestGetError(t *testing.T) {
+	t.Setenv("TEST_TIMEOUT", "soon")
+
+	_, err := Get("TEST_TIMEOUT", time.Second)
+
+	var envErr *Error
+	assert.True(t, errors.As(err, &envErr))
+	assert.Equal(t, "TEST_TIMEOUT", envErr.Key)
+	assert.Equal(t, "soon", envErr.Value)
+	assert.Equal(t, `env: invalid value "soon" for TEST_TIMEOUT: time: invalid duration "soon"`, err.Error())
+}
+
+func TestGetErrorUnwraps(t *testing.T) {
+	t.Setenv("TEST_NUM", "99999999999999999999")
+
+	_, err := Get("TEST_NUM", 0)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+}
+
+func TestMustGetPanics(t *testing.T) {
+	t.Setenv("TEST_BOOL", "maybe")
+
+	assert.Panics(t, func() { MustGet("TEST_BOOL", false) })
+}
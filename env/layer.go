@@ -0,0 +1,152 @@
+package env
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// SourceDefault is the provenance reported for values taken from envDefault tags.
+const SourceDefault = "default"
+
+// Source is one layer of configuration: a named set of KEY=value pairs.
+// Keys use the same names as the env tags, including prefixes.
+type Source struct {
+	Name string
+	Load func() (map[string]string, error)
+}
+
+// Provenance maps each populated variable name to the name of the Source that supplied it.
+type Provenance map[string]string
+
+// FromMap returns a Source serving the fixed values in m.
+func FromMap(name string, m map[string]string) Source {
+	return Source{Name: name, Load: func() (map[string]string, error) { return m, nil }}
+}
+
+// FromEnv returns a Source named "env" reading the process environment.
+func FromEnv() Source {
+	return Source{Name: "env", Load: func() (map[string]string, error) {
+		environ := os.Environ()
+		m := make(map[string]string, len(environ))
+		for _, kv := range environ {
+			k, v, _ := strings.Cut(kv, "=")
+			m[k] = v
+		}
+		return m, nil
+	}}
+}
+
+// FromDotenvFile returns a Source named after path reading a dotenv file (see ParseDotenv).
+// The file is read when Layer runs; a missing file is an error.
+func FromDotenvFile(path string) Source {
+	return Source{Name: path, Load: func() (map[string]string, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		vars, err := parseDotenv(f, path)
+		if err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]string, len(vars))
+		for _, kv := range vars {
+			m[kv[0]] = kv[1]
+		}
+		return m, nil
+	}}
+}
+
+// FromFlags returns a Source named "flags" holding the flags explicitly set on fs.
+// Flags left at their default value are ignored, so they don't mask lower layers.
+//
+// Flag names are mapped to variable names by upper-casing them and replacing
+// '-' and '.' with '_': the flag -db-host supplies DB_HOST.
+func FromFlags(fs *flag.FlagSet) Source {
+	return Source{Name: "flags", Load: func() (map[string]string, error) {
+		if !fs.Parsed() {
+			return nil, errors.New("flag set has not been parsed")
+		}
+
+		m := map[string]string{}
+		fs.Visit(func(f *flag.Flag) {
+			m[FlagKey(f.Name)] = f.Value.String()
+		})
+		return m, nil
+	}}
+}
+
+// FlagKey returns the variable name a flag maps to in FromFlags.
+//
+// Example:
+//
+//	FlagKey("db-host") => "DB_HOST"
+func FlagKey(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// Layer populates the struct pointed to by cfg from several sources and reports
+// where each value came from.
+//
+// Precedence, from lowest to highest:
+//  1. envDefault tags
+//  2. each source, in the order given — later sources override earlier ones
+//
+// so the usual CLI ordering is
+//
+//	prov, err := env.Layer(&cfg,
+//		env.FromDotenvFile("app.env"),   // config file
+//		env.FromEnv(),                   // overrides the file
+//		env.FromFlags(flag.CommandLine), // overrides everything
+//	)
+//
+// Empty values are treated as unset and never override a lower layer. Fields no
+// layer supplies keep whatever value cfg already held and are absent from the
+// returned Provenance. Tags, supported types, and error reporting are the same
+// as for Parse.
+func Layer(cfg any, sources ...Source) (Provenance, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("env: config must be a non-nil pointer to a struct, got %T", cfg)
+	}
+
+	layers := make([]map[string]string, len(sources))
+	for i, src := range sources {
+		m, err := src.Load()
+		if err != nil {
+			return nil, fmt.Errorf("env: loading %s source: %w", src.Name, err)
+		}
+		layers[i] = m
+	}
+
+	prov := Provenance{}
+	var errs []error
+	walkFields(v.Elem(), "", true, func(f field) {
+		raw, from := f.def, SourceDefault
+		for i, m := range layers {
+			if value := m[f.key]; value != "" {
+				raw, from = value, sources[i].Name
+			}
+		}
+		if raw == "" {
+			if f.required {
+				errs = append(errs, &Error{Key: f.key, Err: ErrRequired})
+			}
+			return
+		}
+
+		if err := setValue(f.value, raw, f.sep); err != nil {
+			errs = append(errs, &Error{Key: f.key, Value: raw, Err: err})
+			return
+		}
+		prov[f.key] = from
+	})
+
+	return prov, errors.Join(errs...)
+}
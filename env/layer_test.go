@@ -0,0 +1,89 @@
+package env
+
+import (
+	"errors"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type layeredConfig struct {
+	Host    string `env:"HOST" envDefault:"localhost"`
+	Port    int    `env:"PORT" envDefault:"8080"`
+	Verbose bool   `env:"VERBOSE"`
+	Name    string `env:"NAME"`
+	DB      struct {
+		User string `env:"USER,required"`
+	} `envPrefix:"DB_"`
+}
+
+func TestLayerPrecedence(t *testing.T) {
+	t.Setenv("PORT", "9000")
+	t.Setenv("HOST", "")
+	t.Setenv("DB_USER", "env-user")
+
+	file := writeDotenv(t, "app.env", "HOST=file-host\nPORT=7000\nDB_USER=file-user\n")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Bool("verbose", false, "")
+	fs.String("db-user", "unused-default", "")
+	fs.String("name", "flag-default", "")
+	assert.NoError(t, fs.Parse([]string{"-verbose", "-db-user=flag-user"}))
+
+	var cfg layeredConfig
+	prov, err := Layer(&cfg, FromDotenvFile(file), FromEnv(), FromFlags(fs))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "file-host", cfg.Host, "empty env value does not override")
+	assert.Equal(t, 9000, cfg.Port)
+	assert.True(t, cfg.Verbose)
+	assert.Equal(t, "", cfg.Name, "unset flags are ignored")
+	assert.Equal(t, "flag-user", cfg.DB.User)
+
+	assert.Equal(t, Provenance{
+		"HOST":    file,
+		"PORT":    "env",
+		"VERBOSE": "flags",
+		"DB_USER": "flags",
+	}, prov)
+}
+
+func TestLayerDefaults(t *testing.T) {
+	cfg := layeredConfig{Name: "preset"}
+	prov, err := Layer(&cfg, FromMap("overrides", map[string]string{"DB_USER": "u"}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "localhost", cfg.Host)
+	assert.Equal(t, "preset", cfg.Name, "values already in cfg are kept")
+	assert.Equal(t, Provenance{"HOST": SourceDefault, "PORT": SourceDefault, "DB_USER": "overrides"}, prov)
+}
+
+func TestLayerErrors(t *testing.T) {
+	var cfg layeredConfig
+
+	_, err := Layer(&cfg, FromMap("bad", map[string]string{"PORT": "x"}))
+	assert.ErrorIs(t, err, ErrRequired)
+	assert.ErrorContains(t, err, `env: invalid value "x" for PORT`)
+
+	_, err = Layer(&cfg, Source{Name: "broken", Load: func() (map[string]string, error) {
+		return nil, errors.New("unreachable")
+	}})
+	assert.EqualError(t, err, "env: loading broken source: unreachable")
+
+	_, err = Layer(&cfg, FromDotenvFile(filepath.Join(t.TempDir(), "nope.env")))
+	assert.ErrorContains(t, err, "nope.env")
+
+	_, err = Layer(&cfg, FromFlags(flag.NewFlagSet("unparsed", flag.ContinueOnError)))
+	assert.ErrorContains(t, err, "flag set has not been parsed")
+
+	_, err = Layer(cfg)
+	assert.ErrorContains(t, err, "non-nil pointer to a struct")
+}
+
+func TestFlagKey(t *testing.T) {
+	assert.Equal(t, "DB_HOST", FlagKey("db-host"))
+	assert.Equal(t, "LOG_LEVEL", FlagKey("log.level"))
+	assert.Equal(t, "PORT", FlagKey("port"))
+}
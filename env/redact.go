@@ -0,0 +1,100 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const redactedMask = "******"
+
+// Redacted is a string that hides its value when printed or marshaled.
+//
+// Use it for secrets in configuration structs: fmt, log, and encoding/json all
+// see "******", so an accidental log line doesn't leak the value. Call Reveal
+// where the real value is needed. An empty Redacted prints as "" so that a
+// missing secret is still visible.
+type Redacted string
+
+// Reveal returns the underlying secret value.
+func (r Redacted) Reveal() string {
+	return string(r)
+}
+
+// String returns a mask instead of the secret.
+func (r Redacted) String() string {
+	if r == "" {
+		return ""
+	}
+
+	return redactedMask
+}
+
+// GoString makes %#v print the mask as well.
+func (r Redacted) GoString() string {
+	return fmt.Sprintf("env.Redacted(%q)", r.String())
+}
+
+// MarshalText returns the mask, which also covers encoding/json.
+func (r Redacted) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Dump returns the effective configuration held by cfg, one KEY=value line per
+// env-tagged field, in declaration order. cfg is a struct or a pointer to one,
+// typically after Parse has filled it.
+//
+// Fields tagged `secret:"true"` and fields of type Redacted are masked; a secret
+// that is empty is shown as empty, so a missing credential is still obvious.
+// Slices are joined with the field's separator.
+//
+// Example:
+//
+//	type Config struct {
+//		Host     string `env:"DB_HOST"`
+//		Password string `env:"DB_PASSWORD" secret:"true"`
+//	}
+//	env.Dump(Config{Host: "db", Password: "hunter2"})
+//	=> "DB_HOST=db\nDB_PASSWORD=******\n"
+func Dump(cfg any) string {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	// walkFields needs addressable fields to detect TextUnmarshaler on nested structs.
+	if !v.CanAddr() {
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		v = cp
+	}
+
+	var b strings.Builder
+	walkFields(v, "", false, func(f field) {
+		value := formatValue(f.value, f.sep)
+		if f.secret && value != "" {
+			value = redactedMask
+		}
+		fmt.Fprintf(&b, "%s=%s\n", f.key, value)
+	})
+
+	return b.String()
+}
+
+func formatValue(v reflect.Value, sep string) string {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i), sep)
+		}
+		return strings.Join(parts, sep)
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return ""
+	}
+
+	return fmt.Sprint(v.Interface())
+}
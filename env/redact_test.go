@@ -0,0 +1,78 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedacted(t *testing.T) {
+	secret := Redacted("hunter2")
+
+	assert.Equal(t, "hunter2", secret.Reveal())
+	assert.Equal(t, "******", fmt.Sprint(secret))
+	assert.Equal(t, "******", fmt.Sprintf("%s", secret))
+	assert.Equal(t, `env.Redacted("******")`, fmt.Sprintf("%#v", secret))
+
+	out, err := json.Marshal(struct{ Token Redacted }{secret})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Token":"******"}`, string(out))
+
+	assert.Equal(t, "", Redacted("").String())
+}
+
+func TestRedactedParse(t *testing.T) {
+	var cfg struct {
+		Token Redacted `env:"TOKEN"`
+	}
+	t.Setenv("TOKEN", "s3cr3t")
+
+	assert.NoError(t, Parse(&cfg))
+	assert.Equal(t, "s3cr3t", cfg.Token.Reveal())
+}
+
+func TestDump(t *testing.T) {
+	type db struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD" secret:"true"`
+	}
+	type config struct {
+		Name    string        `env:"NAME"`
+		Timeout time.Duration `env:"TIMEOUT"`
+		Peers   []string      `env:"PEERS" envSeparator:";"`
+		IP      net.IP        `env:"IP"`
+		Token   Redacted      `env:"TOKEN"`
+		Missing string        `env:"MISSING_SECRET" secret:"true"`
+		DB      db            `envPrefix:"DB_"`
+		Replica *db           `envPrefix:"REPLICA_"`
+		Other   string
+	}
+
+	cfg := config{
+		Name:    "api",
+		Timeout: 1500 * time.Millisecond,
+		Peers:   []string{"a", "b"},
+		IP:      net.IPv4(10, 0, 0, 1),
+		Token:   "abc",
+		DB:      db{Host: "db", Password: "hunter2"},
+		Other:   "not shown",
+	}
+
+	expected := "NAME=api\n" +
+		"TIMEOUT=1.5s\n" +
+		"PEERS=a;b\n" +
+		"IP=10.0.0.1\n" +
+		"TOKEN=******\n" +
+		"MISSING_SECRET=\n" +
+		"DB_HOST=db\n" +
+		"DB_PASSWORD=******\n"
+
+	assert.Equal(t, expected, Dump(cfg))
+	assert.Equal(t, expected, Dump(&cfg))
+	assert.Nil(t, cfg.Replica, "Dump does not allocate nested pointers")
+	assert.Equal(t, "", Dump(42))
+}
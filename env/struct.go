@@ -0,0 +1,182 @@
+package env
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRequired is wrapped by the *Error returned for a required variable that is unset or empty.
+var ErrRequired = errors.New("required variable is not set")
+
+// Struct tags understood by Parse.
+const (
+	tagName      = "env"          // variable name, optionally followed by ",required"
+	tagDefault   = "envDefault"   // value used when the variable is unset or empty
+	tagSeparator = "envSeparator" // separator for slice fields, "," if omitted
+	tagPrefix    = "envPrefix"    // prefix prepended to every variable of a nested struct
+	tagSecret    = "secret"       // "true" masks the field's value in Dump
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	redactedType        = reflect.TypeFor[Redacted]()
+)
+
+// Parse populates the struct pointed to by cfg from environment variables.
+//
+// Fields are described with struct tags:
+//
+//	type Config struct {
+//		Port    int           `env:"PORT" envDefault:"8080"`
+//		Host    string        `env:"HOST,required"`
+//		Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+//		Peers   []string      `env:"PEERS" envSeparator:";"`
+//		DB      DBConfig      `envPrefix:"DB_"` // reads DB_HOST, DB_USER, ...
+//	}
+//
+// Nested structs (and pointers to structs) without an env tag are walked
+// recursively; envPrefix is prepended to the names of all their variables and
+// prefixes accumulate through several levels. Fields without an env tag that are
+// not structs are left untouched, as are unexported fields.
+//
+// Supported field types are strings, bools, all integer and float kinds,
+// time.Duration, slices of those, and any type implementing encoding.TextUnmarshaler.
+//
+// Parse does not stop at the first problem: it returns every missing or malformed
+// variable joined into one error, so a misconfigured deployment can be fixed in one go.
+// Each joined error is an *Error.
+func Parse(cfg any) error {
+	_, err := Layer(cfg, FromEnv())
+	return err
+}
+
+// field is an env-tagged struct field found by walkFields.
+type field struct {
+	key      string // full variable name, including prefixes
+	def      string
+	sep      string
+	required bool
+	secret   bool
+	value    reflect.Value
+}
+
+// walkFields calls fn for every env-tagged field of the struct v, descending into
+// untagged nested structs. If alloc is true, nil struct pointers are allocated on the
+// way; otherwise they are skipped.
+func walkFields(v reflect.Value, prefix string, alloc bool, fn func(field)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf, fv := t.Field(i), v.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, hasTag := sf.Tag.Lookup(tagName)
+		if !hasTag {
+			if nested, ok := nestedStruct(fv, alloc); ok {
+				walkFields(nested, prefix+sf.Tag.Get(tagPrefix), alloc, fn)
+			}
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			continue
+		}
+
+		sep := sf.Tag.Get(tagSeparator)
+		if sep == "" {
+			sep = ","
+		}
+		fn(field{
+			key:      prefix + name,
+			def:      sf.Tag.Get(tagDefault),
+			sep:      sep,
+			required: opts == "required",
+			secret:   sf.Tag.Get(tagSecret) == "true" || sf.Type == redactedType,
+			value:    fv,
+		})
+	}
+}
+
+// nestedStruct returns the struct to recurse into for an untagged field.
+// Nil struct pointers are allocated if alloc is true and skipped otherwise.
+func nestedStruct(v reflect.Value, alloc bool) (reflect.Value, bool) {
+	if v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.Struct {
+		if v.IsNil() {
+			if !alloc {
+				return reflect.Value{}, false
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return reflect.Value{}, false
+	}
+
+	return v, true
+}
+
+// setValue parses raw into v according to v's type. sep splits slice values.
+func setValue(v reflect.Value, raw, sep string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		parts := splitList(raw, sep)
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), part, sep); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
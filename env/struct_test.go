@@ -0,0 +1,125 @@
+package env
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dbConfig struct {
+	Host string `env:"HOST" envDefault:"localhost"`
+	Port int    `env:"PORT" envDefault:"5432"`
+}
+
+type testConfig struct {
+	Name     string        `env:"NAME,required"`
+	Debug    bool          `env:"DEBUG"`
+	Timeout  time.Duration `env:"TIMEOUT" envDefault:"5s"`
+	Ratio    float32       `env:"RATIO"`
+	Peers    []string      `env:"PEERS" envSeparator:";"`
+	Ports    []uint16      `env:"PORTS"`
+	IP       net.IP        `env:"IP"`
+	Primary  dbConfig      `envPrefix:"DB_"`
+	Replica  *dbConfig     `envPrefix:"REPLICA_"`
+	Ignored  string
+	internal string `env:"INTERNAL"`
+}
+
+func TestParse(t *testing.T) {
+	t.Setenv("NAME", "api")
+	t.Setenv("DEBUG", "1")
+	t.Setenv("RATIO", "0.25")
+	t.Setenv("PEERS", "a;b; c")
+	t.Setenv("PORTS", "80,443")
+	t.Setenv("IP", "10.0.0.1")
+	t.Setenv("DB_HOST", "db.internal")
+	t.Setenv("REPLICA_PORT", "6543")
+	t.Setenv("INTERNAL", "nope")
+
+	var cfg testConfig
+	assert.NoError(t, Parse(&cfg))
+
+	assert.Equal(t, "api", cfg.Name)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+	assert.Equal(t, float32(0.25), cfg.Ratio)
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Peers)
+	assert.Equal(t, []uint16{80, 443}, cfg.Ports)
+	assert.Equal(t, "10.0.0.1", cfg.IP.String())
+	assert.Equal(t, dbConfig{Host: "db.internal", Port: 5432}, cfg.Primary)
+	assert.Equal(t, &dbConfig{Host: "localhost", Port: 6543}, cfg.Replica)
+	assert.Empty(t, cfg.internal)
+}
+
+func TestParseNestedPrefixesAccumulate(t *testing.T) {
+	type inner struct {
+		Value string `env:"VALUE"`
+	}
+	type middle struct {
+		Inner inner `envPrefix:"INNER_"`
+	}
+	type outer struct {
+		Middle middle `envPrefix:"APP_"`
+	}
+	t.Setenv("APP_INNER_VALUE", "deep")
+
+	var cfg outer
+	assert.NoError(t, Parse(&cfg))
+	assert.Equal(t, "deep", cfg.Middle.Inner.Value)
+}
+
+func TestParseReportsAllErrors(t *testing.T) {
+	t.Setenv("NAME", "")
+	t.Setenv("DEBUG", "sometimes")
+	t.Setenv("DB_PORT", "high")
+
+	var cfg testConfig
+	err := Parse(&cfg)
+
+	assert.ErrorIs(t, err, ErrRequired)
+	assert.ErrorContains(t, err, "env: required variable NAME is not set")
+	assert.ErrorContains(t, err, `env: invalid value "sometimes" for DEBUG`)
+	assert.ErrorContains(t, err, `env: invalid value "high" for DB_PORT`)
+
+	var envErr *Error
+	assert.True(t, errors.As(err, &envErr))
+}
+
+func TestParseInvalidTarget(t *testing.T) {
+	var cfg testConfig
+
+	tests := []struct {
+		name string
+		cfg  any
+	}{
+		{
+			name: "nil",
+			cfg:  nil,
+		},
+		{
+			name: "struct value",
+			cfg:  cfg,
+		},
+		{
+			name: "pointer to non struct",
+			cfg:  new(int),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, Parse(tt.cfg), tt.name)
+		})
+	}
+}
+
+func TestParseUnsupportedType(t *testing.T) {
+	var cfg struct {
+		Values map[string]string `env:"VALUES"`
+	}
+	t.Setenv("VALUES", "a=b")
+
+	assert.ErrorContains(t, Parse(&cfg), "unsupported type map[string]string")
+}
@@ -0,0 +1,198 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Validator is implemented by configuration types that can check their own consistency.
+// Watchers call Validate on every freshly loaded snapshot and discard it on error.
+type Validator interface {
+	Validate() error
+}
+
+// Watcher periodically reloads a configuration snapshot of type T.
+//
+// A reload only replaces the current snapshot if the loader succeeds, the value
+// passes validation (see Validator), and it differs from the current snapshot.
+// Invalid or failed reloads are recorded in Err and otherwise ignored, so readers
+// keep seeing the last good configuration.
+//
+// Load is lock-free and safe to call from any goroutine.
+type Watcher[T any] struct {
+	current atomic.Pointer[T]
+	updates chan T
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewWatcher loads an initial snapshot and then calls loader every interval until
+// ctx is cancelled. It returns an error if the initial load or validation fails.
+func NewWatcher[T any](ctx context.Context, loader func() (T, error), interval time.Duration) (*Watcher[T], error) {
+	return startWatcher(ctx, func() (T, bool, error) {
+		v, err := loader()
+		return v, true, err
+	}, interval)
+}
+
+// NewFileWatcher is like NewWatcher but reads its configuration from the file at path.
+// The file is polled every interval and parse is only called when its size or
+// modification time changes.
+func NewFileWatcher[T any](ctx context.Context, path string, parse func([]byte) (T, error), interval time.Duration) (*Watcher[T], error) {
+	var (
+		lastMod  time.Time
+		lastSize int64 = -1
+	)
+
+	return startWatcher(ctx, func() (T, bool, error) {
+		var zero T
+
+		info, err := os.Stat(path)
+		if err != nil {
+			// Forget the last stat so the file is reread once it is readable again.
+			lastSize = -1
+			return zero, false, err
+		}
+		if info.ModTime().Equal(lastMod) && info.Size() == lastSize {
+			return zero, false, nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			lastSize = -1
+			return zero, false, err
+		}
+		lastMod, lastSize = info.ModTime(), info.Size()
+
+		v, err := parse(data)
+		return v, true, err
+	}, interval)
+}
+
+// Watch is a shortcut for NewWatcher(...).Updates().
+func Watch[T any](ctx context.Context, loader func() (T, error), interval time.Duration) (<-chan T, error) {
+	w, err := NewWatcher(ctx, loader, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Updates(), nil
+}
+
+// WatchFile is a shortcut for NewFileWatcher(...).Updates().
+func WatchFile[T any](ctx context.Context, path string, parse func([]byte) (T, error), interval time.Duration) (<-chan T, error) {
+	w, err := NewFileWatcher(ctx, path, parse, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Updates(), nil
+}
+
+// load returns a new snapshot, whether there was anything to load, and any error.
+type loadFunc[T any] func() (T, bool, error)
+
+func startWatcher[T any](ctx context.Context, load loadFunc[T], interval time.Duration) (*Watcher[T], error) {
+	if interval <= 0 {
+		return nil, errors.New("env: watch interval must be positive")
+	}
+
+	v, _, err := load()
+	if err == nil {
+		err = validate(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("env: initial config load: %w", err)
+	}
+
+	w := &Watcher[T]{updates: make(chan T, 1)}
+	w.current.Store(&v)
+	w.updates <- v
+
+	go w.run(ctx, load, interval)
+
+	return w, nil
+}
+
+func (w *Watcher[T]) run(ctx context.Context, load loadFunc[T], interval time.Duration) {
+	defer close(w.updates)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		v, changed, err := load()
+		if err == nil && changed {
+			err = validate(v)
+		}
+		// An unchanged source keeps the previous outcome, so a bad file stays
+		// reported until it is fixed.
+		if changed || err != nil {
+			w.mu.Lock()
+			w.lastErr = err
+			w.mu.Unlock()
+		}
+		if err != nil || !changed || reflect.DeepEqual(v, *w.current.Load()) {
+			continue
+		}
+
+		w.current.Store(&v)
+		w.publish(v)
+	}
+}
+
+// publish delivers v without blocking, replacing a snapshot the reader hasn't taken yet.
+func (w *Watcher[T]) publish(v T) {
+	select {
+	case <-w.updates:
+	default:
+	}
+	w.updates <- v
+}
+
+// Load returns the current snapshot.
+func (w *Watcher[T]) Load() T {
+	return *w.current.Load()
+}
+
+// Updates returns a channel receiving the initial snapshot followed by every
+// accepted change. A reader that falls behind only sees the latest snapshot.
+// The channel is closed when the watcher's context is cancelled.
+func (w *Watcher[T]) Updates() <-chan T {
+	return w.updates
+}
+
+// Err returns the error from the most recent reload, or nil if it succeeded.
+// For a file watcher, an error from parsing or validating the file is kept
+// until the file changes.
+func (w *Watcher[T]) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.lastErr
+}
+
+// validate calls Validate on v if T or *T implements Validator.
+func validate[T any](v T) error {
+	if val, ok := any(v).(Validator); ok {
+		return val.Validate()
+	}
+	if val, ok := any(&v).(Validator); ok {
+		return val.Validate()
+	}
+
+	return nil
+}
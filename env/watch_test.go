@@ -0,0 +1,167 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type limits struct {
+	Max int
+}
+
+func (l limits) Validate() error {
+	if l.Max < 0 {
+		return errors.New("max must not be negative")
+	}
+
+	return nil
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for update")
+		panic("unreachable")
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		value atomic.Int64
+		fail  atomic.Bool
+	)
+	value.Store(1)
+
+	w, err := NewWatcher(ctx, func() (limits, error) {
+		if fail.Load() {
+			return limits{}, errors.New("source unavailable")
+		}
+		return limits{Max: int(value.Load())}, nil
+	}, time.Millisecond)
+	assert.NoError(t, err)
+
+	assert.Equal(t, limits{Max: 1}, receive(t, w.Updates()), "initial snapshot")
+	assert.Equal(t, limits{Max: 1}, w.Load())
+
+	value.Store(-5) // fails validation
+	assert.Eventually(t, func() bool { return w.Err() != nil }, 2*time.Second, time.Millisecond)
+	assert.Equal(t, limits{Max: 1}, w.Load(), "invalid snapshot not published")
+
+	fail.Store(true)
+	assert.Eventually(t, func() bool {
+		err := w.Err()
+		return err != nil && err.Error() == "source unavailable"
+	}, 2*time.Second, time.Millisecond)
+
+	fail.Store(false)
+	value.Store(7)
+	assert.Equal(t, limits{Max: 7}, receive(t, w.Updates()))
+	assert.Equal(t, limits{Max: 7}, w.Load())
+	assert.NoError(t, w.Err())
+
+	cancel()
+	for range w.Updates() {
+	}
+}
+
+func TestWatcherInitialErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := Watch(ctx, func() (limits, error) { return limits{}, errors.New("boom") }, time.Second)
+	assert.EqualError(t, err, "env: initial config load: boom")
+
+	_, err = Watch(ctx, func() (limits, error) { return limits{Max: -1}, nil }, time.Second)
+	assert.EqualError(t, err, "env: initial config load: max must not be negative")
+
+	_, err = Watch(ctx, func() (limits, error) { return limits{}, nil }, 0)
+	assert.Error(t, err)
+}
+
+func TestWatchFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "max.txt")
+	write := func(s string, mod time.Time) {
+		assert.NoError(t, os.WriteFile(path, []byte(s), 0o600))
+		assert.NoError(t, os.Chtimes(path, mod, mod))
+	}
+	parse := func(b []byte) (limits, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+		return limits{Max: n}, err
+	}
+
+	start := time.Now()
+	write("3", start)
+
+	updates, err := WatchFile(ctx, path, parse, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, limits{Max: 3}, receive(t, updates))
+
+	write("oops", start.Add(time.Second))
+	write("42", start.Add(2*time.Second))
+	assert.Equal(t, limits{Max: 42}, receive(t, updates))
+
+	_, err = WatchFile(ctx, filepath.Join(t.TempDir(), "missing"), parse, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestFileWatcherErr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "max.txt")
+	write := func(s string, mod time.Time) {
+		assert.NoError(t, os.WriteFile(path, []byte(s), 0o600))
+		assert.NoError(t, os.Chtimes(path, mod, mod))
+	}
+	parse := func(b []byte) (limits, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+		return limits{Max: n}, err
+	}
+
+	start := time.Now()
+	write("3", start)
+
+	w, err := NewFileWatcher(ctx, path, parse, time.Millisecond)
+	assert.NoError(t, err)
+
+	write("oops", start.Add(time.Second))
+	assert.Eventually(t, func() bool { return w.Err() != nil }, 2*time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond) // several ticks with the file unchanged
+	assert.Error(t, w.Err(), "still broken")
+	assert.Equal(t, limits{Max: 3}, w.Load())
+
+	write("-1", start.Add(2*time.Second)) // fails validation
+	assert.Eventually(t, func() bool {
+		err := w.Err()
+		return err != nil && err.Error() == "max must not be negative"
+	}, 2*time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.EqualError(t, w.Err(), "max must not be negative")
+
+	write("3", start)
+	assert.Eventually(t, func() bool { return w.Err() == nil }, 2*time.Second, time.Millisecond)
+
+	// A file that disappears and comes back unchanged is read again.
+	assert.NoError(t, os.Remove(path))
+	assert.Eventually(t, func() bool { return w.Err() != nil }, 2*time.Second, time.Millisecond)
+	write("3", start)
+	assert.Eventually(t, func() bool { return w.Err() == nil }, 2*time.Second, time.Millisecond)
+}
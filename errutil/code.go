@@ -0,0 +1,126 @@
+package errutil
+
+import "errors"
+
+// Well-known error codes. Any string can be used as a code; these are the ones
+// the category predicates understand.
+const (
+	CodeNotFound         = "NOT_FOUND"
+	CodeAlreadyExists    = "ALREADY_EXISTS"
+	CodeConflict         = "CONFLICT"
+	CodeInvalidArgument  = "INVALID_ARGUMENT"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodePermissionDenied = "PERMISSION_DENIED"
+	CodeUnavailable      = "UNAVAILABLE"
+	CodeTimeout          = "TIMEOUT"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodeInternal         = "INTERNAL"
+)
+
+// codedError attaches a code to an error without changing its message.
+type codedError struct {
+	err  error
+	code string
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// Code returns err annotated with code. The message is unchanged, and the code
+// survives any further wrapping with fmt.Errorf("...: %w", err).
+// Code returns nil if err is nil.
+//
+// Example:
+//
+//	return errutil.Code(fmt.Errorf("user %d: %w", id, sql.ErrNoRows), errutil.CodeNotFound)
+func Code(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &codedError{err: err, code: code}
+}
+
+// CodeOf returns the outermost code attached to err, or "" if there is none.
+//
+// Because the outermost code wins, a layer that re-classifies an error (e.g. a
+// repository turning NOT_FOUND into CONFLICT) takes precedence over the code it wraps.
+func CodeOf(err error) string {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	return ""
+}
+
+// HasCode reports whether code is attached anywhere in err's chain,
+// not only as the outermost code.
+func HasCode(err error, code string) bool {
+	for err != nil {
+		if coded, ok := err.(*codedError); ok && coded.code == code {
+			return true
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if HasCode(e, code) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// IsNotFound reports whether err is classified as NOT_FOUND.
+func IsNotFound(err error) bool {
+	return CodeOf(err) == CodeNotFound
+}
+
+// IsConflict reports whether err is classified as CONFLICT or ALREADY_EXISTS.
+func IsConflict(err error) bool {
+	switch CodeOf(err) {
+	case CodeConflict, CodeAlreadyExists:
+		return true
+	}
+
+	return false
+}
+
+// IsInvalid reports whether err is classified as INVALID_ARGUMENT.
+func IsInvalid(err error) bool {
+	return CodeOf(err) == CodeInvalidArgument
+}
+
+// IsUnauthorized reports whether err is classified as UNAUTHENTICATED or PERMISSION_DENIED.
+func IsUnauthorized(err error) bool {
+	switch CodeOf(err) {
+	case CodeUnauthenticated, CodePermissionDenied:
+		return true
+	}
+
+	return false
+}
+
+// IsTransient reports whether err is classified with a code that usually clears
+// up on its own: UNAVAILABLE, TIMEOUT, or RATE_LIMITED.
+func IsTransient(err error) bool {
+	switch CodeOf(err) {
+	case CodeUnavailable, CodeTimeout, CodeRateLimited:
+		return true
+	}
+
+	return false
+}
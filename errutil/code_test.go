@@ -0,0 +1,93 @@
+package errutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	base := errors.New("user 7 not found")
+	err := Code(base, CodeNotFound)
+
+	assert.Equal(t, "user 7 not found", err.Error())
+	assert.ErrorIs(t, err, base)
+	assert.Equal(t, CodeNotFound, CodeOf(err))
+	assert.Nil(t, Code(nil, CodeNotFound))
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: "",
+		},
+		{
+			name:     "uncoded",
+			err:      io.EOF,
+			expected: "",
+		},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("handler: %w", Code(io.EOF, CodeUnavailable)),
+			expected: CodeUnavailable,
+		},
+		{
+			name:     "outermost wins",
+			err:      Code(fmt.Errorf("insert: %w", Code(io.EOF, CodeNotFound)), CodeConflict),
+			expected: CodeConflict,
+		},
+		{
+			name:     "inside multi error",
+			err:      Append(io.EOF, Code(io.ErrClosedPipe, CodeTimeout)),
+			expected: CodeTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CodeOf(tt.err), tt.name)
+		})
+	}
+}
+
+func TestHasCode(t *testing.T) {
+	err := Code(fmt.Errorf("insert: %w", Code(io.EOF, CodeNotFound)), CodeConflict)
+
+	assert.True(t, HasCode(err, CodeConflict))
+	assert.True(t, HasCode(err, CodeNotFound))
+	assert.False(t, HasCode(err, CodeInternal))
+	assert.True(t, HasCode(Append(io.EOF, Code(io.EOF, CodeInternal)), CodeInternal))
+	assert.False(t, HasCode(nil, CodeInternal))
+}
+
+func TestCategoryPredicates(t *testing.T) {
+	wrap := func(code string) error {
+		return fmt.Errorf("op: %w", Code(io.EOF, code))
+	}
+
+	assert.True(t, IsNotFound(wrap(CodeNotFound)))
+	assert.False(t, IsNotFound(wrap(CodeConflict)))
+
+	assert.True(t, IsConflict(wrap(CodeConflict)))
+	assert.True(t, IsConflict(wrap(CodeAlreadyExists)))
+	assert.False(t, IsConflict(io.EOF))
+
+	assert.True(t, IsInvalid(wrap(CodeInvalidArgument)))
+
+	assert.True(t, IsUnauthorized(wrap(CodeUnauthenticated)))
+	assert.True(t, IsUnauthorized(wrap(CodePermissionDenied)))
+	assert.False(t, IsUnauthorized(wrap(CodeInternal)))
+
+	assert.True(t, IsTransient(wrap(CodeUnavailable)))
+	assert.True(t, IsTransient(wrap(CodeTimeout)))
+	assert.True(t, IsTransient(wrap(CodeRateLimited)))
+	assert.False(t, IsTransient(wrap(CodeNotFound)))
+}
@@ -0,0 +1,71 @@
+package errutil
+
+import "maps"
+
+// fieldsError attaches structured key/value context to an error.
+type fieldsError struct {
+	err    error
+	fields map[string]any
+}
+
+func (e *fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// WithFields returns err annotated with fields. The message is unchanged; use
+// Fields to read the context back, e.g. in a logging middleware. The map is
+// copied, so the caller may reuse it. WithFields returns nil if err is nil,
+// and err unchanged if fields is empty.
+//
+// Example:
+//
+//	return errutil.WithFields(err, map[string]any{"user_id": id, "attempt": n})
+func WithFields(err error, fields map[string]any) error {
+	if err == nil || len(fields) == 0 {
+		return err
+	}
+
+	return &fieldsError{err: err, fields: maps.Clone(fields)}
+}
+
+// Fields returns all fields attached anywhere in err's chain, merged into one map.
+// When the same key is attached at several levels, the outermost value wins.
+// Fields returns nil if no fields are attached.
+func Fields(err error) map[string]any {
+	var out map[string]any
+	collectFields(err, &out)
+
+	return out
+}
+
+// collectFields walks err from the outside in, only filling keys not yet set.
+func collectFields(err error, out *map[string]any) {
+	for err != nil {
+		if fe, ok := err.(*fieldsError); ok && len(fe.fields) > 0 {
+			if *out == nil {
+				*out = make(map[string]any, len(fe.fields))
+			}
+			for k, v := range fe.fields {
+				if _, exists := (*out)[k]; !exists {
+					(*out)[k] = v
+				}
+			}
+		}
+
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				collectFields(e, out)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package errutil
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFields(t *testing.T) {
+	fields := map[string]any{"user_id": 7}
+	err := WithFields(io.EOF, fields)
+	fields["user_id"] = 8
+
+	assert.Equal(t, "EOF", err.Error())
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, map[string]any{"user_id": 7}, Fields(err), "fields are copied")
+	assert.Nil(t, WithFields(nil, fields))
+	assert.Same(t, io.EOF, WithFields(io.EOF, nil), "nil fields leave err unchanged")
+	assert.Same(t, io.EOF, WithFields(io.EOF, map[string]any{}), "empty fields leave err unchanged")
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected map[string]any
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "no fields",
+			err:      fmt.Errorf("x: %w", io.EOF),
+			expected: nil,
+		},
+		{
+			name:     "empty fields",
+			err:      fmt.Errorf("x: %w", WithFields(io.EOF, map[string]any{})),
+			expected: nil,
+		},
+		{
+			name:     "empty fields struct",
+			err:      &fieldsError{err: io.EOF},
+			expected: nil,
+		},
+		{
+			name: "empty inner fields",
+			err: WithFields(
+				&fieldsError{err: io.EOF, fields: map[string]any{}},
+				map[string]any{"k": "v"},
+			),
+			expected: map[string]any{"k": "v"},
+		},
+		{
+			name: "accumulates through wrapping",
+			err: WithFields(
+				fmt.Errorf("handler: %w", WithFields(io.EOF, map[string]any{"query": "select"})),
+				map[string]any{"request_id": "abc"},
+			),
+			expected: map[string]any{"query": "select", "request_id": "abc"},
+		},
+		{
+			name: "outermost wins",
+			err: WithFields(
+				WithFields(io.EOF, map[string]any{"attempt": 1, "host": "a"}),
+				map[string]any{"attempt": 3},
+			),
+			expected: map[string]any{"attempt": 3, "host": "a"},
+		},
+		{
+			name: "through other markers",
+			err: Code(
+				MarkTemporary(WithFields(io.EOF, map[string]any{"k": "v"})),
+				CodeUnavailable,
+			),
+			expected: map[string]any{"k": "v"},
+		},
+		{
+			name: "multi error",
+			err: Append(
+				WithFields(io.EOF, map[string]any{"item": 1}),
+				WithFields(io.EOF, map[string]any{"batch": 2}),
+			),
+			expected: map[string]any{"item": 1, "batch": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Fields(tt.err), tt.name)
+		})
+	}
+}
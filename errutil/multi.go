@@ -0,0 +1,112 @@
+// Package errutil provides helpers for building, classifying, and inspecting errors.
+//
+// Everything here works with the standard errors package: wrapped errors keep
+// their markers and codes, and errors.Is / errors.As see through every type
+// defined in this package.
+package errutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError collects several errors into one.
+//
+// It implements Unwrap() []error, so errors.Is and errors.As match any of the
+// collected errors. Build one with Append and return it through ErrorOrNil so
+// that "no errors" stays a plain nil error.
+type MultiError struct {
+	Errors []error
+}
+
+// Append adds errs to err and returns the combined MultiError.
+//
+// If err is already a *MultiError the errors are appended to it; otherwise a
+// new MultiError holding err is created. Nil errors are skipped, and any
+// *MultiError among errs is flattened so that the result is never nested.
+//
+// Example:
+//
+//	var result *errutil.MultiError
+//	for _, item := range items {
+//		result = errutil.Append(result, validate(item))
+//	}
+//	return result.ErrorOrNil()
+func Append(err error, errs ...error) *MultiError {
+	m, ok := err.(*MultiError)
+	if !ok || m == nil {
+		m = &MultiError{}
+		m.add(err)
+	}
+	for _, e := range errs {
+		m.add(e)
+	}
+
+	return m
+}
+
+func (m *MultiError) add(err error) {
+	switch e := err.(type) {
+	case nil:
+	case *MultiError:
+		if e != nil {
+			m.Errors = append(m.Errors, e.Errors...)
+		}
+	default:
+		m.Errors = append(m.Errors, err)
+	}
+}
+
+// Error lists every collected error. A single error is returned unchanged;
+// several are formatted one per line:
+//
+//	2 errors occurred:
+//		* name is required
+//		* age must be positive
+func (m *MultiError) Error() string {
+	switch len(m.Errors) {
+	case 0:
+		return "no errors"
+	case 1:
+		return m.Errors[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors occurred:", len(m.Errors))
+	for _, err := range m.Errors {
+		b.WriteString("\n\t* ")
+		b.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n\t  "))
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the collected errors.
+func (m *MultiError) Unwrap() []error {
+	if m == nil {
+		return nil
+	}
+
+	return m.Errors
+}
+
+// Len returns the number of collected errors. It is safe to call on a nil MultiError.
+func (m *MultiError) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.Errors)
+}
+
+// ErrorOrNil returns m as an error, or nil if m is nil or holds no errors.
+//
+// Always return a MultiError through ErrorOrNil: a nil *MultiError stored in an
+// error interface is not == nil.
+func (m *MultiError) ErrorOrNil() error {
+	if m.Len() == 0 {
+		return nil
+	}
+
+	return m
+}
@@ -0,0 +1,126 @@
+package errutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppend(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		name     string
+		err      error
+		errs     []error
+		expected []error
+	}{
+		{
+			name:     "nil base",
+			err:      nil,
+			errs:     []error{errA},
+			expected: []error{errA},
+		},
+		{
+			name:     "plain base",
+			err:      errA,
+			errs:     []error{errB},
+			expected: []error{errA, errB},
+		},
+		{
+			name:     "skips nils",
+			err:      nil,
+			errs:     []error{nil, errA, nil},
+			expected: []error{errA},
+		},
+		{
+			name:     "existing multi",
+			err:      &MultiError{Errors: []error{errA}},
+			errs:     []error{errB},
+			expected: []error{errA, errB},
+		},
+		{
+			name:     "flattens nested",
+			err:      errA,
+			errs:     []error{&MultiError{Errors: []error{errB, errC}}},
+			expected: []error{errA, errB, errC},
+		},
+		{
+			name:     "typed nil multi",
+			err:      (*MultiError)(nil),
+			errs:     []error{errA},
+			expected: []error{errA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Append(tt.err, tt.errs...)
+			assert.Equal(t, tt.expected, m.Errors, tt.name)
+		})
+	}
+}
+
+func TestMultiErrorError(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []error
+		expected string
+	}{
+		{
+			name:     "empty",
+			errs:     nil,
+			expected: "no errors",
+		},
+		{
+			name:     "single",
+			errs:     []error{errors.New("boom")},
+			expected: "boom",
+		},
+		{
+			name:     "multiple",
+			errs:     []error{errors.New("name is required"), errors.New("age must be positive")},
+			expected: "2 errors occurred:\n\t* name is required\n\t* age must be positive",
+		},
+		{
+			name:     "multiline member",
+			errs:     []error{errors.New("first\nsecond"), errors.New("third")},
+			expected: "2 errors occurred:\n\t* first\n\t  second\n\t* third",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MultiError{Errors: tt.errs}
+			assert.Equal(t, tt.expected, m.Error(), tt.name)
+		})
+	}
+}
+
+func TestMultiErrorIsAs(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+	err := fmt.Errorf("loading: %w", Append(io.EOF, pathErr).ErrorOrNil())
+
+	assert.ErrorIs(t, err, io.EOF)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	var target *fs.PathError
+	assert.True(t, errors.As(err, &target))
+	assert.Equal(t, "x", target.Path)
+}
+
+func TestErrorOrNil(t *testing.T) {
+	var m *MultiError
+	assert.Nil(t, m.ErrorOrNil())
+	assert.Equal(t, 0, m.Len())
+	assert.Nil(t, m.Unwrap())
+
+	m = Append(nil, nil)
+	assert.Nil(t, m.ErrorOrNil())
+
+	m = Append(m, io.EOF)
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, error(m), m.ErrorOrNil())
+}
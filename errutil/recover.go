@@ -0,0 +1,60 @@
+package errutil
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic turned into an error.
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // goroutine stack at the point of recovery
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and errors.As
+// can match panics like panic(io.ErrUnexpectedEOF).
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover converts a panic into a *PanicError stored in *errp.
+// It must be deferred directly, since recover only works in a deferred call:
+//
+//	func (h *Handler) Process(msg Message) (err error) {
+//		defer errutil.Recover(&err)
+//		...
+//	}
+//
+// If the function had already set *errp when it panicked, both errors are kept
+// in a MultiError. Without a panic, Recover does nothing.
+func Recover(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+	if *errp == nil {
+		*errp = panicErr
+		return
+	}
+	*errp = Append(*errp, panicErr)
+}
+
+// Safe calls fn and returns its error, or a *PanicError if fn panics.
+//
+// Example:
+//
+//	err := errutil.Safe(func() error {
+//		return plugin.Run(ctx)
+//	})
+func Safe(fn func() error) (err error) {
+	defer Recover(&err)
+
+	return fn()
+}
@@ -0,0 +1,80 @@
+package errutil
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafe(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func() error
+		expected string
+	}{
+		{
+			name:     "no panic",
+			fn:       func() error { return nil },
+			expected: "",
+		},
+		{
+			name:     "returned error",
+			fn:       func() error { return io.EOF },
+			expected: "EOF",
+		},
+		{
+			name:     "panic with string",
+			fn:       func() error { panic("boom") },
+			expected: "panic: boom",
+		},
+		{
+			name: "runtime panic",
+			fn: func() error {
+				var m map[string]int
+				m["x"] = 1
+				return nil
+			},
+			expected: "panic: assignment to entry in nil map",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Safe(tt.fn)
+			if tt.expected == "" {
+				assert.NoError(t, err, tt.name)
+				return
+			}
+			assert.EqualError(t, err, tt.expected, tt.name)
+		})
+	}
+}
+
+func TestSafePanicError(t *testing.T) {
+	err := Safe(func() error { panic(io.ErrUnexpectedEOF) })
+
+	var panicErr *PanicError
+	assert.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, io.ErrUnexpectedEOF, panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "TestSafePanicError")
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	assert.Nil(t, (&PanicError{Value: 42}).Unwrap())
+}
+
+func TestRecoverKeepsExistingError(t *testing.T) {
+	run := func() (err error) {
+		defer Recover(&err)
+		defer func() { panic("cleanup failed") }()
+
+		return io.EOF
+	}
+
+	err := run()
+	assert.ErrorIs(t, err, io.EOF)
+
+	var panicErr *PanicError
+	assert.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "cleanup failed", panicErr.Value)
+}
@@ -0,0 +1,93 @@
+package errutil
+
+// markedError carries an explicit retry classification.
+//
+// It implements the Temporary() bool convention used by net.Error, so code
+// that already checks for that interface understands the markers too.
+type markedError struct {
+	err       error
+	temporary bool
+}
+
+func (e *markedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *markedError) Unwrap() error {
+	return e.err
+}
+
+func (e *markedError) Temporary() bool {
+	return e.temporary
+}
+
+// MarkTemporary marks err as worth retrying. It returns nil if err is nil.
+func MarkTemporary(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &markedError{err: err, temporary: true}
+}
+
+// MarkPermanent marks err as not worth retrying, overriding any temporary
+// classification of the errors it wraps. It returns nil if err is nil.
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &markedError{err: err, temporary: false}
+}
+
+// IsTemporary reports whether err should be retried.
+//
+// The error chain is searched from the outside in, and the first error that
+// expresses an opinion decides:
+//   - errors marked with MarkTemporary or MarkPermanent,
+//   - any error with a Temporary() bool method, such as net.Error,
+//   - errors carrying a transient code (see IsTransient).
+//
+// Errors nobody classified are not temporary. Retry loops should use
+// IsTemporary as their classification so all clients agree on what to retry.
+func IsTemporary(err error) bool {
+	temporary, _ := classify(err)
+	return temporary
+}
+
+// IsPermanent reports whether err was explicitly classified as not retryable,
+// either by MarkPermanent or by an error whose Temporary method returns false.
+// Unclassified errors are neither temporary nor permanent.
+func IsPermanent(err error) bool {
+	temporary, decided := classify(err)
+	return decided && !temporary
+}
+
+func classify(err error) (temporary, decided bool) {
+	for err != nil {
+		switch e := err.(type) {
+		case interface{ Temporary() bool }:
+			return e.Temporary(), true
+		case *codedError:
+			if IsTransient(e) {
+				return true, true
+			}
+		}
+
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if temporary, decided := classify(e); decided {
+					return temporary, true
+				}
+			}
+			return false, false
+		default:
+			return false, false
+		}
+	}
+
+	return false, false
+}
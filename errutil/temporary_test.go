@@ -0,0 +1,87 @@
+package errutil
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tempErr struct{ temporary bool }
+
+func (e tempErr) Error() string   { return "temp" }
+func (e tempErr) Temporary() bool { return e.temporary }
+
+func TestTemporaryClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		temporary bool
+		permanent bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+		},
+		{
+			name: "unclassified",
+			err:  io.EOF,
+		},
+		{
+			name:      "marked temporary",
+			err:       fmt.Errorf("dial: %w", MarkTemporary(io.EOF)),
+			temporary: true,
+		},
+		{
+			name:      "marked permanent",
+			err:       MarkPermanent(io.EOF),
+			permanent: true,
+		},
+		{
+			name:      "permanent overrides inner temporary",
+			err:       MarkPermanent(fmt.Errorf("x: %w", MarkTemporary(io.EOF))),
+			permanent: true,
+		},
+		{
+			name:      "temporary interface",
+			err:       fmt.Errorf("x: %w", tempErr{temporary: true}),
+			temporary: true,
+		},
+		{
+			name:      "net timeout",
+			err:       &net.DNSError{Err: "timeout", IsTimeout: true, IsTemporary: true},
+			temporary: true,
+		},
+		{
+			name:      "transient code",
+			err:       Code(io.EOF, CodeUnavailable),
+			temporary: true,
+		},
+		{
+			name: "non transient code",
+			err:  Code(io.EOF, CodeNotFound),
+		},
+		{
+			name:      "inside multi error",
+			err:       Append(io.EOF, MarkPermanent(io.ErrClosedPipe)),
+			permanent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.temporary, IsTemporary(tt.err), "temporary")
+			assert.Equal(t, tt.permanent, IsPermanent(tt.err), "permanent")
+		})
+	}
+}
+
+func TestMarkers(t *testing.T) {
+	assert.Nil(t, MarkTemporary(nil))
+	assert.Nil(t, MarkPermanent(nil))
+
+	err := MarkTemporary(io.EOF)
+	assert.Equal(t, "EOF", err.Error())
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,65 @@
+package id
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/bits"
+)
+
+// NanoIDAlphabet is the default NanoID alphabet: 64 URL-safe characters.
+const NanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// NanoID returns a random string of size characters from NanoIDAlphabet.
+//
+// With the default alphabet, 21 characters give about the same collision
+// resistance as a UUIDv4; shorter IDs are fine for invite codes and share links
+// as long as they are checked for collisions when stored.
+func NanoID(size int) (string, error) {
+	return NanoIDWithAlphabet(NanoIDAlphabet, size)
+}
+
+// NanoIDWithAlphabet returns a random string of size characters drawn uniformly
+// from alphabet, which must hold between 2 and 256 distinct bytes.
+//
+// Random bytes come from crypto/rand and are masked down to the next power of
+// two; values that fall outside the alphabet are discarded rather than folded
+// back with a modulo, so every character is equally likely.
+//
+// Example:
+//
+//	NanoIDWithAlphabet("0123456789ABCDEFGHJKMNPQRSTVWXYZ", 8) => "7K2QX9MD"
+func NanoIDWithAlphabet(alphabet string, size int) (string, error) {
+	if size <= 0 {
+		return "", fmt.Errorf("id: nanoid size must be positive, got %d", size)
+	}
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return "", fmt.Errorf("id: nanoid alphabet must have 2 to 256 characters, got %d", len(alphabet))
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		if seen[alphabet[i]] {
+			return "", fmt.Errorf("id: nanoid alphabet has duplicate character %q", alphabet[i])
+		}
+		seen[alphabet[i]] = true
+	}
+
+	// Smallest all-ones mask covering every alphabet index.
+	mask := byte(1<<bits.Len(uint(len(alphabet)-1)) - 1)
+	// Fetch enough random bytes to usually finish in one round, given the
+	// fraction of masked values that will be rejected.
+	step := 1 + int(1.6*float64(int(mask)*size)/float64(len(alphabet)))
+
+	out := make([]byte, 0, size)
+	buf := make([]byte, step)
+	for {
+		rand.Read(buf)
+		for _, b := range buf {
+			if idx := int(b & mask); idx < len(alphabet) {
+				out = append(out, alphabet[idx])
+				if len(out) == size {
+					return string(out), nil
+				}
+			}
+		}
+	}
+}
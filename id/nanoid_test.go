@@ -0,0 +1,88 @@
+package id
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNanoID(t *testing.T) {
+	for _, size := range []int{1, 8, 21, 64} {
+		s, err := NanoID(size)
+		assert.NoError(t, err)
+		assert.Len(t, s, size)
+		for _, c := range s {
+			assert.True(t, strings.ContainsRune(NanoIDAlphabet, c), "unexpected %q", c)
+		}
+	}
+
+	a, _ := NanoID(21)
+	b, _ := NanoID(21)
+	assert.NotEqual(t, a, b)
+}
+
+func TestNanoIDWithAlphabetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		size     int
+	}{
+		{
+			name:     "zero size",
+			alphabet: "ab",
+			size:     0,
+		},
+		{
+			name:     "single character",
+			alphabet: "a",
+			size:     5,
+		},
+		{
+			name:     "too long",
+			alphabet: strings.Repeat("x", 257),
+			size:     5,
+		},
+		{
+			name:     "duplicates",
+			alphabet: "abca",
+			size:     5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NanoIDWithAlphabet(tt.alphabet, tt.size)
+			assert.Error(t, err, tt.name)
+		})
+	}
+}
+
+func TestNanoIDWithAlphabetUnbiased(t *testing.T) {
+	// 5 characters: a modulo over bytes would favour the first one.
+	const alphabet = "abcde"
+	const n = 50000
+
+	s, err := NanoIDWithAlphabet(alphabet, n)
+	assert.NoError(t, err)
+
+	counts := map[rune]int{}
+	for _, c := range s {
+		counts[c]++
+	}
+	assert.Len(t, counts, len(alphabet))
+	for c, count := range counts {
+		// Expected 10000 each; 5 sigma is roughly ±450.
+		assert.InDelta(t, n/len(alphabet), count, 600, "character %q", c)
+	}
+}
+
+func TestNanoIDFullByteAlphabet(t *testing.T) {
+	var all [256]byte
+	for i := range all {
+		all[i] = byte(i)
+	}
+
+	s, err := NanoIDWithAlphabet(string(all[:]), 32)
+	assert.NoError(t, err)
+	assert.Len(t, s, 32)
+}
@@ -0,0 +1,129 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Snowflake ID layout, from the most significant bit:
+//
+//	1 bit unused (IDs are always positive) | 41 bits milliseconds since epoch | 10 bits node | 12 bits sequence
+const (
+	snowflakeTimeBits = 41
+	snowflakeNodeBits = 10
+	snowflakeSeqBits  = 12
+
+	// MaxSnowflakeNode is the largest node ID a Snowflake can be configured with.
+	MaxSnowflakeNode = 1<<snowflakeNodeBits - 1
+
+	snowflakeMaxSeq  = 1<<snowflakeSeqBits - 1
+	snowflakeMaxTime = 1<<snowflakeTimeBits - 1
+
+	// snowflakeMaxDrift is how far the clock may step backwards before Next gives
+	// up waiting for it to catch up and returns ErrClockMovedBackwards.
+	snowflakeMaxDrift = 10 * time.Millisecond
+)
+
+var (
+	// ErrClockMovedBackwards is returned by Next when the system clock jumped
+	// back further than a few milliseconds. Issuing IDs would risk duplicates.
+	ErrClockMovedBackwards = errors.New("id: clock moved backwards")
+
+	// ErrSnowflakeExhausted is returned by Next once the 41-bit timestamp
+	// (about 69 years after the epoch) has run out.
+	ErrSnowflakeExhausted = errors.New("id: snowflake timestamp exhausted")
+)
+
+// Snowflake generates 64-bit, time-ordered IDs in the style of Twitter's Snowflake.
+//
+// Each generator has a node ID that must be unique among all generators sharing
+// the same epoch; within one node, up to 4096 IDs are issued per millisecond.
+// When a millisecond's sequence is used up, Next waits for the next millisecond.
+// IDs sort by creation time across nodes (to the millisecond).
+//
+// It is safe for concurrent use.
+type Snowflake struct {
+	epoch time.Time
+	node  int64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	lastMs int64
+	seq    int64
+}
+
+// NewSnowflake returns a generator for the given node ID (0 to MaxSnowflakeNode)
+// counting time from epoch. The epoch should be a fixed date shortly before the
+// system went live, and must never change once IDs have been issued.
+func NewSnowflake(node int64, epoch time.Time) (*Snowflake, error) {
+	if node < 0 || node > MaxSnowflakeNode {
+		return nil, fmt.Errorf("id: snowflake node %d out of range [0, %d]", node, MaxSnowflakeNode)
+	}
+	if epoch.After(time.Now()) {
+		return nil, fmt.Errorf("id: snowflake epoch %s is in the future", epoch.Format(time.RFC3339))
+	}
+
+	return &Snowflake{epoch: epoch, node: node, now: time.Now, lastMs: -1}, nil
+}
+
+// Next returns a new ID.
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := s.sinceEpoch()
+	if ms < s.lastMs {
+		if time.Duration(s.lastMs-ms)*time.Millisecond > snowflakeMaxDrift {
+			return 0, fmt.Errorf("%w by %dms", ErrClockMovedBackwards, s.lastMs-ms)
+		}
+		ms = s.waitAfter(s.lastMs - 1)
+	}
+
+	if ms == s.lastMs {
+		s.seq = (s.seq + 1) & snowflakeMaxSeq
+		if s.seq == 0 {
+			ms = s.waitAfter(ms)
+		}
+	} else {
+		s.seq = 0
+	}
+
+	if ms > snowflakeMaxTime {
+		return 0, ErrSnowflakeExhausted
+	}
+	s.lastMs = ms
+
+	return ms<<(snowflakeNodeBits+snowflakeSeqBits) | s.node<<snowflakeSeqBits | s.seq, nil
+}
+
+// Decompose splits an ID produced by a generator with the same epoch back into
+// its creation time, node ID, and sequence number.
+func (s *Snowflake) Decompose(id int64) (t time.Time, node, seq int64) {
+	ms := id >> (snowflakeNodeBits + snowflakeSeqBits)
+	node = (id >> snowflakeSeqBits) & MaxSnowflakeNode
+	seq = id & snowflakeMaxSeq
+
+	return s.epoch.Add(time.Duration(ms) * time.Millisecond), node, seq
+}
+
+// Node returns the generator's node ID.
+func (s *Snowflake) Node() int64 {
+	return s.node
+}
+
+func (s *Snowflake) sinceEpoch() int64 {
+	return s.now().Sub(s.epoch).Milliseconds()
+}
+
+// waitAfter blocks until the clock is past ms and returns the new time.
+func (s *Snowflake) waitAfter(ms int64) int64 {
+	now := s.sinceEpoch()
+	for now <= ms {
+		time.Sleep(time.Duration(ms-now+1) * time.Millisecond / 2)
+		now = s.sinceEpoch()
+	}
+
+	return now
+}
@@ -0,0 +1,174 @@
+package id
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeClock returns a clock reading the given times in order, then repeating the last one
+// advanced by a millisecond per call.
+func fakeClock(times ...time.Time) func() time.Time {
+	var mu sync.Mutex
+	i := 0
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if i < len(times) {
+			i++
+			return times[i-1]
+		}
+		times[len(times)-1] = times[len(times)-1].Add(time.Millisecond)
+		return times[len(times)-1]
+	}
+}
+
+func TestNewSnowflake(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    int64
+		epoch   time.Time
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			node:  5,
+			epoch: testEpoch,
+		},
+		{
+			name:  "max node",
+			node:  MaxSnowflakeNode,
+			epoch: testEpoch,
+		},
+		{
+			name:    "negative node",
+			node:    -1,
+			epoch:   testEpoch,
+			wantErr: true,
+		},
+		{
+			name:    "node too large",
+			node:    MaxSnowflakeNode + 1,
+			epoch:   testEpoch,
+			wantErr: true,
+		},
+		{
+			name:    "future epoch",
+			node:    1,
+			epoch:   time.Now().Add(time.Hour),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSnowflake(tt.node, tt.epoch)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.node, s.Node(), tt.name)
+		})
+	}
+}
+
+func TestSnowflakeDecompose(t *testing.T) {
+	s, err := NewSnowflake(42, testEpoch)
+	assert.NoError(t, err)
+
+	at := testEpoch.Add(1234 * time.Millisecond)
+	s.now = fakeClock(at, at, at)
+
+	for seq := int64(0); seq < 3; seq++ {
+		id, err := s.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1234)<<22|42<<12|seq, id)
+
+		created, node, gotSeq := s.Decompose(id)
+		assert.True(t, at.Equal(created))
+		assert.Equal(t, int64(42), node)
+		assert.Equal(t, seq, gotSeq)
+	}
+}
+
+func TestSnowflakeOrderedAndUnique(t *testing.T) {
+	s, err := NewSnowflake(1, testEpoch)
+	assert.NoError(t, err)
+
+	const n = 20000 // more than one millisecond's sequence
+	seen := make(map[int64]bool, n)
+	last := int64(-1)
+	for i := 0; i < n; i++ {
+		id, err := s.Next()
+		assert.NoError(t, err)
+		if id <= last {
+			t.Fatalf("id %d not after %d", id, last)
+		}
+		seen[id] = true
+		last = id
+	}
+	assert.Len(t, seen, n)
+
+	created, _, _ := s.Decompose(last)
+	assert.WithinDuration(t, time.Now(), created, time.Minute)
+}
+
+func TestSnowflakeSequenceExhaustion(t *testing.T) {
+	s, _ := NewSnowflake(0, testEpoch)
+
+	at := testEpoch.Add(time.Second)
+	times := make([]time.Time, snowflakeMaxSeq+2)
+	for i := range times {
+		times[i] = at
+	}
+	s.now = fakeClock(times...)
+
+	var id int64
+	for i := 0; i <= snowflakeMaxSeq+1; i++ {
+		id, _ = s.Next()
+	}
+
+	created, _, seq := s.Decompose(id)
+	assert.Equal(t, int64(0), seq, "sequence restarts")
+	assert.True(t, created.After(at), "moved on to the next millisecond")
+}
+
+func TestSnowflakeClockDrift(t *testing.T) {
+	at := testEpoch.Add(time.Hour)
+
+	t.Run("small drift waits", func(t *testing.T) {
+		s, _ := NewSnowflake(0, testEpoch)
+		s.now = fakeClock(at, at.Add(-2*time.Millisecond), at.Add(-time.Millisecond))
+
+		first, err := s.Next()
+		assert.NoError(t, err)
+		second, err := s.Next()
+		assert.NoError(t, err)
+		assert.Greater(t, second, first)
+	})
+
+	t.Run("large drift fails", func(t *testing.T) {
+		s, _ := NewSnowflake(0, testEpoch)
+		s.now = fakeClock(at, at.Add(-time.Second))
+
+		_, err := s.Next()
+		assert.NoError(t, err)
+		_, err = s.Next()
+		assert.True(t, errors.Is(err, ErrClockMovedBackwards))
+		assert.ErrorContains(t, err, "by 1000ms")
+	})
+}
+
+func TestSnowflakeExhausted(t *testing.T) {
+	s, _ := NewSnowflake(0, testEpoch)
+	s.now = fakeClock(testEpoch.Add(time.Duration(snowflakeMaxTime+1) * time.Millisecond))
+
+	_, err := s.Next()
+	assert.ErrorIs(t, err, ErrSnowflakeExhausted)
+}
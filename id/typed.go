@@ -0,0 +1,209 @@
+package id
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidTyped is returned (wrapped) when a typed ID can't be parsed or created.
+var ErrInvalidTyped = errors.New("invalid typed ID")
+
+const maxPrefixLen = 16
+
+var prefixes = struct {
+	sync.RWMutex
+	known map[string]bool
+}{known: map[string]bool{}}
+
+// RegisterPrefix makes prefix available to NewTyped and ParseTyped.
+//
+// Prefixes are 1 to 16 lowercase letters or digits starting with a letter,
+// e.g. "usr", "org", "inv2". Registering the same prefix twice is an error, which
+// catches two packages accidentally claiming the same one. Register prefixes
+// at init time, typically next to the type that uses them.
+func RegisterPrefix(prefix string) error {
+	if err := validPrefix(prefix); err != nil {
+		return err
+	}
+
+	prefixes.Lock()
+	defer prefixes.Unlock()
+
+	if prefixes.known[prefix] {
+		return fmt.Errorf("id: %w: prefix %q already registered", ErrInvalidTyped, prefix)
+	}
+	prefixes.known[prefix] = true
+
+	return nil
+}
+
+// MustRegisterPrefix is like RegisterPrefix but panics on error.
+//
+// Example:
+//
+//	var userPrefix = id.MustRegisterPrefix("usr")
+func MustRegisterPrefix(prefix string) string {
+	if err := RegisterPrefix(prefix); err != nil {
+		panic(err)
+	}
+
+	return prefix
+}
+
+func validPrefix(prefix string) error {
+	if prefix == "" || len(prefix) > maxPrefixLen {
+		return fmt.Errorf("id: %w: prefix %q must have 1 to %d characters", ErrInvalidTyped, prefix, maxPrefixLen)
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if !(c >= 'a' && c <= 'z' || i > 0 && c >= '0' && c <= '9') {
+			return fmt.Errorf("id: %w: prefix %q must be lowercase letters and digits, starting with a letter", ErrInvalidTyped, prefix)
+		}
+	}
+
+	return nil
+}
+
+func registered(prefix string) bool {
+	prefixes.RLock()
+	defer prefixes.RUnlock()
+
+	return prefixes.known[prefix]
+}
+
+// Typed is a prefixed identifier in the style of Stripe's object IDs:
+//
+//	usr_01hx3k6qn6f7y9t8e3q1v4b2ma
+//
+// The prefix names the kind of object, so an ID pasted into the wrong API or log
+// search is recognizably wrong, and the suffix is a lowercase ULID, which keeps
+// typed IDs sortable by creation time. The underlying 128 bits can also be read
+// as a UUID for storage in UUID columns.
+type Typed struct {
+	prefix string
+	id     ULID
+}
+
+// NewTyped returns a new typed ID with a fresh ULID. prefix must be registered.
+func NewTyped(prefix string) (Typed, error) {
+	return TypedFromULID(prefix, NewULID())
+}
+
+// TypedFromULID returns the typed ID for an existing ULID. prefix must be registered.
+func TypedFromULID(prefix string, u ULID) (Typed, error) {
+	if !registered(prefix) {
+		return Typed{}, fmt.Errorf("id: %w: unknown prefix %q", ErrInvalidTyped, prefix)
+	}
+
+	return Typed{prefix: prefix, id: u}, nil
+}
+
+// TypedFromUUID returns the typed ID for a UUID in its canonical
+// 8-4-4-4-12 hex form. prefix must be registered.
+func TypedFromUUID(prefix, uuid string) (Typed, error) {
+	if len(uuid) != 36 || uuid[8] != '-' || uuid[13] != '-' || uuid[18] != '-' || uuid[23] != '-' {
+		return Typed{}, fmt.Errorf("id: %w: malformed UUID %q", ErrInvalidTyped, uuid)
+	}
+	raw := uuid[0:8] + uuid[9:13] + uuid[14:18] + uuid[19:23] + uuid[24:]
+	var u ULID
+	if _, err := hex.Decode(u[:], []byte(raw)); err != nil {
+		return Typed{}, fmt.Errorf("id: %w: malformed UUID %q", ErrInvalidTyped, uuid)
+	}
+
+	return TypedFromULID(prefix, u)
+}
+
+// ParseTyped parses a typed ID such as "usr_01hx3k6qn6f7y9t8e3q1v4b2ma".
+// The prefix must be registered.
+func ParseTyped(s string) (Typed, error) {
+	prefix, body, found := strings.Cut(s, "_")
+	if !found {
+		return Typed{}, fmt.Errorf("id: %w %q: missing prefix separator", ErrInvalidTyped, s)
+	}
+
+	u, err := ParseULID(body)
+	if err != nil {
+		return Typed{}, fmt.Errorf("id: %w %q: %v", ErrInvalidTyped, s, err)
+	}
+
+	return TypedFromULID(prefix, u)
+}
+
+// ParseTypedAs is like ParseTyped but also requires the ID to have the given prefix,
+// e.g. to reject an "org_..." ID passed where a user ID is expected.
+func ParseTypedAs(prefix, s string) (Typed, error) {
+	t, err := ParseTyped(s)
+	if err != nil {
+		return Typed{}, err
+	}
+	if t.prefix != prefix {
+		return Typed{}, fmt.Errorf("id: %w %q: want prefix %q", ErrInvalidTyped, s, prefix)
+	}
+
+	return t, nil
+}
+
+// Prefix returns the ID's prefix.
+func (t Typed) Prefix() string {
+	return t.prefix
+}
+
+// ULID returns the underlying ULID.
+func (t Typed) ULID() ULID {
+	return t.id
+}
+
+// UUID returns the underlying 128 bits formatted as a canonical UUID string.
+func (t Typed) UUID() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], t.id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], t.id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], t.id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], t.id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], t.id[10:])
+
+	return string(buf[:])
+}
+
+// String returns the "prefix_ulid" form, or "" for the zero Typed.
+func (t Typed) String() string {
+	if t.prefix == "" {
+		return ""
+	}
+
+	return t.prefix + "_" + strings.ToLower(t.id.String())
+}
+
+// IsZero reports whether t is the zero Typed.
+func (t Typed) IsZero() bool {
+	return t.prefix == "" && t.id.IsZero()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Typed) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The prefix must be registered.
+// Empty text decodes to the zero Typed, mirroring MarshalText.
+func (t *Typed) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = Typed{}
+		return nil
+	}
+
+	parsed, err := ParseTyped(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+
+	return nil
+}
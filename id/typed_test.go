@@ -0,0 +1,166 @@
+package id
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	MustRegisterPrefix("usr")
+	MustRegisterPrefix("org")
+}
+
+func TestRegisterPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		wantErr bool
+	}{
+		{
+			name:   "letters and digits",
+			prefix: "inv2",
+		},
+		{
+			name:    "duplicate",
+			prefix:  "usr",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			prefix:  "",
+			wantErr: true,
+		},
+		{
+			name:    "uppercase",
+			prefix:  "Usr",
+			wantErr: true,
+		},
+		{
+			name:    "leading digit",
+			prefix:  "2fa",
+			wantErr: true,
+		},
+		{
+			name:    "underscore",
+			prefix:  "a_b",
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			prefix:  strings.Repeat("a", maxPrefixLen+1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterPrefix(tt.prefix)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTyped, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+		})
+	}
+
+	assert.Panics(t, func() { MustRegisterPrefix("usr") })
+}
+
+func TestTypedRoundTrip(t *testing.T) {
+	typed, err := NewTyped("usr")
+	assert.NoError(t, err)
+	assert.Equal(t, "usr", typed.Prefix())
+
+	s := typed.String()
+	assert.True(t, strings.HasPrefix(s, "usr_"))
+	assert.Len(t, s, len("usr_")+ulidLen)
+	assert.Equal(t, strings.ToLower(s), s)
+
+	parsed, err := ParseTyped(s)
+	assert.NoError(t, err)
+	assert.Equal(t, typed, parsed)
+
+	_, err = NewTyped("unregistered")
+	assert.ErrorIs(t, err, ErrInvalidTyped)
+}
+
+func TestParseTypedErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "no separator",
+			input: "01arz3ndektsv4rrffq69g5fav",
+		},
+		{
+			name:  "unknown prefix",
+			input: "cus_01arz3ndektsv4rrffq69g5fav",
+		},
+		{
+			name:  "bad body",
+			input: "usr_123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTyped(tt.input)
+			assert.ErrorIs(t, err, ErrInvalidTyped, tt.name)
+		})
+	}
+}
+
+func TestParseTypedAs(t *testing.T) {
+	_, err := ParseTypedAs("usr", "usr_01arz3ndektsv4rrffq69g5fav")
+	assert.NoError(t, err)
+
+	_, err = ParseTypedAs("usr", "org_01arz3ndektsv4rrffq69g5fav")
+	assert.ErrorContains(t, err, `want prefix "usr"`)
+}
+
+func TestTypedUUID(t *testing.T) {
+	const uuid = "01563e3a-b5d3-d676-4c61-efb99302bd5b"
+
+	typed, err := TypedFromUUID("org", uuid)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid, typed.UUID())
+	assert.Equal(t, "org_01arz3ndektsv4rrffq69g5fav", typed.String())
+	assert.Equal(t, MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV"), typed.ULID())
+
+	for _, bad := range []string{
+		"01563e3a",
+		"01563e3a-b5d3-d676-4c61-efb99302bdzz",
+		"01563e3ab5d3d6764c61efb99302bd5b",
+		"01563e3ab-5d3-d676-4c61-efb99302bd5b",
+		"01563e3a-b5d3d-676-4c61-efb99302bd5b",
+		"-01563e3ab5d3-d676-4c61efb99302bd5b",
+	} {
+		_, err = TypedFromUUID("org", bad)
+		assert.ErrorIs(t, err, ErrInvalidTyped, bad)
+	}
+}
+
+func TestTypedJSON(t *testing.T) {
+	typed, _ := ParseTyped("usr_01arz3ndektsv4rrffq69g5fav")
+
+	out, err := json.Marshal(struct{ ID Typed }{typed})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ID":"usr_01arz3ndektsv4rrffq69g5fav"}`, string(out))
+
+	var decoded struct{ ID Typed }
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, typed, decoded.ID)
+
+	assert.True(t, Typed{}.IsZero())
+	assert.Equal(t, "", Typed{}.String())
+
+	out, err = json.Marshal(struct{ ID Typed }{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ID":""}`, string(out))
+
+	decoded.ID = typed
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.True(t, decoded.ID.IsZero())
+}
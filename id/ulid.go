@@ -0,0 +1,219 @@
+// Package id generates and parses unique identifiers: sortable ULIDs,
+// Snowflake-style 64-bit IDs, short random NanoIDs, and prefixed typed IDs.
+//
+// Everything here uses only the standard library, and all randomness comes
+// from crypto/rand.
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ULID is a 128-bit Universally Unique Lexicographically Sortable Identifier.
+//
+// The first 48 bits are a Unix timestamp in milliseconds and the remaining 80
+// bits are random. Its canonical form is 26 characters of Crockford base32:
+//
+//	01ARZ3NDEKTSV4RRFFQ69G5FAV
+//	|--------||--------------|
+//	timestamp     randomness
+//
+// Both the byte form and the string form sort in creation order, so ULIDs can be
+// used directly as database keys and compared with bytes.Compare or <.
+type ULID [16]byte
+
+// crockford is the Crockford base32 alphabet. Its characters are in ASCII
+// order, which is what makes encoded ULIDs sort like their bytes.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidLen is the length of an encoded ULID.
+const ulidLen = 26
+
+// maxULIDTime is the largest timestamp that fits in 48 bits.
+const maxULIDTime = 1<<48 - 1
+
+var crockfordIndex = func() [256]byte {
+	var idx [256]byte
+	for i := range idx {
+		idx[i] = 0xFF
+	}
+	for i := 0; i < len(crockford); i++ {
+		c := crockford[i]
+		idx[c] = byte(i)
+		if c >= 'A' {
+			idx[c+'a'-'A'] = byte(i) // accept lowercase
+		}
+	}
+	return idx
+}()
+
+// ErrInvalidULID is returned (wrapped) by ParseULID for malformed input.
+var ErrInvalidULID = errors.New("invalid ULID")
+
+var ulidGen struct {
+	mu   sync.Mutex
+	last ULID
+}
+
+// NewULID returns a new ULID for the current time.
+//
+// ULIDs generated by one process are strictly increasing: within the same
+// millisecond, the random part of the previous ULID is incremented instead of
+// drawn afresh. If the clock goes backwards, the previous timestamp is reused
+// for the same reason. It is safe for concurrent use.
+func NewULID() ULID {
+	ms := uint64(time.Now().UnixMilli())
+
+	ulidGen.mu.Lock()
+	defer ulidGen.mu.Unlock()
+
+	last := &ulidGen.last
+	if lastMs := last.Timestamp(); ms <= lastMs {
+		u := *last
+		if u.incrementRandom() {
+			*last = u
+			return u
+		}
+		// 2^80 IDs in one millisecond: borrow the next millisecond.
+		ms = lastMs + 1
+	}
+
+	u := NewULIDAt(time.UnixMilli(int64(ms)))
+	*last = u
+
+	return u
+}
+
+// NewULIDAt returns a ULID for time t with fresh randomness.
+// Unlike NewULID, consecutive calls are not guaranteed to be ordered within a millisecond.
+func NewULIDAt(t time.Time) ULID {
+	var u ULID
+	u.setTimestamp(uint64(t.UnixMilli()) & maxULIDTime)
+	rand.Read(u[6:])
+
+	return u
+}
+
+// ParseULID parses the canonical 26-character form. Lowercase input is accepted.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != ulidLen {
+		return u, fmt.Errorf("id: %w %q: length %d, want %d", ErrInvalidULID, s, len(s), ulidLen)
+	}
+
+	var hi, lo uint64
+	for i := 0; i < ulidLen; i++ {
+		v := crockfordIndex[s[i]]
+		if v == 0xFF {
+			return u, fmt.Errorf("id: %w %q: bad character %q", ErrInvalidULID, s, s[i])
+		}
+		if i == 0 && v > 7 {
+			// 26 characters hold 130 bits; the top two must be zero.
+			return u, fmt.Errorf("id: %w %q: overflows 128 bits", ErrInvalidULID, s)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+
+	return u, nil
+}
+
+// MustParseULID is like ParseULID but panics on error. It is meant for constants in tests.
+func MustParseULID(s string) ULID {
+	u, err := ParseULID(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return u
+}
+
+// String returns the canonical 26-character Crockford base32 form.
+func (u ULID) String() string {
+	hi, lo := binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+
+	var buf [ulidLen]byte
+	for i := range buf {
+		// Character i holds bits [shift, shift+5) of the 128-bit value.
+		shift := uint(125 - 5*i)
+		var v uint64
+		if shift >= 64 {
+			v = hi >> (shift - 64)
+		} else {
+			v = lo>>shift | hi<<(64-shift)
+		}
+		buf[i] = crockford[v&31]
+	}
+
+	return string(buf[:])
+}
+
+// Timestamp returns the embedded Unix time in milliseconds.
+func (u ULID) Timestamp() uint64 {
+	return uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+		uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+}
+
+// Time returns the embedded timestamp as a time.Time.
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(int64(u.Timestamp()))
+}
+
+// Compare returns -1, 0, or +1 depending on whether u sorts before, equal to, or after other.
+func (u ULID) Compare(other ULID) int {
+	for i := range u {
+		switch {
+		case u[i] < other[i]:
+			return -1
+		case u[i] > other[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// IsZero reports whether u is the zero ULID.
+func (u ULID) IsZero() bool {
+	return u == ULID{}
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical form.
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+
+	return nil
+}
+
+func (u *ULID) setTimestamp(ms uint64) {
+	u[0], u[1], u[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	u[3], u[4], u[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+}
+
+// incrementRandom adds one to the 80-bit random part. It returns false on overflow.
+func (u *ULID) incrementRandom() bool {
+	for i := len(u) - 1; i >= 6; i-- {
+		u[i]++
+		if u[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
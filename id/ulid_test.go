@@ -0,0 +1,131 @@
+package id
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestULIDStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		ulid ULID
+		str  string
+	}{
+		{
+			name: "zero",
+			ulid: ULID{},
+			str:  "00000000000000000000000000",
+		},
+		{
+			name: "max",
+			ulid: ULID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+			str:  "7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		},
+		{
+			name: "mixed",
+			ulid: ULID{0x01, 0x56, 0x3E, 0x3A, 0xB5, 0xD3, 0xD6, 0x76, 0x4C, 0x61, 0xEF, 0xB9, 0x93, 0x02, 0xBD, 0x5B},
+			str:  "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.str, tt.ulid.String(), tt.name)
+
+			parsed, err := ParseULID(tt.str)
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.ulid, parsed, tt.name)
+
+			lower, err := ParseULID(strings.ToLower(tt.str))
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.ulid, lower, tt.name)
+		})
+	}
+}
+
+func TestParseULIDErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "too short",
+			input: "01ARZ3NDEK",
+		},
+		{
+			name:  "bad character",
+			input: "01ARZ3NDEKTSV4RRFFQ69G5FAU",
+		},
+		{
+			name:  "overflow",
+			input: "80000000000000000000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseULID(tt.input)
+			assert.ErrorIs(t, err, ErrInvalidULID, tt.name)
+		})
+	}
+
+	assert.Panics(t, func() { MustParseULID("nope") })
+}
+
+func TestULIDTime(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 123_000_000, time.UTC)
+	u := NewULIDAt(at)
+
+	assert.Equal(t, uint64(at.UnixMilli()), u.Timestamp())
+	assert.True(t, at.Equal(u.Time()))
+	assert.Equal(t, uint64(0x01563E3AB5D3), MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV").Timestamp())
+}
+
+func TestNewULIDMonotonic(t *testing.T) {
+	ids := make([]ULID, 10000)
+	strs := make([]string, len(ids))
+	for i := range ids {
+		ids[i] = NewULID()
+		strs[i] = ids[i].String()
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].Compare(ids[i]) >= 0 {
+			t.Fatalf("ULID %d (%s) not after %s", i, ids[i], ids[i-1])
+		}
+	}
+	assert.True(t, sort.StringsAreSorted(strs), "string form sorts the same as bytes")
+	assert.Len(t, slices.Compact(slices.Clone(strs)), len(strs))
+
+	assert.WithinDuration(t, time.Now(), ids[0].Time(), time.Minute)
+}
+
+func TestULIDIncrementOverflow(t *testing.T) {
+	u := ULID{0, 0, 0, 0, 0, 1, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	assert.False(t, u.incrementRandom())
+
+	u = ULID{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xFF}
+	assert.True(t, u.incrementRandom())
+	assert.Equal(t, ULID{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0}, u)
+}
+
+func TestULIDText(t *testing.T) {
+	u := MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+
+	out, err := json.Marshal(map[string]ULID{"id": u})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`, string(out))
+
+	var decoded map[string]ULID
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, u, decoded["id"])
+	assert.Error(t, json.Unmarshal([]byte(`{"id":"bad"}`), &decoded))
+
+	assert.True(t, ULID{}.IsZero())
+	assert.False(t, u.IsZero())
+	assert.Equal(t, 0, u.Compare(u))
+}
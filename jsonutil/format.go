@@ -0,0 +1,221 @@
+// Package jsonutil provides helpers for working with raw JSON documents:
+// formatting, canonicalization, path lookups, patching, streaming, and
+// tolerant parsing. All of it is built on encoding/json.
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Pretty returns b re-indented with two spaces per level.
+// b must be valid JSON; key order and number formatting are preserved.
+func Pretty(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, "", "  "); err != nil {
+		return nil, fmt.Errorf("jsonutil: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// Compact returns b with all insignificant whitespace removed.
+// b must be valid JSON; key order and number formatting are preserved.
+func Compact(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Compact(&out, b); err != nil {
+		return nil, fmt.Errorf("jsonutil: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// Canonicalize returns a deterministic encoding of b, so that documents that
+// mean the same thing produce identical bytes for diffs, hashes, and golden files.
+//
+// The output is compact, object keys are sorted by their UTF-16 code units, and
+// numbers are written in the shortest form that round-trips through float64,
+// following the JSON Canonicalization Scheme (RFC 8785):
+//
+//	{"b": 1.0, "a": [1E2, "x"]} => {"a":[100,"x"],"b":1}
+//
+// Duplicate object keys are rejected since their meaning is ambiguous.
+func Canonicalize(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := canonicalValue(dec, &buf); err != nil {
+		return nil, fmt.Errorf("jsonutil: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("jsonutil: unexpected data after top-level value")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func canonicalValue(dec *json.Decoder, buf *bytes.Buffer) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			return canonicalArray(dec, buf)
+		}
+		return canonicalObject(dec, buf)
+	case json.Number:
+		return canonicalNumber(t, buf)
+	case string:
+		writeString(buf, t)
+	case bool:
+		buf.WriteString(strconv.FormatBool(t))
+	case nil:
+		buf.WriteString("null")
+	}
+
+	return nil
+}
+
+func canonicalArray(dec *json.Decoder, buf *bytes.Buffer) error {
+	buf.WriteByte('[')
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := canonicalValue(dec, buf); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	_, err := dec.Token() // ']'
+
+	return err
+}
+
+func canonicalObject(dec *json.Decoder, buf *bytes.Buffer) error {
+	type member struct {
+		key   string
+		units []uint16 // key as UTF-16, the sort order RFC 8785 requires
+		value []byte
+	}
+
+	var members []member
+	seen := map[string]bool{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		if seen[key] {
+			return fmt.Errorf("duplicate object key %q", key)
+		}
+		seen[key] = true
+
+		var value bytes.Buffer
+		if err := canonicalValue(dec, &value); err != nil {
+			return err
+		}
+		members = append(members, member{key: key, units: utf16.Encode([]rune(key)), value: value.Bytes()})
+	}
+	if _, err := dec.Token(); err != nil { // '}'
+		return err
+	}
+
+	// Keys are unique, so no two members compare equal and the order is total.
+	slices.SortFunc(members, func(a, b member) int {
+		return slices.Compare(a.units, b.units)
+	})
+
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeString(buf, m.key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// canonicalNumber writes n in the ECMAScript Number.toString form RFC 8785 requires.
+func canonicalNumber(n json.Number, buf *bytes.Buffer) error {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil || math.IsInf(f, 0) {
+		return fmt.Errorf("number %s out of range", n)
+	}
+	if f == 0 {
+		buf.WriteByte('0') // also covers -0
+		return nil
+	}
+
+	abs := math.Abs(f)
+	if abs >= 1e21 || abs < 1e-6 {
+		// Exponent form: keep the sign but strip the leading zero strconv pads the
+		// exponent with ("1e-07" => "1e-7"); "1e+21" is already in ECMAScript form.
+		s := strconv.FormatFloat(f, 'e', -1, 64)
+		mantissa, exp, _ := bytes.Cut([]byte(s), []byte("e"))
+		buf.Write(mantissa)
+		buf.WriteByte('e')
+		sign, digits := exp[0], bytes.TrimLeft(exp[1:], "0")
+		buf.WriteByte(sign)
+		buf.Write(digits)
+		return nil
+	}
+	buf.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+
+	return nil
+}
+
+// writeString writes s as a JSON string the way RFC 8785 requires: only '"',
+// '\' and control characters below U+0020 are escaped, using the short forms
+// where JSON has them. Everything else, including '<', '&', U+2028 and U+2029
+// that encoding/json always escapes, is written literally.
+func writeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		buf.WriteString(s[start:i])
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[c>>4])
+			buf.WriteByte(hex[c&0xF])
+		}
+		start = i + 1
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
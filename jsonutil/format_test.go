@@ -0,0 +1,148 @@
+package jsonutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPretty(t *testing.T) {
+	out, err := Pretty([]byte(`{"b":1,"a":[1,2]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"b\": 1,\n  \"a\": [\n    1,\n    2\n  ]\n}", string(out))
+
+	_, err = Pretty([]byte(`{"a":`))
+	assert.Error(t, err)
+}
+
+func TestCompact(t *testing.T) {
+	out, err := Compact([]byte("{\n  \"b\": 1.0,\n  \"a\": [ 1, 2 ]\n}"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":1.0,"a":[1,2]}`, string(out))
+
+	_, err = Compact([]byte(`nope`))
+	assert.Error(t, err)
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "sorted keys",
+			input:    `{"b": 1, "a": {"d": true, "c": null}}`,
+			expected: `{"a":{"c":null,"d":true},"b":1}`,
+		},
+		{
+			name:     "numbers",
+			input:    `[1.0, 1E2, -0, 0.5, 1e21, 1e-7, 123456789012345680000, 3.14159]`,
+			expected: `[1,100,0,0.5,1e+21,1e-7,123456789012345680000,3.14159]`,
+		},
+		{
+			name:     "strings not html escaped",
+			input:    `"<a&b>é\n"`,
+			expected: `"<a&b>é\n"`,
+		},
+		{
+			name:     "utf16 key order",
+			input:    `{"\ufb33": 1, "\ud83d\ude00": 2}`,
+			expected: "{\"\U0001F600\":2,\"\uFB33\":1}",
+		},
+		{
+			name:     "non-BMP keys sharing a high surrogate",
+			input:    `{"\ud83d\ude01": 1, "\ud83d\ude00": 2}`,
+			expected: "{\"\U0001F600\":2,\"\U0001F601\":1}",
+		},
+		{
+			name:     "line and paragraph separators written literally",
+			input:    `"a\u2028b\u2029c"`,
+			expected: "\"a\u2028b\u2029c\"",
+		},
+		{
+			name:     "control characters",
+			input:    `"\"\\\/\b\f\n\r\t\u0001\u001f\u007f"`,
+			expected: "\"\\\"\\\\/\\b\\f\\n\\r\\t\\u0001\\u001f\u007f\"",
+		},
+		{
+			name:     "scalar",
+			input:    ` true `,
+			expected: `true`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Canonicalize([]byte(tt.input))
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, string(out), tt.name)
+		})
+	}
+}
+
+func TestCanonicalizeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "duplicate key",
+			input: `{"a":1,"a":2}`,
+		},
+		{
+			name:  "invalid",
+			input: `{"a":}`,
+		},
+		{
+			name:  "trailing data",
+			input: `{} {}`,
+		},
+		{
+			name:  "trailing garbage",
+			input: `{} x`,
+		},
+		{
+			name:  "trailing garbage without space",
+			input: `{}garbage`,
+		},
+		{
+			name:  "stray closing brace",
+			input: `{} }`,
+		},
+		{
+			name:  "stray closing brackets",
+			input: `[1] ]]`,
+		},
+		{
+			name:  "number overflow",
+			input: `1e400`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Canonicalize([]byte(tt.input))
+			assert.Error(t, err, tt.name)
+		})
+	}
+}
+
+func TestCanonicalizeIsStable(t *testing.T) {
+	a, err := Canonicalize([]byte(`{"x":[1,2,{"z":1,"y":2}],"w":"v"}`))
+	assert.NoError(t, err)
+	b, err := Canonicalize([]byte("{\"w\":\"v\",\n\"x\":[1.0,2e0,{\"y\":2,\"z\":1}]}"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(a), string(b))
+
+	// Keys in the same plane must still sort the same way whatever the input order.
+	x, err := Canonicalize([]byte(`{"😁":1,"😀":2,"a":3}`))
+	assert.NoError(t, err)
+	y, err := Canonicalize([]byte(`{"😀":2,"a":3,"😁":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":3,"😀":2,"😁":1}`, string(x))
+	assert.Equal(t, string(x), string(y))
+
+	again, err := Canonicalize(a)
+	assert.NoError(t, err)
+	assert.Equal(t, string(a), string(again))
+}
@@ -0,0 +1,101 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// StripJSONC returns a copy of b with comments and trailing commas removed, so
+// that hand-edited "JSON with comments" can be fed to encoding/json.
+//
+// Both // line comments and /* block */ comments are supported, as are commas
+// directly before a closing ] or }. Everything inside string literals is left
+// alone. Removed bytes are replaced with spaces and newlines are kept, so byte
+// offsets and line numbers in later syntax errors still point into the original
+// input. Anything else, valid or not, is passed through unchanged.
+func StripJSONC(b []byte) []byte {
+	out := make([]byte, len(b))
+	copy(out, b)
+
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if out[i] != '\n' && out[i] != '\r' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	// First pass: comments.
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"':
+			i = skipString(out, i)
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			end := i
+			for end < len(out) && out[end] != '\n' {
+				end++
+			}
+			blank(i, end)
+			i = end
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := len(out)
+			if n := bytes.Index(out[i+2:], []byte("*/")); n >= 0 {
+				end = i + 2 + n + 2
+			}
+			blank(i, end)
+			i = end - 1
+		}
+	}
+
+	// Second pass: trailing commas, now that comments can't hide them.
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			i = skipString(out, i)
+		case ',':
+			j := i + 1
+			for j < len(out) && isSpace(out[j]) {
+				j++
+			}
+			if j < len(out) && (out[j] == ']' || out[j] == '}') {
+				out[i] = ' '
+			}
+		}
+	}
+
+	return out
+}
+
+// UnmarshalLenient is like json.Unmarshal but first removes comments and
+// trailing commas with StripJSONC.
+//
+// Example:
+//
+//	var cfg Config
+//	err := jsonutil.UnmarshalLenient([]byte(`{
+//		// listen address
+//		"addr": ":8080",
+//		"tags": ["a", "b",],
+//	}`), &cfg)
+func UnmarshalLenient(b []byte, v any) error {
+	return json.Unmarshal(StripJSONC(b), v)
+}
+
+// skipString returns the index of the quote closing the string starting at b[i],
+// or the last index if the string is unterminated.
+func skipString(b []byte, i int) int {
+	for i++; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return len(b) - 1
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
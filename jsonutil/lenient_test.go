@@ -0,0 +1,83 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripJSONC(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "line comment",
+			input:    "{\"a\": 1 // one\n}",
+			expected: "{\"a\": 1       \n}",
+		},
+		{
+			name:     "block comment",
+			input:    "[1, /* two\nlines */ 2]",
+			expected: "[1,       \n         2]",
+		},
+		{
+			name:     "trailing commas",
+			input:    `{"a": [1, 2,], "b": {"c": 3,},}`,
+			expected: `{"a": [1, 2 ], "b": {"c": 3 } }`,
+		},
+		{
+			name:     "comma before comment",
+			input:    "[1, // last\n]",
+			expected: "[1         \n]",
+		},
+		{
+			name:     "strings untouched",
+			input:    `{"url": "http://x/*y*/", "s": "a,]", "q": "\"//"}`,
+			expected: `{"url": "http://x/*y*/", "s": "a,]", "q": "\"//"}`,
+		},
+		{
+			name:     "unterminated block comment",
+			input:    "1 /* open",
+			expected: "1        ",
+		},
+		{
+			name:     "plain json",
+			input:    `{"a":[1,2]}`,
+			expected: `{"a":[1,2]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(StripJSONC([]byte(tt.input))), tt.name)
+		})
+	}
+}
+
+func TestStripJSONCDoesNotModifyInput(t *testing.T) {
+	in := []byte("[1,]")
+	StripJSONC(in)
+	assert.Equal(t, "[1,]", string(in))
+}
+
+func TestUnmarshalLenient(t *testing.T) {
+	var cfg struct {
+		Addr string   `json:"addr"`
+		Tags []string `json:"tags"`
+	}
+	err := UnmarshalLenient([]byte(`{
+		// listen address
+		"addr": ":8080", /* default */
+		"tags": ["a", "b",],
+	}`), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Addr)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+
+	err = UnmarshalLenient([]byte("{\n// c\n\"a\": x}"), &cfg)
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, int64(13), syntaxErr.Offset, "offsets match the original input")
+}
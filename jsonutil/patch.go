@@ -0,0 +1,495 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrTestFailed is returned (wrapped) by ApplyPatch when a "test" operation doesn't match.
+var ErrTestFailed = errors.New("test operation failed")
+
+// MergePatch applies an RFC 7386 JSON Merge Patch to doc and returns the result.
+//
+// Objects in patch are merged into doc recursively; a null member deletes the
+// key, and any non-object value replaces what was there:
+//
+//	MergePatch({"a":1,"b":{"c":2}}, {"a":null,"b":{"d":3}}) => {"b":{"c":2,"d":3}}
+//
+// Object keys in the output are sorted.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeAny(doc)
+	if err != nil {
+		return nil, fmt.Errorf("jsonutil: decoding document: %w", err)
+	}
+	p, err := decodeAny(patch)
+	if err != nil {
+		return nil, fmt.Errorf("jsonutil: decoding merge patch: %w", err)
+	}
+
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+
+	return t
+}
+
+// PatchOperation is one operation of an RFC 6902 JSON Patch.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch (a JSON array of operations) to doc.
+//
+// All six operations are supported: add, remove, replace, move, copy, and test.
+// Paths are RFC 6901 JSON Pointers, and "-" addresses the end of an array in add.
+// The patch is atomic: if any operation fails, including a failed test, an error
+// is returned and doc is left as it was. Object keys in the output are sorted.
+func ApplyPatch(doc, patch []byte) ([]byte, error) {
+	root, err := decodeAny(doc)
+	if err != nil {
+		return nil, fmt.Errorf("jsonutil: decoding document: %w", err)
+	}
+
+	var ops []PatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("jsonutil: decoding patch: %w", err)
+	}
+
+	for i, op := range ops {
+		if root, err = applyOp(root, op); err != nil {
+			return nil, fmt.Errorf("jsonutil: patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(root)
+}
+
+func applyOp(root any, op PatchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (any, error) {
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		return decodeAny(op.Value)
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return addAt(root, path, v)
+		case "replace":
+			return replaceAt(root, path, v)
+		}
+		current, err := getAt(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalJSON(current, v) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	case "remove":
+		root, _, err := removeAt(root, path)
+		return root, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		v, err := getAt(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "copy" {
+			return addAt(root, path, deepCopy(v))
+		}
+		if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		if root, _, err = removeAt(root, from); err != nil {
+			return nil, err
+		}
+		return addAt(root, path, v)
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+// CreatePatch returns an RFC 6902 JSON Patch that turns from into to.
+//
+// The patch uses only add, remove, and replace. Object members are compared
+// key by key and arrays index by index, so appending to or truncating an array
+// yields a minimal patch; inserting at the front does not. Operations are
+// ordered deterministically.
+func CreatePatch(from, to []byte) ([]byte, error) {
+	a, err := decodeAny(from)
+	if err != nil {
+		return nil, fmt.Errorf("jsonutil: decoding source document: %w", err)
+	}
+	b, err := decodeAny(to)
+	if err != nil {
+		return nil, fmt.Errorf("jsonutil: decoding target document: %w", err)
+	}
+
+	ops := []PatchOperation{}
+	if err := diff("", a, b, &ops); err != nil {
+		return nil, fmt.Errorf("jsonutil: %w", err)
+	}
+
+	return json.Marshal(ops)
+}
+
+func diff(path string, a, b any, ops *[]PatchOperation) error {
+	if equalJSON(a, b) {
+		return nil
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(av) {
+			child := path + "/" + escapePointer(k)
+			if bChild, ok := bv[k]; ok {
+				if err := diff(child, av[k], bChild, ops); err != nil {
+					return err
+				}
+				continue
+			}
+			*ops = append(*ops, PatchOperation{Op: "remove", Path: child})
+		}
+		for _, k := range sortedKeys(bv) {
+			if _, ok := av[k]; !ok {
+				if err := appendOp(ops, "add", path+"/"+escapePointer(k), bv[k]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		common := min(len(av), len(bv))
+		for i := 0; i < common; i++ {
+			if err := diff(path+"/"+strconv.Itoa(i), av[i], bv[i], ops); err != nil {
+				return err
+			}
+		}
+		// Remove from the end so earlier indexes stay valid.
+		for i := len(av) - 1; i >= common; i-- {
+			*ops = append(*ops, PatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := common; i < len(bv); i++ {
+			if err := appendOp(ops, "add", path+"/"+strconv.Itoa(i), bv[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return appendOp(ops, "replace", path, b)
+}
+
+func appendOp(ops *[]PatchOperation, op, path string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	*ops = append(*ops, PatchOperation{Op: op, Path: path, Value: raw})
+
+	return nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", p)
+	}
+
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+
+	return tokens, nil
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// arrayIndex parses an array reference token. allowEnd accepts "-" as len(arr).
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	idx, err := strconv.Atoi(token)
+	limit := length - 1
+	if allowEnd {
+		limit = length
+	}
+	if err != nil || idx > limit {
+		return 0, fmt.Errorf("array index %s out of range", token)
+	}
+
+	return idx, nil
+}
+
+func getAt(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			v, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			node = v
+		case []any:
+			idx, err := arrayIndex(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[idx]
+		default:
+			return nil, fmt.Errorf("cannot index into %T with %q", node, token)
+		}
+	}
+
+	return node, nil
+}
+
+// updateParent walks to the parent of path and lets fn replace it, rebuilding
+// the slices along the way since fn may change an array's length.
+func updateParent(node any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	child, err := getAt(node, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	newChild, err := updateParent(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		n[path[0]] = newChild
+	case []any:
+		idx, _ := arrayIndex(path[0], len(n), false)
+		n[idx] = newChild
+	}
+
+	return node, nil
+}
+
+func addAt(root any, path []string, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+
+	return updateParent(root, path, func(parent any, token string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[token] = v
+			return p, nil
+		case []any:
+			idx, err := arrayIndex(token, len(p), true)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(p, idx, v), nil
+		}
+		return nil, fmt.Errorf("cannot add %q to %T", token, parent)
+	})
+}
+
+func replaceAt(root any, path []string, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+
+	return updateParent(root, path, func(parent any, token string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			if _, ok := p[token]; !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			p[token] = v
+			return p, nil
+		case []any:
+			idx, err := arrayIndex(token, len(p), false)
+			if err != nil {
+				return nil, err
+			}
+			p[idx] = v
+			return p, nil
+		}
+		return nil, fmt.Errorf("cannot replace %q in %T", token, parent)
+	})
+}
+
+func removeAt(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the document root")
+	}
+
+	var removed any
+	root, err := updateParent(root, path, func(parent any, token string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			v, ok := p[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			removed = v
+			delete(p, token)
+			return p, nil
+		case []any:
+			idx, err := arrayIndex(token, len(p), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = p[idx]
+			return slices.Delete(p, idx, idx+1), nil
+		}
+		return nil, fmt.Errorf("cannot remove %q from %T", token, parent)
+	})
+
+	return root, removed, err
+}
+
+// decodeAny decodes b keeping numbers as json.Number, so they round-trip unchanged.
+func decodeAny(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+
+	return v, nil
+}
+
+// equalJSON compares decoded JSON values, treating numbers by value (1 == 1.0).
+func equalJSON(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			w, ok := bv[k]
+			if !ok || !equalJSON(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalJSON(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		af, errA := av.Float64()
+		bf, errB := bv.Float64()
+		return errA == nil && errB == nil && af == bf
+	}
+
+	return a == b
+}
+
+func deepCopy(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[k] = deepCopy(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(t))
+		for i, e := range t {
+			s[i] = deepCopy(e)
+		}
+		return s
+	}
+
+	return v
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,282 @@
+package jsonutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Test cases from RFC 7386, appendix A.
+	tests := []struct {
+		doc, patch, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.doc+" + "+tt.patch, func(t *testing.T) {
+			out, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(out))
+		})
+	}
+
+	_, err := MergePatch([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+	_, err = MergePatch([]byte(`{}`), []byte(`{`))
+	assert.Error(t, err)
+	_, err = MergePatch([]byte(`{} }`), []byte(`{}`))
+	assert.EqualError(t, err, "jsonutil: decoding document: unexpected data after top-level value")
+	_, err = MergePatch([]byte(`{}`), []byte(`{"a":1}garbage`))
+	assert.EqualError(t, err, "jsonutil: decoding merge patch: unexpected data after top-level value")
+}
+
+func TestMergePatchPreservesNumbers(t *testing.T) {
+	out, err := MergePatch([]byte(`{"big":12345678901234567890,"f":1.50}`), []byte(`{"x":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"big":12345678901234567890,"f":1.50,"x":1}`, string(out))
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "add member",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":"qux"}]`,
+			expected: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:     "add array element",
+			doc:      `{"foo":["bar","baz"]}`,
+			patch:    `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			expected: `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:     "append to array",
+			doc:      `{"foo":[1]}`,
+			patch:    `[{"op":"add","path":"/foo/-","value":[2]}]`,
+			expected: `{"foo":[1,[2]]}`,
+		},
+		{
+			name:     "add null value",
+			doc:      `{}`,
+			patch:    `[{"op":"add","path":"/n","value":null}]`,
+			expected: `{"n":null}`,
+		},
+		{
+			name:     "remove",
+			doc:      `{"baz":"qux","foo":["a","b","c"]}`,
+			patch:    `[{"op":"remove","path":"/baz"},{"op":"remove","path":"/foo/1"}]`,
+			expected: `{"foo":["a","c"]}`,
+		},
+		{
+			name:     "replace",
+			doc:      `{"baz":"qux","foo":"bar"}`,
+			patch:    `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			expected: `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:     "move",
+			doc:      `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:     "move array element",
+			doc:      `{"foo":["all","grass","cows","eat"]}`,
+			patch:    `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			expected: `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:     "copy is deep",
+			doc:      `{"a":{"b":1}}`,
+			patch:    `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`,
+			expected: `{"a":{"b":1},"c":{"b":2}}`,
+		},
+		{
+			name:     "test passes",
+			doc:      `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch:    `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`,
+			expected: `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			name:     "escaped pointer",
+			doc:      `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`,
+			expected: `{"a/b":3}`,
+		},
+		{
+			name:     "replace root",
+			doc:      `{"a":1}`,
+			patch:    `[{"op":"replace","path":"","value":[1]}]`,
+			expected: `[1]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := ApplyPatch([]byte(tt.doc), []byte(tt.patch))
+			assert.NoError(t, err, tt.name)
+			assert.JSONEq(t, tt.expected, string(out), tt.name)
+		})
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "test fails",
+			doc:      `{"baz":"qux"}`,
+			patch:    `[{"op":"test","path":"/baz","value":"bar"}]`,
+			expected: "jsonutil: patch operation 0 (test /baz): test operation failed",
+		},
+		{
+			name:     "remove missing",
+			doc:      `{}`,
+			patch:    `[{"op":"remove","path":"/a"}]`,
+			expected: `jsonutil: patch operation 0 (remove /a): member "a" not found`,
+		},
+		{
+			name:     "replace missing",
+			doc:      `{}`,
+			patch:    `[{"op":"replace","path":"/a","value":1}]`,
+			expected: `jsonutil: patch operation 0 (replace /a): member "a" not found`,
+		},
+		{
+			name:     "index out of range",
+			doc:      `[1]`,
+			patch:    `[{"op":"add","path":"/2","value":1}]`,
+			expected: "jsonutil: patch operation 0 (add /2): array index 2 out of range",
+		},
+		{
+			name:     "leading zero index",
+			doc:      `[1,2]`,
+			patch:    `[{"op":"remove","path":"/01"}]`,
+			expected: `jsonutil: patch operation 0 (remove /01): invalid array index "01"`,
+		},
+		{
+			name:     "missing value",
+			doc:      `{}`,
+			patch:    `[{"op":"add","path":"/a"}]`,
+			expected: "jsonutil: patch operation 0 (add /a): missing value",
+		},
+		{
+			name:     "unknown op",
+			doc:      `{}`,
+			patch:    `[{"op":"frobnicate","path":"/a"}]`,
+			expected: `jsonutil: patch operation 0 (frobnicate /a): unknown operation "frobnicate"`,
+		},
+		{
+			name:     "move into child",
+			doc:      `{"a":{"b":{}}}`,
+			patch:    `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			expected: "jsonutil: patch operation 0 (move /a/b/c): cannot move a value into one of its children",
+		},
+		{
+			name:     "bad pointer",
+			doc:      `{}`,
+			patch:    `[{"op":"add","path":"a","value":1}]`,
+			expected: `jsonutil: patch operation 0 (add a): invalid JSON pointer "a"`,
+		},
+		{
+			name:     "later operation fails",
+			doc:      `{"a":1}`,
+			patch:    `[{"op":"remove","path":"/a"},{"op":"remove","path":"/a"}]`,
+			expected: `jsonutil: patch operation 1 (remove /a): member "a" not found`,
+		},
+		{
+			name:     "stray closing bracket after document",
+			doc:      `[1] ]`,
+			patch:    `[]`,
+			expected: "jsonutil: decoding document: unexpected data after top-level value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyPatch([]byte(tt.doc), []byte(tt.patch))
+			assert.EqualError(t, err, tt.expected, tt.name)
+		})
+	}
+
+	_, err := ApplyPatch([]byte(`{}`), []byte(`{"op":"add"}`))
+	assert.ErrorContains(t, err, "decoding patch")
+}
+
+func TestCreatePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		expected string
+	}{
+		{
+			name:     "identical",
+			from:     `{"a":[1,{"b":2}]}`,
+			to:       `{"a":[1.0,{"b":2}]}`,
+			expected: `[]`,
+		},
+		{
+			name:     "object members",
+			from:     `{"a":1,"b":2,"c":{"d":3}}`,
+			to:       `{"b":2,"c":{"d":4},"e":null}`,
+			expected: `[{"op":"remove","path":"/a"},{"op":"replace","path":"/c/d","value":4},{"op":"add","path":"/e","value":null}]`,
+		},
+		{
+			name:     "array grow",
+			from:     `[1,2]`,
+			to:       `[1,3,4,5]`,
+			expected: `[{"op":"replace","path":"/1","value":3},{"op":"add","path":"/2","value":4},{"op":"add","path":"/3","value":5}]`,
+		},
+		{
+			name:     "array shrink",
+			from:     `[1,2,3]`,
+			to:       `[1]`,
+			expected: `[{"op":"remove","path":"/2"},{"op":"remove","path":"/1"}]`,
+		},
+		{
+			name:     "type change",
+			from:     `{"a":[1]}`,
+			to:       `{"a":{"0":1}}`,
+			expected: `[{"op":"replace","path":"/a","value":{"0":1}}]`,
+		},
+		{
+			name:     "escaped keys",
+			from:     `{}`,
+			to:       `{"a/b~":1}`,
+			expected: `[{"op":"add","path":"/a~1b~0","value":1}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := CreatePatch([]byte(tt.from), []byte(tt.to))
+			assert.NoError(t, err, tt.name)
+			assert.JSONEq(t, tt.expected, string(patch), tt.name)
+
+			out, err := ApplyPatch([]byte(tt.from), patch)
+			assert.NoError(t, err, tt.name)
+			assert.JSONEq(t, tt.to, string(out), tt.name)
+		})
+	}
+}
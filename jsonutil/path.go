@@ -0,0 +1,251 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned (wrapped) by Get when the path does not exist in the document.
+var ErrNotFound = errors.New("path not found")
+
+// Kind is the JSON type of a Value.
+type Kind int
+
+// JSON value kinds.
+const (
+	Invalid Kind = iota
+	Null
+	Bool
+	Number
+	String
+	Array
+	Object
+)
+
+var kindNames = [...]string{"invalid", "null", "bool", "number", "string", "array", "object"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+
+	return kindNames[k]
+}
+
+// Value is a raw JSON value extracted by Get, with typed accessors.
+type Value struct {
+	raw json.RawMessage
+}
+
+// Get returns the value at path in the JSON document b.
+//
+// A path is a list of object keys and array indexes separated by dots; "" is the
+// whole document. A literal dot in a key is written as "\.":
+//
+//	Get(b, "items.0.id")
+//	Get(b, `labels.app\.kubernetes\.io/name`)
+//
+// The document is scanned token by token and everything off the path is skipped
+// without being decoded, so pulling a couple of fields from a large payload is
+// much cheaper than unmarshaling it. Only the returned value is copied.
+//
+// A path that leads nowhere returns an error wrapping ErrNotFound.
+func Get(b []byte, path string) (Value, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	segments := splitPath(path)
+	for i, seg := range segments {
+		found, err := descend(dec, seg)
+		if err != nil {
+			return Value{}, fmt.Errorf("jsonutil: %w", err)
+		}
+		if !found {
+			return Value{}, fmt.Errorf("jsonutil: %w: %q", ErrNotFound, joinPath(segments[:i+1]))
+		}
+	}
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return Value{}, fmt.Errorf("jsonutil: %w", err)
+	}
+
+	return Value{raw: raw}, nil
+}
+
+// descend advances dec into the next value and positions it at the child named seg.
+func descend(dec *json.Decoder, seg string) (bool, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return false, err
+			}
+			if key == seg {
+				return true, nil
+			}
+			if err := skipValue(dec); err != nil {
+				return false, err
+			}
+		}
+	case json.Delim('['):
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 {
+			return false, nil
+		}
+		for i := 0; dec.More(); i++ {
+			if i == idx {
+				return true, nil
+			}
+			if err := skipValue(dec); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// skipValue consumes the next value, however deeply nested.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var (
+		segments []string
+		cur      strings.Builder
+	)
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			cur.WriteByte('.')
+			i++
+		case path[i] == '.':
+			segments = append(segments, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(path[i])
+		}
+	}
+
+	return append(segments, cur.String())
+}
+
+func joinPath(segments []string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = strings.ReplaceAll(s, ".", `\.`)
+	}
+
+	return strings.Join(escaped, ".")
+}
+
+// Raw returns the value's JSON encoding.
+func (v Value) Raw() json.RawMessage {
+	return v.raw
+}
+
+// Kind returns the JSON type of the value.
+func (v Value) Kind() Kind {
+	if len(v.raw) == 0 {
+		return Invalid
+	}
+
+	switch v.raw[0] {
+	case 'n':
+		return Null
+	case 't', 'f':
+		return Bool
+	case '"':
+		return String
+	case '[':
+		return Array
+	case '{':
+		return Object
+	}
+
+	return Number
+}
+
+// IsNull reports whether the value is JSON null.
+func (v Value) IsNull() bool {
+	return v.Kind() == Null
+}
+
+// AsString returns the value as a string. It fails unless the value is a JSON string.
+func (v Value) AsString() (string, error) {
+	var s string
+	return s, v.decodeKind(String, &s)
+}
+
+// AsInt returns the value as an int64. It fails unless the value is an integral number in range.
+func (v Value) AsInt() (int64, error) {
+	var n json.Number
+	if err := v.decodeKind(Number, &n); err != nil {
+		return 0, err
+	}
+	i, err := n.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("jsonutil: %s is not an int64", n)
+	}
+
+	return i, nil
+}
+
+// AsFloat returns the value as a float64. It fails unless the value is a number.
+func (v Value) AsFloat() (float64, error) {
+	var f float64
+	return f, v.decodeKind(Number, &f)
+}
+
+// AsBool returns the value as a bool. It fails unless the value is true or false.
+func (v Value) AsBool() (bool, error) {
+	var b bool
+	return b, v.decodeKind(Bool, &b)
+}
+
+// Decode unmarshals the value into target, like json.Unmarshal.
+func (v Value) Decode(target any) error {
+	return json.Unmarshal(v.raw, target)
+}
+
+func (v Value) decodeKind(want Kind, target any) error {
+	if got := v.Kind(); got != want {
+		return fmt.Errorf("jsonutil: value is %s, not %s", got, want)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(v.raw))
+	dec.UseNumber()
+
+	return dec.Decode(target)
+}
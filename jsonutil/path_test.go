@@ -0,0 +1,172 @@
+package jsonutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pathDoc = `{
+	"id": 7,
+	"name": "order",
+	"paid": true,
+	"note": null,
+	"total": 12.5,
+	"items": [
+		{"id": "a", "qty": 1, "tags": ["x", {"deep": [1, 2]}]},
+		{"id": "b", "qty": 2}
+	],
+	"labels": {"app.kubernetes.io/name": "shop", "0": "zero-key"}
+}`
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		kind     Kind
+	}{
+		{
+			name:     "whole document",
+			path:     "",
+			expected: "",
+			kind:     Object,
+		},
+		{
+			name:     "top level",
+			path:     "name",
+			expected: `"order"`,
+			kind:     String,
+		},
+		{
+			name:     "array index",
+			path:     "items.1.id",
+			expected: `"b"`,
+			kind:     String,
+		},
+		{
+			name:     "nested array",
+			path:     "items.0.tags.1.deep.1",
+			expected: `2`,
+			kind:     Number,
+		},
+		{
+			name:     "container",
+			path:     "items.1",
+			expected: `{"id": "b", "qty": 2}`,
+			kind:     Object,
+		},
+		{
+			name:     "escaped dot",
+			path:     `labels.app\.kubernetes\.io/name`,
+			expected: `"shop"`,
+			kind:     String,
+		},
+		{
+			name:     "numeric object key",
+			path:     "labels.0",
+			expected: `"zero-key"`,
+			kind:     String,
+		},
+		{
+			name:     "null",
+			path:     "note",
+			expected: `null`,
+			kind:     Null,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Get([]byte(pathDoc), tt.path)
+			assert.NoError(t, err, tt.name)
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, string(v.Raw()), tt.name)
+			}
+			assert.Equal(t, tt.kind, v.Kind(), tt.name)
+		})
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "missing key",
+			path:     "items.0.price",
+			expected: `jsonutil: path not found: "items.0.price"`,
+		},
+		{
+			name:     "index out of range",
+			path:     "items.5.id",
+			expected: `jsonutil: path not found: "items.5"`,
+		},
+		{
+			name:     "non numeric index",
+			path:     "items.first",
+			expected: `jsonutil: path not found: "items.first"`,
+		},
+		{
+			name:     "into scalar",
+			path:     "name.first",
+			expected: `jsonutil: path not found: "name.first"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get([]byte(pathDoc), tt.path)
+			assert.ErrorIs(t, err, ErrNotFound, tt.name)
+			assert.EqualError(t, err, tt.expected, tt.name)
+		})
+	}
+
+	_, err := Get([]byte(`{"a": [1, `), "a.3")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestValueAccessors(t *testing.T) {
+	get := func(path string) Value {
+		v, err := Get([]byte(pathDoc), path)
+		assert.NoError(t, err)
+		return v
+	}
+
+	s, err := get("name").AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "order", s)
+
+	n, err := get("id").AsInt()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	_, err = get("total").AsInt()
+	assert.EqualError(t, err, "jsonutil: 12.5 is not an int64")
+
+	f, err := get("total").AsFloat()
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, f)
+
+	b, err := get("paid").AsBool()
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	assert.True(t, get("note").IsNull())
+
+	_, err = get("id").AsString()
+	assert.EqualError(t, err, "jsonutil: value is number, not string")
+
+	var item struct {
+		ID  string `json:"id"`
+		Qty int    `json:"qty"`
+	}
+	assert.NoError(t, get("items.1").Decode(&item))
+	assert.Equal(t, "b", item.ID)
+	assert.Equal(t, 2, item.Qty)
+
+	assert.Equal(t, Invalid, Value{}.Kind())
+	assert.Equal(t, "object", Object.String())
+	assert.Equal(t, "Kind(42)", Kind(42).String())
+}
@@ -0,0 +1,403 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RedactedValue replaces the value of every redacted field in MarshalRedacted output.
+const RedactedValue = "******"
+
+// maxRedactDepth guards against reference cycles, which json.Marshal would also reject.
+const maxRedactDepth = 1000
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// MarshalRedacted encodes v like json.Marshal, but replaces the values of
+// sensitive fields with RedactedValue so the output is safe to log.
+//
+// A struct field is redacted when it is tagged `redact:"true"` or when its JSON
+// name matches one of names; map entries are redacted when their key matches one
+// of names. Names are compared case-insensitively. Nested structs, maps, slices,
+// and pointers are walked at any depth. Struct field names, the "-", omitempty
+// and omitzero tag options, and embedded structs follow encoding/json.
+//
+// Types implementing json.Marshaler or encoding.TextMarshaler are encoded by
+// their own methods and are not looked into.
+//
+// Example:
+//
+//	type Login struct {
+//		User     string            `json:"user"`
+//		Password string            `json:"password" redact:"true"`
+//		Headers  map[string]string `json:"headers"`
+//	}
+//	jsonutil.MarshalRedacted(req, "authorization")
+//	=> {"user":"bob","password":"******","headers":{"Authorization":"******"}}
+func MarshalRedacted(v any, names ...string) ([]byte, error) {
+	r := redactor{names: make(map[string]bool, len(names))}
+	for _, n := range names {
+		r.names[strings.ToLower(n)] = true
+	}
+
+	var buf bytes.Buffer
+	if err := r.encode(&buf, reflect.ValueOf(v), 0); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type redactor struct {
+	names map[string]bool
+}
+
+func (r *redactor) redacted(name string) bool {
+	return r.names[strings.ToLower(name)]
+}
+
+func (r *redactor) encode(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if depth > maxRedactDepth {
+		return errors.New("jsonutil: value nested too deeply or contains a cycle")
+	}
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if implementsMarshaler(v) {
+		return r.leaf(buf, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return r.encode(buf, v.Elem(), depth+1)
+	case reflect.Struct:
+		return r.encodeStruct(buf, v, depth)
+	case reflect.Map:
+		return r.encodeMap(buf, v, depth)
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return r.leaf(buf, v)
+		}
+		fallthrough
+	case reflect.Array:
+		buf.WriteByte('[')
+		for i := range v.Len() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := r.encode(buf, v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	return r.leaf(buf, v)
+}
+
+func (r *redactor) leaf(buf *bytes.Buffer, v reflect.Value) error {
+	// Marshal through a pointer where possible so pointer-receiver methods are
+	// found, as they would be by encoding/json for addressable values.
+	if v.CanAddr() && v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+		v = v.Addr()
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
+
+func (r *redactor) encodeStruct(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	buf.WriteByte('{')
+	first := true
+	for _, f := range structFields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) || (f.omitZero && isZeroValue(fv)) {
+			continue
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeString(buf, f.name)
+		buf.WriteByte(':')
+
+		if f.redact || r.redacted(f.name) {
+			writeString(buf, RedactedValue)
+			continue
+		}
+		if err := r.encode(buf, fv, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func (r *redactor) encodeMap(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+
+	entries := make(map[string]reflect.Value, v.Len())
+	for it := v.MapRange(); it.Next(); {
+		k, err := mapKey(it.Key())
+		if err != nil {
+			return err
+		}
+		entries[k] = it.Value()
+	}
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeString(buf, k)
+		buf.WriteByte(':')
+
+		if r.redacted(k) {
+			writeString(buf, RedactedValue)
+			continue
+		}
+		if err := r.encode(buf, entries[k], depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// mapKey converts a map key to an object key following encoding/json's rules.
+func mapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("jsonutil: unsupported map key type %s", k.Type())
+}
+
+// implementsMarshaler reports whether v encodes itself, either directly or through
+// a pointer receiver that encoding/json would also use.
+func implementsMarshaler(v reflect.Value) bool {
+	t := v.Type()
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return false
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return true
+	}
+	if v.CanAddr() {
+		pt := reflect.PointerTo(t)
+		return pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType)
+	}
+	return false
+}
+
+type fieldCandidate struct {
+	redactField
+	depth  int
+	tagged bool
+}
+
+// dominantField picks the field that wins among fields sharing a name: the
+// shallowest one, or the only tagged one among the shallowest.
+func dominantField(fields []fieldCandidate) (fieldCandidate, bool) {
+	minDepth := fields[0].depth
+	for _, f := range fields[1:] {
+		minDepth = min(minDepth, f.depth)
+	}
+
+	var shallow, tagged []fieldCandidate
+	for _, f := range fields {
+		if f.depth == minDepth {
+			shallow = append(shallow, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+
+	switch {
+	case len(shallow) == 1:
+		return shallow[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
+
+	return fieldCandidate{}, false
+}
+
+type redactField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	omitZero  bool
+	redact    bool
+}
+
+// structFields lists the JSON fields of t in encoding order, promoting the fields
+// of embedded structs. Like encoding/json, a shallower field hides deeper fields
+// with the same name, and ambiguous names at the same depth are dropped unless
+// exactly one of them is tagged.
+func structFields(t reflect.Type) []redactField {
+	var (
+		all  []fieldCandidate
+		walk func(t reflect.Type, index []int, depth int, seen map[reflect.Type]bool)
+	)
+	walk = func(t reflect.Type, index []int, depth int, seen map[reflect.Type]bool) {
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+
+			ft := sf.Type
+			if sf.Anonymous {
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if !sf.IsExported() && ft.Kind() != reflect.Struct {
+					continue
+				}
+			} else if !sf.IsExported() {
+				continue
+			}
+
+			idx := append(append([]int(nil), index...), i)
+			if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				walk(ft, idx, depth+1, seen)
+				continue
+			}
+
+			f := fieldCandidate{depth: depth, tagged: name != ""}
+			f.name = name
+			if f.name == "" {
+				f.name = sf.Name
+			}
+			f.index = idx
+			f.redact = sf.Tag.Get("redact") == "true"
+			for _, o := range strings.Split(opts, ",") {
+				switch o {
+				case "omitempty":
+					f.omitEmpty = true
+				case "omitzero":
+					f.omitZero = true
+				}
+			}
+			all = append(all, f)
+		}
+	}
+	walk(t, nil, 0, map[reflect.Type]bool{})
+
+	// Resolve name conflicts the way encoding/json does.
+	byName := make(map[string][]fieldCandidate)
+	for _, f := range all {
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	var fields []redactField
+	for _, f := range all {
+		if winner, ok := dominantField(byName[f.name]); ok && slices.Equal(winner.index, f.index) {
+			fields = append(fields, f.redactField)
+		}
+	}
+
+	return fields
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead of
+// panicking when it runs into a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// isZeroValue reports whether v is zero for omitzero, preferring an IsZero method.
+func isZeroValue(v reflect.Value) bool {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return true
+		}
+		return z.IsZero()
+	}
+	if v.CanAddr() {
+		if z, ok := v.Addr().Interface().(interface{ IsZero() bool }); ok {
+			return z.IsZero()
+		}
+	}
+
+	return v.IsZero()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+
+	return false
+}
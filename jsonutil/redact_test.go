@@ -0,0 +1,163 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type redactCredentials struct {
+	User     string `json:"user"`
+	Password string `json:"password" redact:"true"`
+}
+
+type redactBase struct {
+	ID     int    `json:"id"`
+	Secret string `json:"secret" redact:"true"`
+}
+
+type redactRequest struct {
+	redactBase
+	Method  string              `json:"method"`
+	Auth    *redactCredentials  `json:"auth,omitempty"`
+	Headers map[string][]string `json:"headers"`
+	Tokens  []redactCredentials `json:"tokens,omitempty"`
+	At      time.Time           `json:"at"`
+	Skipped string              `json:"-"`
+	Nothing *int                `json:"nothing,omitzero"`
+	private string
+}
+
+func TestMarshalRedacted(t *testing.T) {
+	req := redactRequest{
+		redactBase: redactBase{ID: 7, Secret: "s"},
+		Method:     "POST",
+		Auth:       &redactCredentials{User: "bob", Password: "hunter2"},
+		Headers: map[string][]string{
+			"Authorization": {"Bearer abc"},
+			"Accept":        {"*/*"},
+		},
+		Tokens:  []redactCredentials{{User: "svc", Password: "x"}},
+		At:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Skipped: "skipped",
+		private: "private",
+	}
+
+	out, err := MarshalRedacted(req, "authorization")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":7,"secret":"******","method":"POST",`+
+		`"auth":{"user":"bob","password":"******"},`+
+		`"headers":{"Accept":["*/*"],"Authorization":"******"},`+
+		`"tokens":[{"user":"svc","password":"******"}],`+
+		`"at":"2024-01-02T03:04:05Z"}`, string(out))
+
+	ptrOut, err := MarshalRedacted(&req, "AUTHORIZATION")
+	assert.NoError(t, err)
+	assert.Equal(t, string(out), string(ptrOut), "pointer and case-insensitive names")
+}
+
+func TestMarshalRedactedByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		names    []string
+		expected string
+	}{
+		{
+			name:     "struct field by json name",
+			value:    struct{ User, Token string }{"bob", "abc"},
+			names:    []string{"token"},
+			expected: `{"User":"bob","Token":"******"}`,
+		},
+		{
+			name:     "nested maps",
+			value:    map[string]any{"a": map[string]any{"api_key": 1, "b": []any{map[string]any{"api_key": nil}}}},
+			names:    []string{"api_key"},
+			expected: `{"a":{"api_key":"******","b":[{"api_key":"******"}]}}`,
+		},
+		{
+			name:     "integer map keys",
+			value:    map[int]string{2: "b", 1: "a"},
+			expected: `{"1":"a","2":"b"}`,
+		},
+		{
+			name:     "no names matches json.Marshal",
+			value:    []any{1, "x", nil, true, []byte("hi"), 1.5},
+			expected: `[1,"x",null,true,"aGk=",1.5]`,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: `null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := MarshalRedacted(tt.value, tt.names...)
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, string(out), tt.name)
+		})
+	}
+}
+
+type redactOuter struct {
+	redactInnerA
+	redactInnerB
+	Name string `json:"name"`
+}
+
+type redactInnerA struct {
+	Name  string `json:"name"`
+	Dup   string
+	Label string `json:"label"`
+}
+
+type redactInnerB struct {
+	Dup   string
+	Label string
+}
+
+func TestMarshalRedactedMatchesEncodingJSON(t *testing.T) {
+	values := []any{
+		redactOuter{
+			redactInnerA: redactInnerA{Name: "inner", Dup: "a", Label: "tagged"},
+			redactInnerB: redactInnerB{Dup: "b", Label: "untagged"},
+			Name:         "outer",
+		},
+		struct {
+			A int     `json:",omitempty"`
+			B string  `json:"b,omitempty"`
+			C []int   `json:"c,omitempty"`
+			D float64 `json:"d"`
+		}{D: 2},
+		map[string]any{"z": 1, "a": []int{1, 2}, "m": map[string]int{}},
+	}
+
+	for _, v := range values {
+		want, err := json.Marshal(v)
+		assert.NoError(t, err)
+
+		got, err := MarshalRedacted(v)
+		assert.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	}
+}
+
+type redactCycle struct {
+	Next *redactCycle
+}
+
+func TestMarshalRedactedErrors(t *testing.T) {
+	c := &redactCycle{}
+	c.Next = c
+	_, err := MarshalRedacted(c)
+	assert.Error(t, err)
+
+	_, err = MarshalRedacted(map[float64]int{1: 1})
+	assert.EqualError(t, err, "jsonutil: unsupported map key type float64")
+
+	_, err = MarshalRedacted(func() {})
+	assert.Error(t, err)
+}
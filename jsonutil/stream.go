@@ -0,0 +1,56 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrStop can be returned by the callback passed to StreamArray to stop reading
+// early. StreamArray then returns nil.
+var ErrStop = errors.New("stop streaming")
+
+// StreamArray decodes the top-level JSON array read from r one element at a time
+// and calls fn for each of them in order.
+//
+// Only one element is held in memory at a time, so it can walk arrays far larger
+// than json.Unmarshal could handle. Reading stops at the first decoding error or
+// at the first error returned by fn, which is returned as is; returning ErrStop
+// stops without an error. Anything after the closing bracket is not read.
+//
+// Example:
+//
+//	err := jsonutil.StreamArray(f, func(u User) error {
+//		return db.Insert(u)
+//	})
+func StreamArray[T any](r io.Reader, fn func(T) error) error {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("jsonutil: %w", err)
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("jsonutil: expected array, got %v", tok)
+	}
+
+	for i := 0; dec.More(); i++ {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("jsonutil: element %d: %w", i, err)
+		}
+		if err := fn(v); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("jsonutil: %w", err)
+	}
+
+	return nil
+}
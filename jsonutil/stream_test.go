@@ -0,0 +1,130 @@
+package jsonutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type streamItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestStreamArray(t *testing.T) {
+	input := `[{"id":1,"name":"a"}, {"id":2,"name":"b"},
+		{"id":3,"name":"c"}]`
+
+	var got []streamItem
+	err := StreamArray(strings.NewReader(input), func(it streamItem) error {
+		got = append(got, it)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []streamItem{{1, "a"}, {2, "b"}, {3, "c"}}, got)
+
+	calls := 0
+	err = StreamArray(strings.NewReader(`[]`), func(int) error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, calls)
+}
+
+func TestStreamArrayStop(t *testing.T) {
+	var got []int
+	err := StreamArray(strings.NewReader(`[1,2,3,4`), func(n int) error {
+		got = append(got, n)
+		if n == 2 {
+			return ErrStop
+		}
+		return nil
+	})
+	assert.NoError(t, err, "input after the stop is not read")
+	assert.Equal(t, []int{1, 2}, got)
+
+	boom := errors.New("boom")
+	err = StreamArray(strings.NewReader(`[1,2,3]`), func(n int) error {
+		if n == 2 {
+			return boom
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestStreamArrayErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "not an array",
+			input:    `{"id":1}`,
+			expected: "jsonutil: expected array, got {",
+		},
+		{
+			name:     "wrong element type",
+			input:    `[1,"two"]`,
+			expected: "jsonutil: element 1: json: cannot unmarshal string into Go value of type int",
+		},
+		{
+			name:     "empty input",
+			input:    ``,
+			expected: "jsonutil: EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StreamArray(strings.NewReader(tt.input), func(int) error { return nil })
+			assert.EqualError(t, err, tt.expected, tt.name)
+		})
+	}
+
+	err := StreamArray(strings.NewReader(`[1,2`), func(int) error { return nil })
+	assert.Error(t, err, "truncated input")
+}
+
+// arrayReader generates a large array on the fly without ever holding it in memory.
+type arrayReader struct {
+	n, next int
+	buf     []byte
+}
+
+func (r *arrayReader) Read(p []byte) (int, error) {
+	for len(r.buf) < len(p) && r.next <= r.n {
+		switch {
+		case r.next == 0:
+			r.buf = append(r.buf, '[')
+		case r.next == r.n:
+			r.buf = fmt.Appendf(r.buf, `{"id":%d}]`, r.next)
+		default:
+			r.buf = fmt.Appendf(r.buf, `{"id":%d},`, r.next)
+		}
+		r.next++
+	}
+	if len(r.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func TestStreamArrayLarge(t *testing.T) {
+	r := &arrayReader{n: 100000}
+
+	count := 0
+	err := StreamArray(r, func(it streamItem) error {
+		count++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 100000, count)
+}
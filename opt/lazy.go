@@ -0,0 +1,115 @@
+package opt
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Lazy computes a value on first use and then caches it.
+//
+// It replaces the sync.Once-plus-globals pattern for expensive clients:
+//
+//	var db = opt.NewLazy(func() (*sql.DB, error) {
+//		return sql.Open("postgres", dsn)
+//	})
+//
+//	func handler(...) {
+//		conn, err := db.Get()
+//		...
+//	}
+//
+// Unlike sync.Once, a failed initialization is not final: by default the next
+// Get tries again. RetryAfter and CacheErrors change that policy. Concurrent
+// callers of Get wait for a single in-flight initialization rather than each
+// running init.
+//
+// A Lazy must not be copied after first use.
+type Lazy[T any] struct {
+	init       func() (T, error)
+	retryAfter time.Duration // 0: retry on every Get, <0: never retry
+
+	// value is published once init succeeds, so Get can return it without
+	// taking mu; Reset stores nil. err and failedAt are guarded by mu.
+	value    atomic.Pointer[T]
+	mu       sync.Mutex
+	err      error
+	failedAt time.Time
+}
+
+// NewLazy returns a Lazy that calls init on first use.
+func NewLazy[T any](init func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+// RetryAfter makes Get return the last error, without calling init again,
+// until d has passed since the failure. It returns l for chaining and must be
+// called before the first Get.
+func (l *Lazy[T]) RetryAfter(d time.Duration) *Lazy[T] {
+	l.retryAfter = d
+	return l
+}
+
+// CacheErrors makes a failed initialization final, like sync.Once: every later
+// Get returns the same error until Reset is called. It returns l for chaining
+// and must be called before the first Get.
+func (l *Lazy[T]) CacheErrors() *Lazy[T] {
+	l.retryAfter = -1
+	return l
+}
+
+// Get returns the value, initializing it if needed. It is safe for concurrent use.
+func (l *Lazy[T]) Get() (T, error) {
+	if p := l.value.Load(); p != nil {
+		return *p, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if p := l.value.Load(); p != nil {
+		return *p, nil
+	}
+	if l.err != nil && (l.retryAfter < 0 || time.Since(l.failedAt) < l.retryAfter) {
+		var zero T
+		return zero, l.err
+	}
+
+	v, err := l.init()
+	if err != nil {
+		l.err, l.failedAt = err, time.Now()
+		var zero T
+		return zero, err
+	}
+
+	l.err = nil
+	l.value.Store(&v)
+
+	return v, nil
+}
+
+// MustGet is like Get but panics if initialization fails.
+func (l *Lazy[T]) MustGet() T {
+	v, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// Initialized reports whether the value has been computed successfully.
+func (l *Lazy[T]) Initialized() bool {
+	return l.value.Load() != nil
+}
+
+// Reset discards the cached value or error, so the next Get calls init again.
+// Callers still holding the old value are unaffected; closing it, if needed,
+// is up to them.
+func (l *Lazy[T]) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.value.Store(nil)
+	l.err, l.failedAt = nil, time.Time{}
+}
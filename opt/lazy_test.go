@@ -0,0 +1,140 @@
+package opt
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	l := NewLazy(func() (string, error) {
+		calls++
+		return "client", nil
+	})
+	assert.False(t, l.Initialized())
+
+	for i := 0; i < 3; i++ {
+		v, err := l.Get()
+		assert.NoError(t, err)
+		assert.Equal(t, "client", v)
+	}
+	assert.Equal(t, 1, calls)
+	assert.True(t, l.Initialized())
+
+	l.Reset()
+	assert.False(t, l.Initialized())
+	assert.Equal(t, "client", l.MustGet())
+	assert.Equal(t, 2, calls)
+}
+
+func TestLazyConcurrentInitOnce(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func() (int, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 42, l.MustGet())
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestLazyConcurrentGetAndReset(t *testing.T) {
+	type conn struct{ id int }
+
+	var calls atomic.Int32
+	l := NewLazy(func() (*conn, error) {
+		return &conn{id: int(calls.Add(1))}, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				c, err := l.Get()
+				if !assert.NoError(t, err) || !assert.NotNil(t, c, "never a zero value without an error") {
+					return
+				}
+				assert.Positive(t, c.id)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Reset()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, calls.Load(), int32(1))
+}
+
+func TestLazyRetryPolicies(t *testing.T) {
+	errDown := errors.New("down")
+
+	newFlaky := func(failures int) (*Lazy[int], *int) {
+		calls := 0
+		return NewLazy(func() (int, error) {
+			calls++
+			if calls <= failures {
+				return 0, errDown
+			}
+			return calls, nil
+		}), &calls
+	}
+
+	t.Run("retry on every get", func(t *testing.T) {
+		l, calls := newFlaky(1)
+
+		_, err := l.Get()
+		assert.Equal(t, errDown, err)
+		assert.Equal(t, 2, l.MustGet())
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("cache errors", func(t *testing.T) {
+		l, calls := newFlaky(1)
+		l.CacheErrors()
+
+		for i := 0; i < 3; i++ {
+			_, err := l.Get()
+			assert.Equal(t, errDown, err)
+		}
+		assert.Equal(t, 1, *calls)
+		assert.Panics(t, func() { l.MustGet() })
+
+		l.Reset()
+		assert.Equal(t, 2, l.MustGet())
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		l, calls := newFlaky(1)
+		l.RetryAfter(20 * time.Millisecond)
+
+		_, err := l.Get()
+		assert.Equal(t, errDown, err)
+		_, err = l.Get()
+		assert.Equal(t, errDown, err)
+		assert.Equal(t, 1, *calls, "no retry within the window")
+
+		time.Sleep(25 * time.Millisecond)
+		assert.Equal(t, 2, l.MustGet())
+	})
+}
@@ -0,0 +1,154 @@
+// Package opt provides generic wrapper types for values that may be missing,
+// may have failed, or are computed on first use.
+package opt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Optional holds a value of type T or nothing.
+//
+// Unlike a *T, an Optional can't be dereferenced by accident, and its zero
+// value is a valid "absent". It is designed for PATCH-style request bodies
+// where "field not sent" must be told apart from "field sent as zero":
+//
+//	type UpdateUser struct {
+//		Name opt.Optional[string] `json:"name,omitzero"`
+//		Age  opt.Optional[int]    `json:"age,omitzero"`
+//	}
+//
+// A missing JSON key, or an explicit null, decodes to None; any other value
+// decodes to Some. On output, None marshals as null, or is left out entirely
+// with the omitzero option.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, ok: true}
+}
+
+// None returns an empty Optional. It is equivalent to Optional[T]{}.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// FromPtr returns Some(*p), or None if p is nil.
+func FromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return None[T]()
+	}
+
+	return Some(*p)
+}
+
+// Get returns the held value and true, or the zero value and false.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// IsSome reports whether o holds a value.
+func (o Optional[T]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone reports whether o is empty.
+func (o Optional[T]) IsNone() bool {
+	return !o.ok
+}
+
+// IsZero reports whether o is empty. It makes the `json:",omitzero"` option
+// leave out absent fields.
+func (o Optional[T]) IsZero() bool {
+	return !o.ok
+}
+
+// MustGet returns the held value. It panics if o is empty.
+func (o Optional[T]) MustGet() T {
+	if !o.ok {
+		panic("opt: MustGet on empty Optional")
+	}
+
+	return o.value
+}
+
+// OrElse returns the held value, or fallback if o is empty.
+func (o Optional[T]) OrElse(fallback T) T {
+	if !o.ok {
+		return fallback
+	}
+
+	return o.value
+}
+
+// OrElseFunc returns the held value, or the result of fn if o is empty.
+// fn is only called when needed.
+func (o Optional[T]) OrElseFunc(fn func() T) T {
+	if !o.ok {
+		return fn()
+	}
+
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the held value, or nil if o is empty.
+func (o Optional[T]) Ptr() *T {
+	if !o.ok {
+		return nil
+	}
+	v := o.value
+
+	return &v
+}
+
+// String returns "Some(v)" or "None".
+func (o Optional[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// Map applies fn to the value held by o. An empty o stays empty and fn is not called.
+//
+// Example:
+//
+//	opt.Map(opt.Some(42), strconv.Itoa) => Some("42")
+//	opt.Map(opt.None[int](), strconv.Itoa) => None
+func Map[T, U any](o Optional[T], fn func(T) U) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+
+	return Some(fn(o.value))
+}
+
+// MarshalJSON encodes the held value, or null if o is empty.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as None and anything else as Some.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = None[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+
+	return nil
+}
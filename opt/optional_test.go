@@ -0,0 +1,96 @@
+package opt
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	some := Some(0)
+	v, ok := some.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	assert.True(t, some.IsSome())
+	assert.False(t, some.IsNone())
+	assert.Equal(t, 0, some.OrElse(5))
+	assert.Equal(t, 0, some.MustGet())
+	assert.Equal(t, "Some(0)", some.String())
+
+	none := None[int]()
+	_, ok = none.Get()
+	assert.False(t, ok)
+	assert.True(t, none.IsNone())
+	assert.Equal(t, Optional[int]{}, none)
+	assert.Equal(t, 5, none.OrElse(5))
+	assert.Equal(t, 6, none.OrElseFunc(func() int { return 6 }))
+	assert.Equal(t, "None", none.String())
+	assert.Panics(t, func() { none.MustGet() })
+}
+
+func TestOptionalPtr(t *testing.T) {
+	assert.Nil(t, None[string]().Ptr())
+	assert.Equal(t, "x", *Some("x").Ptr())
+
+	v := 3
+	assert.Equal(t, Some(3), FromPtr(&v))
+	assert.Equal(t, None[int](), FromPtr[int](nil))
+}
+
+func TestMap(t *testing.T) {
+	assert.Equal(t, Some("42"), Map(Some(42), strconv.Itoa))
+
+	called := false
+	got := Map(None[int](), func(int) string {
+		called = true
+		return ""
+	})
+	assert.Equal(t, None[string](), got)
+	assert.False(t, called)
+}
+
+type patch struct {
+	Name  Optional[string] `json:"name,omitzero"`
+	Age   Optional[int]    `json:"age,omitzero"`
+	Email Optional[string] `json:"email"`
+}
+
+func TestOptionalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected patch
+	}{
+		{
+			name:     "absent",
+			input:    `{}`,
+			expected: patch{},
+		},
+		{
+			name:     "zero values are present",
+			input:    `{"name":"","age":0}`,
+			expected: patch{Name: Some(""), Age: Some(0)},
+		},
+		{
+			name:     "null is absent",
+			input:    `{"name":null,"email":"a@b.c"}`,
+			expected: patch{Email: Some("a@b.c")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p patch
+			assert.NoError(t, json.Unmarshal([]byte(tt.input), &p), tt.name)
+			assert.Equal(t, tt.expected, p, tt.name)
+		})
+	}
+
+	out, err := json.Marshal(patch{Age: Some(0)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"age":0,"email":null}`, string(out))
+
+	var p patch
+	assert.Error(t, json.Unmarshal([]byte(`{"age":"old"}`), &p))
+}
@@ -0,0 +1,133 @@
+package opt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vk4s/goutils/errutil"
+)
+
+// Result holds either a value of type T or an error.
+//
+// It lets per-item outcomes travel as one value, for example through a
+// channel or in the output slice of a parallel map, instead of keeping a
+// []T and an []error aligned by index.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result holding err. A nil err is replaced by an error
+// saying so, so that an Err result never reports success.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		err = errors.New("opt: Err called with nil error")
+	}
+
+	return Result[T]{err: err}
+}
+
+// Try converts a (value, error) pair, as returned by most Go functions, into a Result.
+//
+// Example:
+//
+//	results <- opt.Try(fetch(ctx, url))
+func Try[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+
+	return Ok(v)
+}
+
+// Get returns the value and error as a regular Go pair.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr reports whether r holds an error.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Err returns the held error, or nil if r is successful.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap returns the held value. It panics with the held error if r failed.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+
+	return r.value
+}
+
+// UnwrapOr returns the held value, or fallback if r failed.
+func (r Result[T]) UnwrapOr(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+
+	return r.value
+}
+
+// String returns "Ok(v)" or "Err(message)".
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies fn to the value held by r. A failed r keeps its error and fn is not called.
+func MapResult[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+
+	return Ok(fn(r.value))
+}
+
+// Then applies fn, which may itself fail, to the value held by r.
+// A failed r keeps its error and fn is not called.
+func Then[T, U any](r Result[T], fn func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+
+	return Try(fn(r.value))
+}
+
+// Collect returns the values of results in order, or, if any of them failed,
+// nil and an *errutil.MultiError holding every error.
+func Collect[T any](results []Result[T]) ([]T, error) {
+	var errs *errutil.MultiError
+	for _, r := range results {
+		if r.err != nil {
+			errs = errutil.Append(errs, r.err)
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	values := make([]T, len(results))
+	for i, r := range results {
+		values[i] = r.value
+	}
+
+	return values, nil
+}
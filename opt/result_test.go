@@ -0,0 +1,90 @@
+package opt
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	ok := Ok(3)
+	v, err := ok.Get()
+	assert.Equal(t, 3, v)
+	assert.NoError(t, err)
+	assert.True(t, ok.IsOk())
+	assert.False(t, ok.IsErr())
+	assert.Equal(t, 3, ok.Unwrap())
+	assert.Equal(t, 3, ok.UnwrapOr(9))
+	assert.Equal(t, "Ok(3)", ok.String())
+
+	failed := Err[int](io.EOF)
+	_, err = failed.Get()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, io.EOF, failed.Err())
+	assert.True(t, failed.IsErr())
+	assert.Equal(t, 9, failed.UnwrapOr(9))
+	assert.Equal(t, "Err(EOF)", failed.String())
+	assert.PanicsWithError(t, "EOF", func() { failed.Unwrap() })
+
+	assert.True(t, Err[int](nil).IsErr(), "Err never reports success")
+}
+
+func TestTry(t *testing.T) {
+	assert.Equal(t, Ok(42), Try(strconv.Atoi("42")))
+
+	r := Try(strconv.Atoi("x"))
+	assert.True(t, r.IsErr())
+	assert.ErrorIs(t, r.Err(), strconv.ErrSyntax)
+}
+
+func TestMapResultAndThen(t *testing.T) {
+	assert.Equal(t, Ok("7"), MapResult(Ok(7), strconv.Itoa))
+	assert.Equal(t, io.EOF, MapResult(Err[int](io.EOF), strconv.Itoa).Err())
+
+	assert.Equal(t, Ok(12), Then(Ok("12"), strconv.Atoi))
+	assert.True(t, Then(Ok("twelve"), strconv.Atoi).IsErr())
+	assert.Equal(t, io.EOF, Then(Err[string](io.EOF), strconv.Atoi).Err())
+}
+
+func TestCollect(t *testing.T) {
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	tests := []struct {
+		name     string
+		results  []Result[int]
+		expected []int
+		errs     []error
+	}{
+		{
+			name:     "empty",
+			results:  nil,
+			expected: []int{},
+		},
+		{
+			name:     "all ok",
+			results:  []Result[int]{Ok(1), Ok(2)},
+			expected: []int{1, 2},
+		},
+		{
+			name:    "some failed",
+			results: []Result[int]{Ok(1), Err[int](errA), Ok(3), Err[int](errB)},
+			errs:    []error{errA, errB},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := Collect(tt.results)
+			assert.Equal(t, tt.expected, values, tt.name)
+			if tt.errs == nil {
+				assert.NoError(t, err, tt.name)
+				return
+			}
+			for _, e := range tt.errs {
+				assert.ErrorIs(t, err, e, tt.name)
+			}
+		})
+	}
+}
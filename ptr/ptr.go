@@ -0,0 +1,63 @@
+// Package ptr provides helpers for working with pointers to values, as found in
+// API structs where optional fields are modelled as *T.
+//
+// Go has no way to take the address of a literal, so every codebase grows its own
+// StringPtr / IntPtr helpers. ptr.To replaces all of them:
+//
+//	req := api.UpdateUser{Name: ptr.To("alice"), Age: ptr.To(30)}
+package ptr
+
+// To returns a pointer to a copy of v.
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or fallback if p is nil.
+//
+// Example:
+//
+//	ptr.Deref(req.Limit, 50) => *req.Limit, or 50 when the field was omitted
+func Deref[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+
+	return *p
+}
+
+// Equal reports whether a and b are both nil, or both non-nil and point to equal values.
+func Equal[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// Coalesce returns the first of vals that is not the zero value of T,
+// or the zero value if all of them are.
+//
+// Example:
+//
+//	ptr.Coalesce(cfg.Region, os.Getenv("REGION"), "us-east-1")
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+
+	return zero
+}
+
+// CoalescePtr returns the first non-nil pointer in ptrs, or nil if all are nil.
+func CoalescePtr[T any](ptrs ...*T) *T {
+	for _, p := range ptrs {
+		if p != nil {
+			return p
+		}
+	}
+
+	return nil
+}
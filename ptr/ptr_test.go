@@ -0,0 +1,99 @@
+package ptr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTo(t *testing.T) {
+	v := 5
+	p := To(v)
+	v = 6
+
+	assert.Equal(t, 5, *p, "points to a copy")
+	assert.Equal(t, "x", *To("x"))
+}
+
+func TestDeref(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *int
+		fallback int
+		expected int
+	}{
+		{
+			name:     "nil",
+			p:        nil,
+			fallback: 50,
+			expected: 50,
+		},
+		{
+			name:     "set",
+			p:        To(10),
+			fallback: 50,
+			expected: 10,
+		},
+		{
+			name:     "set to zero",
+			p:        To(0),
+			fallback: 50,
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Deref(tt.p, tt.fallback), tt.name)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     *string
+		expected bool
+	}{
+		{
+			name:     "both nil",
+			expected: true,
+		},
+		{
+			name:     "one nil",
+			a:        To("a"),
+			expected: false,
+		},
+		{
+			name:     "equal values",
+			a:        To("a"),
+			b:        To("a"),
+			expected: true,
+		},
+		{
+			name:     "different values",
+			a:        To("a"),
+			b:        To("b"),
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Equal(tt.a, tt.b), tt.name)
+			assert.Equal(t, tt.expected, Equal(tt.b, tt.a), tt.name)
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	assert.Equal(t, "b", Coalesce("", "b", "c"))
+	assert.Equal(t, "", Coalesce[string]())
+	assert.Equal(t, 0, Coalesce(0, 0))
+	assert.Equal(t, 3, Coalesce(0, 3))
+}
+
+func TestCoalescePtr(t *testing.T) {
+	second := To(2)
+
+	assert.Same(t, second, CoalescePtr(nil, second, To(3)))
+	assert.Nil(t, CoalescePtr[int](nil, nil))
+}
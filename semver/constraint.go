@@ -0,0 +1,326 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidConstraint is returned (wrapped) when a constraint expression is malformed.
+var ErrInvalidConstraint = errors.New("invalid constraint")
+
+// Constraint is a parsed version constraint such as "^1.2.0" or ">=1.4 <2.0".
+//
+// A constraint is one or more comparator sets joined by "||"; a version
+// satisfies it if it satisfies every comparator of at least one set. Comparators
+// within a set are separated by spaces or commas. Supported forms, with the
+// range each one expands to:
+//
+//	1.2.3, =1.2.3    exactly 1.2.3
+//	!=1.2.3          anything but 1.2.3
+//	>1.2, >=1.2      >=1.3.0, >=1.2.0 (missing components are 0 or bump the next one up)
+//	<2.0, <=2.0      <2.0.0, <2.1.0
+//	~1.2.3, ~>1.2.3  >=1.2.3 <1.3.0
+//	^1.2.3           >=1.2.3 <2.0.0   (^0.2.3 is <0.3.0, ^0.0.3 is <0.0.4)
+//	1.2, 1.2.x, 1.*  >=1.2.0 <1.3.0, >=1.2.0 <1.3.0, >=1.0.0 <2.0.0
+//	*, x, ""         any version
+//	1.2 - 2.3        >=1.2.0 <=2.3.0
+//
+// Prerelease versions only satisfy a comparator set if one of its comparators
+// names the same MAJOR.MINOR.PATCH with a prerelease, so "^1.2.0" does not match
+// 1.3.0-beta but ">=1.3.0-alpha" does match 1.3.0-beta. This keeps unstable
+// releases from being picked up by ranges that never asked for them.
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+type operator int
+
+const (
+	opEQ operator = iota
+	opNE
+	opGT
+	opGE
+	opLT
+	opLE
+)
+
+type comparator struct {
+	op      operator
+	version Version
+}
+
+func (c comparator) check(v Version) bool {
+	cmp := Compare(v, c.version)
+	switch c.op {
+	case opEQ:
+		return cmp == 0
+	case opNE:
+		return cmp != 0
+	case opGT:
+		return cmp > 0
+	case opGE:
+		return cmp >= 0
+	case opLT:
+		return cmp < 0
+	case opLE:
+		return cmp <= 0
+	}
+
+	return false
+}
+
+// ParseConstraint parses a constraint expression. See Constraint for the syntax.
+//
+// Example:
+//
+//	c, err := semver.ParseConstraint(">=1.4 <2.0 || ^3.1")
+//	c.Check(semver.MustParse("1.9.2")) => true
+//	c.Check(semver.MustParse("2.0.0")) => false
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: s}
+	for _, part := range strings.Split(s, "||") {
+		set, err := parseSet(part)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("semver: %w %q: %w", ErrInvalidConstraint, s, err)
+		}
+		c.sets = append(c.sets, set)
+	}
+
+	return c, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics if s is invalid.
+func MustParseConstraint(s string) Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Check reports whether v satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, set := range c.sets {
+		if checkSet(set, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Highest returns the highest of versions that satisfies the constraint, or
+// false if none does.
+func (c Constraint) Highest(versions []Version) (Version, bool) {
+	var (
+		best  Version
+		found bool
+	)
+	for _, v := range versions {
+		if c.Check(v) && (!found || Compare(v, best) > 0) {
+			best, found = v, true
+		}
+	}
+
+	return best, found
+}
+
+// String returns the constraint as it was written.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+func checkSet(set []comparator, v Version) bool {
+	for _, c := range set {
+		if !c.check(v) {
+			return false
+		}
+	}
+	if v.Prerelease == "" {
+		return true
+	}
+
+	for _, c := range set {
+		if c.version.Prerelease != "" && c.version.Core() == v.Core() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSet parses one comparator set, the text between "||".
+func parseSet(s string) ([]comparator, error) {
+	tokens := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '\t' || r == ',' })
+
+	// Join operators written apart from their version, as in ">= 1.2".
+	var joined []string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if strings.Trim(tok, "=!<>~^") == "" && tok != "-" && i+1 < len(tokens) {
+			i++
+			tok += tokens[i]
+		}
+		joined = append(joined, tok)
+	}
+
+	if len(joined) == 3 && joined[1] == "-" {
+		return hyphenRange(joined[0], joined[2])
+	}
+	if len(joined) == 0 {
+		return []comparator{{opGE, Version{}}}, nil
+	}
+
+	var set []comparator
+	for _, tok := range joined {
+		cs, err := parseComparator(tok)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cs...)
+	}
+
+	return set, nil
+}
+
+// parseComparator expands one comparator token into primitive comparators.
+func parseComparator(tok string) ([]comparator, error) {
+	op := tok[:len(tok)-len(strings.TrimLeft(tok, "=!<>~^"))]
+	v, n, err := parseRange(tok[len(op):])
+	if err != nil {
+		return nil, err
+	}
+
+	anyVersion := []comparator{{opGE, Version{}}}
+	switch op {
+	case "", "=":
+		if n == 3 {
+			return []comparator{{opEQ, v}}, nil
+		}
+		if n == 0 {
+			return anyVersion, nil
+		}
+		return []comparator{{opGE, v}, {opLT, bump(v, n)}}, nil
+	case "!=":
+		if n < 3 {
+			return nil, fmt.Errorf("%q needs a full version", tok)
+		}
+		return []comparator{{opNE, v}}, nil
+	case ">":
+		switch n {
+		case 0:
+			return nil, fmt.Errorf("%q matches nothing", tok)
+		case 3:
+			return []comparator{{opGT, v}}, nil
+		}
+		return []comparator{{opGE, bump(v, n)}}, nil
+	case ">=":
+		return []comparator{{opGE, v}}, nil
+	case "<":
+		if n == 0 {
+			return nil, fmt.Errorf("%q matches nothing", tok)
+		}
+		return []comparator{{opLT, v}}, nil
+	case "<=":
+		switch n {
+		case 0:
+			return anyVersion, nil
+		case 3:
+			return []comparator{{opLE, v}}, nil
+		}
+		return []comparator{{opLT, bump(v, n)}}, nil
+	case "~", "~>":
+		if n == 0 {
+			return anyVersion, nil
+		}
+		return []comparator{{opGE, v}, {opLT, bump(v, min(n, 2))}}, nil
+	case "^":
+		if n == 0 {
+			return anyVersion, nil
+		}
+		// Bump the first non-zero component, or the last one given.
+		switch {
+		case v.Major > 0 || n == 1:
+			return []comparator{{opGE, v}, {opLT, bump(v, 1)}}, nil
+		case v.Minor > 0 || n == 2:
+			return []comparator{{opGE, v}, {opLT, bump(v, 2)}}, nil
+		}
+		return []comparator{{opGE, v}, {opLT, bump(v, 3)}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+func hyphenRange(lo, hi string) ([]comparator, error) {
+	from, _, err := parseRange(lo)
+	if err != nil {
+		return nil, err
+	}
+	to, n, err := parseRange(hi)
+	if err != nil {
+		return nil, err
+	}
+
+	set := []comparator{{opGE, from}}
+	switch n {
+	case 0:
+	case 3:
+		set = append(set, comparator{opLE, to})
+	default:
+		set = append(set, comparator{opLT, bump(to, n)})
+	}
+
+	return set, nil
+}
+
+// parseRange parses a possibly partial version in which trailing components may
+// be missing or written as x, X, or *. It returns the version with missing
+// components set to zero and the number of components that were given.
+func parseRange(s string) (Version, int, error) {
+	parts := strings.Split(s, ".")
+	given := 0
+	for given < len(parts) && !isWildcard(parts[given]) {
+		given++
+	}
+	for _, p := range parts[given:] {
+		if !isWildcard(p) {
+			return Version{}, 0, fmt.Errorf("version %q has a number after a wildcard", s)
+		}
+	}
+	if given == 0 {
+		if len(parts) > 3 {
+			return Version{}, 0, fmt.Errorf("version %q has too many components", s)
+		}
+		return Version{}, 0, nil
+	}
+
+	v, n, err := parsePartial(strings.Join(parts[:given], "."))
+	if err != nil {
+		return Version{}, 0, fmt.Errorf("version %q: %w", s, err)
+	}
+	if n == 3 && given < len(parts) {
+		return Version{}, 0, fmt.Errorf("version %q has too many components", s)
+	}
+
+	return v, n, nil
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*" || s == ""
+}
+
+// bump returns the smallest release above every version starting with the first
+// n components of v: bump(1.2.3, 2) is 1.3.0.
+func bump(v Version, n int) Version {
+	switch n {
+	case 1:
+		return New(v.Major+1, 0, 0)
+	case 2:
+		return New(v.Major, v.Minor+1, 0)
+	}
+
+	return New(v.Major, v.Minor, v.Patch+1)
+}
@@ -0,0 +1,91 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		misses     []string
+	}{
+		{"1.2.3", []string{"1.2.3", "1.2.3+build"}, []string{"1.2.4", "1.2.3-rc.1"}},
+		{"=1.2", []string{"1.2.0", "1.2.99"}, []string{"1.3.0", "1.1.9", "1.3.0-beta"}},
+		{"!=1.2.3", []string{"1.2.2", "1.2.4"}, []string{"1.2.3"}},
+		{">1.2.3", []string{"1.2.4", "2.0.0"}, []string{"1.2.3", "1.3.0-beta"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{">=1.4 <2.0", []string{"1.4.0", "1.9.9"}, []string{"1.3.9", "2.0.0", "2.0.0-rc.1"}},
+		{">= 1.4, < 2.0", []string{"1.5.0"}, []string{"2.0.0"}},
+		{"<=1.2", []string{"1.2.9", "0.1.0"}, []string{"1.3.0"}},
+		{"<=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~>1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"^1.2.0", []string{"1.2.0", "1.9.9"}, []string{"2.0.0", "1.1.9", "1.3.0-beta"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"^0.0", []string{"0.0.9"}, []string{"0.1.0"}},
+		{"^1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.x", []string{"1.0.0", "1.9.9"}, []string{"2.0.0"}},
+		{"1.2.*", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{"*", []string{"0.0.0", "9.9.9"}, []string{"1.0.0-rc.1"}},
+		{"", []string{"1.0.0"}, nil},
+		{"1.2 - 2.3", []string{"1.2.0", "2.3.9"}, []string{"1.1.9", "2.4.0"}},
+		{"1.2.3 - 2.3.4", []string{"2.3.4"}, []string{"2.3.5"}},
+		{"^1.2 || ^3.1", []string{"1.5.0", "3.1.0"}, []string{"2.0.0", "3.0.9"}},
+		{">=1.3.0-alpha", []string{"1.3.0-beta", "1.3.0", "1.4.0"}, []string{"1.4.0-beta", "1.3.0-0"}},
+		{"v1.2.3", []string{"1.2.3"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			assert.NoError(t, err)
+			for _, v := range tt.matches {
+				assert.True(t, c.Check(MustParse(v)), "%q should match %s", tt.constraint, v)
+			}
+			for _, v := range tt.misses {
+				assert.False(t, c.Check(MustParse(v)), "%q should not match %s", tt.constraint, v)
+			}
+			assert.Equal(t, tt.constraint, c.String())
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"abc", `semver: invalid constraint "abc": version "abc": non-numeric component "abc"`},
+		{"!=1.2", `semver: invalid constraint "!=1.2": "!=1.2" needs a full version`},
+		{">*", `semver: invalid constraint ">*": ">*" matches nothing`},
+		{"=>1.0.0", `semver: invalid constraint "=>1.0.0": unknown operator "=>"`},
+		{"1.x.3", `semver: invalid constraint "1.x.3": version "1.x.3" has a number after a wildcard`},
+		{"1.2.3.x", `semver: invalid constraint "1.2.3.x": version "1.2.3.x" has too many components`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseConstraint(tt.input)
+			assert.EqualError(t, err, tt.expected)
+			assert.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+
+	assert.Panics(t, func() { MustParseConstraint("~~") })
+}
+
+func TestConstraintHighest(t *testing.T) {
+	versions := []Version{
+		MustParse("1.2.0"), MustParse("1.9.1"), MustParse("2.0.0"), MustParse("1.10.0-beta"), MustParse("1.8.0"),
+	}
+
+	v, ok := MustParseConstraint("^1.2").Highest(versions)
+	assert.True(t, ok)
+	assert.Equal(t, MustParse("1.9.1"), v)
+
+	_, ok = MustParseConstraint(">=3").Highest(versions)
+	assert.False(t, ok)
+}
@@ -0,0 +1,297 @@
+// Package semver parses and compares semantic versions (https://semver.org) and
+// matches them against constraint expressions such as "^1.2.0" or ">=1.4 <2.0".
+package semver
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned (wrapped) when a version string is malformed.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// Version is a parsed semantic version: MAJOR.MINOR.PATCH, an optional
+// prerelease such as "rc.1", and optional build metadata.
+//
+// The zero value is 0.0.0.
+type Version struct {
+	Major, Minor, Patch uint64
+
+	// Prerelease holds the dot-separated identifiers after "-", without the dash.
+	Prerelease string
+	// Build holds the metadata after "+", without the plus. It is ignored when
+	// comparing versions.
+	Build string
+}
+
+// New returns the release version major.minor.patch.
+func New(major, minor, patch uint64) Version {
+	return Version{Major: major, Minor: minor, Patch: patch}
+}
+
+// Parse parses a semantic version. A leading "v" is accepted, as Go and git tags
+// use it, but all three numeric components are required.
+//
+// Example:
+//
+//	Parse("v1.4.0-rc.1+build.5")
+//	=> Version{Major: 1, Minor: 4, Patch: 0, Prerelease: "rc.1", Build: "build.5"}
+func Parse(s string) (Version, error) {
+	v, n, err := parsePartial(s)
+	if err == nil && n < 3 {
+		err = errors.New("expected MAJOR.MINOR.PATCH")
+	}
+	if err != nil {
+		return Version{}, fmt.Errorf("semver: %w %q: %w", ErrInvalidVersion, s, err)
+	}
+
+	return v, nil
+}
+
+// MustParse is like Parse but panics if s is not a valid version.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// parsePartial parses a version whose minor and patch components may be
+// missing, returning how many numeric components were present. A prerelease
+// or build suffix requires all three.
+func parsePartial(s string) (Version, int, error) {
+	var v Version
+
+	s = strings.TrimPrefix(s, "v")
+	var hasPre, hasBuild bool
+	s, v.Build, hasBuild = strings.Cut(s, "+")
+	s, v.Prerelease, hasPre = strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, errors.New("too many components")
+	}
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := parseNumber(p)
+		if err != nil {
+			return Version{}, 0, err
+		}
+		*nums[i] = n
+	}
+
+	if (hasPre || hasBuild) && len(parts) < 3 {
+		return Version{}, 0, errors.New("prerelease and build require MAJOR.MINOR.PATCH")
+	}
+	if hasPre {
+		if err := checkIdentifiers(v.Prerelease, true); err != nil {
+			return Version{}, 0, fmt.Errorf("prerelease: %w", err)
+		}
+	}
+	if hasBuild {
+		if err := checkIdentifiers(v.Build, false); err != nil {
+			return Version{}, 0, fmt.Errorf("build: %w", err)
+		}
+	}
+
+	return v, len(parts), nil
+}
+
+func parseNumber(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty component")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("leading zero in %q", s)
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("non-numeric component %q", s)
+		}
+	}
+
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// checkIdentifiers validates dot-separated prerelease or build identifiers.
+// Numeric prerelease identifiers may not have leading zeros.
+func checkIdentifiers(s string, prerelease bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return errors.New("empty identifier")
+		}
+		for _, c := range id {
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return fmt.Errorf("invalid character %q in %q", c, id)
+			}
+		}
+		if prerelease && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("leading zero in %q", id)
+		}
+	}
+
+	return nil
+}
+
+func isNumeric(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// String returns the canonical form of v, without a "v" prefix.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// IsPrerelease reports whether v has a prerelease part.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Core returns v without its prerelease and build parts.
+func (v Version) Core() Version {
+	return New(v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0, or +1 depending on whether v is lower than, equal to,
+// or higher than o, following the precedence rules of the SemVer spec.
+func (v Version) Compare(o Version) int {
+	return Compare(v, o)
+}
+
+// LessThan reports whether v has lower precedence than o.
+func (v Version) LessThan(o Version) bool {
+	return Compare(v, o) < 0
+}
+
+// Equal reports whether v and o have the same precedence. Build metadata is
+// ignored, so 1.0.0+a equals 1.0.0+b.
+func (v Version) Equal(o Version) bool {
+	return Compare(v, o) == 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(b []byte) error {
+	parsed, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+
+	return nil
+}
+
+// Compare returns -1, 0, or +1 depending on whether a has lower, equal, or
+// higher precedence than b. It has the signature slices.SortFunc expects.
+//
+// Numeric components are compared numerically. A prerelease version is lower
+// than the release with the same core, and prereleases are compared identifier
+// by identifier: numbers numerically, others in ASCII order, numbers before
+// others, and a shorter list before a longer one with the same prefix.
+//
+// Example:
+//
+//	1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0-beta.2 < 1.0.0-beta.11 < 1.0.0-rc.1 < 1.0.0
+func Compare(a, b Version) int {
+	if c := cmp.Compare(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.Prerelease == b.Prerelease:
+		return 0
+	case a.Prerelease == "":
+		return 1
+	case b.Prerelease == "":
+		return -1
+	}
+
+	ai, bi := strings.Split(a.Prerelease, "."), strings.Split(b.Prerelease, ".")
+	for i := 0; i < len(ai) && i < len(bi); i++ {
+		if c := compareIdentifier(ai[i], bi[i]); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(ai), len(bi))
+}
+
+func compareIdentifier(a, b string) int {
+	an, bn := isNumeric(a), isNumeric(b)
+	switch {
+	case an && bn:
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// Sort sorts versions in ascending order of precedence.
+// Versions that compare equal keep their relative order.
+func Sort(versions []Version) {
+	slices.SortStableFunc(versions, Compare)
+}
+
+// SortStrings parses and sorts version strings in ascending order of precedence.
+// It returns an error, and leaves vs untouched, if any of them fails to parse.
+func SortStrings(vs []string) error {
+	parsed := make([]Version, len(vs))
+	for i, s := range vs {
+		v, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		parsed[i] = v
+	}
+
+	idx := make([]int, len(vs))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(i, j int) int { return Compare(parsed[i], parsed[j]) })
+
+	sorted := make([]string, len(vs))
+	for i, j := range idx {
+		sorted[i] = vs[j]
+	}
+	copy(vs, sorted)
+
+	return nil
+}
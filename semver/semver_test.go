@@ -0,0 +1,129 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+	}{
+		{"1.2.3", New(1, 2, 3)},
+		{"v0.0.0", Version{}},
+		{"1.0.0-alpha", Version{Major: 1, Prerelease: "alpha"}},
+		{"1.0.0-rc.1+build.5", Version{Major: 1, Prerelease: "rc.1", Build: "build.5"}},
+		{"1.0.0+20130313144700", Version{Major: 1, Build: "20130313144700"}},
+		{"1.0.0-x-y-z.--", Version{Major: 1, Prerelease: "x-y-z.--"}},
+		{"1.0.0+001", Version{Major: 1, Build: "001"}},
+		{"18446744073709551615.0.0", New(18446744073709551615, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, err := Parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", `semver: invalid version "": empty component`},
+		{"1.2", `semver: invalid version "1.2": expected MAJOR.MINOR.PATCH`},
+		{"1.2.3.4", `semver: invalid version "1.2.3.4": too many components`},
+		{"01.2.3", `semver: invalid version "01.2.3": leading zero in "01"`},
+		{"1.a.3", `semver: invalid version "1.a.3": non-numeric component "a"`},
+		{"1.2.3-", `semver: invalid version "1.2.3-": prerelease: empty identifier`},
+		{"1.2.3-01", `semver: invalid version "1.2.3-01": prerelease: leading zero in "01"`},
+		{"1.2.3-a..b", `semver: invalid version "1.2.3-a..b": prerelease: empty identifier`},
+		{"1.2.3+", `semver: invalid version "1.2.3+": build: empty identifier`},
+		{"1.2.3+a_b", `semver: invalid version "1.2.3+a_b": build: invalid character '_' in "a_b"`},
+		{"1.2-rc", `semver: invalid version "1.2-rc": prerelease and build require MAJOR.MINOR.PATCH`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			assert.EqualError(t, err, tt.expected)
+			assert.ErrorIs(t, err, ErrInvalidVersion)
+		})
+	}
+
+	assert.Panics(t, func() { MustParse("nope") })
+}
+
+func TestString(t *testing.T) {
+	for _, s := range []string{"1.2.3", "0.0.1-alpha.1", "1.0.0+meta", "2.0.0-rc.1+build.1"} {
+		assert.Equal(t, s, MustParse(s).String())
+	}
+	assert.Equal(t, "1.2.3", MustParse("v1.2.3").String())
+}
+
+func TestCompare(t *testing.T) {
+	// Each version has lower precedence than the next one.
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, b := MustParse(ordered[i]), MustParse(ordered[i+1])
+		assert.Equal(t, -1, Compare(a, b), "%s < %s", a, b)
+		assert.Equal(t, 1, Compare(b, a), "%s > %s", b, a)
+		assert.True(t, a.LessThan(b))
+	}
+
+	assert.True(t, MustParse("1.0.0+a").Equal(MustParse("1.0.0+b")), "build metadata is ignored")
+	assert.Equal(t, 0, MustParse("1.0.0-rc.1").Compare(MustParse("1.0.0-rc.1")))
+}
+
+func TestSort(t *testing.T) {
+	vs := []Version{MustParse("1.10.0"), MustParse("1.2.0"), MustParse("1.2.0-rc.1"), MustParse("0.1.0")}
+	Sort(vs)
+	assert.Equal(t, []Version{MustParse("0.1.0"), MustParse("1.2.0-rc.1"), MustParse("1.2.0"), MustParse("1.10.0")}, vs)
+
+	ss := []string{"v1.10.0", "1.9.0", "v1.9.0-beta"}
+	assert.NoError(t, SortStrings(ss))
+	assert.Equal(t, []string{"v1.9.0-beta", "1.9.0", "v1.10.0"}, ss)
+
+	bad := []string{"2.0.0", "x"}
+	assert.Error(t, SortStrings(bad))
+	assert.Equal(t, []string{"2.0.0", "x"}, bad)
+}
+
+func TestVersionJSON(t *testing.T) {
+	var out struct {
+		V Version `json:"v"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"v":"v1.2.3-rc.1"}`), &out))
+	assert.Equal(t, MustParse("1.2.3-rc.1"), out.V)
+
+	b, err := json.Marshal(out)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"v":"1.2.3-rc.1"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"v":"1.2"}`), &out))
+}
+
+func TestHelpers(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+b")
+	assert.True(t, v.IsPrerelease())
+	assert.False(t, v.Core().IsPrerelease())
+	assert.Equal(t, New(1, 2, 3), v.Core())
+}
@@ -0,0 +1,101 @@
+package sliceutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vk4s/goutils/errutil"
+)
+
+// BatchError is the error for one failed batch of ProcessInBatches.
+type BatchError struct {
+	// Index is the batch number, counting from 0.
+	Index int
+	// Start is the position in items of the batch's first element.
+	Start int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("sliceutil: batch %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error fn returned for the batch.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchOption configures ProcessInBatches.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	continueOnError bool
+}
+
+// ContinueOnError makes ProcessInBatches run every batch even after one fails,
+// and return all the failures together.
+func ContinueOnError() BatchOption {
+	return func(c *batchConfig) {
+		c.continueOnError = true
+	}
+}
+
+// ProcessInBatches calls fn with consecutive batches of up to batchSize items,
+// one after another, for bulk database writes and API calls with a size limit.
+// A batchSize less than 1 returns an error wrapping ErrInvalidChunkSize
+// without calling fn.
+//
+// By default it stops at the first failing batch and returns its error as a
+// *BatchError. With ContinueOnError it runs the remaining batches and returns
+// an *errutil.MultiError holding a *BatchError for each failure, in batch
+// order. Either way errors.Is and errors.As reach the errors fn returned.
+//
+// ctx is checked before each batch; once it is done no further batches start
+// and ctx.Err() is returned, after any batch errors already collected.
+//
+// Example:
+//
+//	err := sliceutil.ProcessInBatches(ctx, rows, 500, func(ctx context.Context, batch []Row) error {
+//		return db.InsertRows(ctx, batch)
+//	}, sliceutil.ContinueOnError())
+//
+//	var be *sliceutil.BatchError
+//	if errors.As(err, &be) {
+//		log.Printf("rows from %d failed: %v", be.Start, be.Err)
+//	}
+func ProcessInBatches[T any](ctx context.Context, items []T, batchSize int, fn func(ctx context.Context, batch []T) error, opts ...BatchOption) error {
+	var c batchConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var (
+		result *errutil.MultiError
+		index  int
+	)
+	err := ChunkFunc(items, batchSize, func(batch []T) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		i := index
+		index++
+		if err := fn(ctx, batch); err != nil {
+			be := &BatchError{Index: i, Start: i * batchSize, Err: err}
+			if !c.continueOnError {
+				return be
+			}
+			result = errutil.Append(result, be)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if result.Len() == 0 {
+			return err
+		}
+		result = errutil.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,125 @@
+package sliceutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk4s/goutils/errutil"
+)
+
+func TestProcessInBatches(t *testing.T) {
+	errFail := errors.New("insert failed")
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name    string
+		opts    []BatchOption
+		failing map[int]bool
+		batches [][]int
+		err     string
+	}{
+		{
+			name:    "all succeed",
+			batches: [][]int{{1, 2, 3}, {4, 5, 6}, {7}},
+		},
+		{
+			name:    "stop at first error",
+			failing: map[int]bool{4: true, 7: true},
+			batches: [][]int{{1, 2, 3}, {4, 5, 6}},
+			err:     "sliceutil: batch 1: insert failed",
+		},
+		{
+			name:    "continue on error",
+			opts:    []BatchOption{ContinueOnError()},
+			failing: map[int]bool{1: true, 7: true},
+			batches: [][]int{{1, 2, 3}, {4, 5, 6}, {7}},
+			err:     "2 errors occurred:\n\t* sliceutil: batch 0: insert failed\n\t* sliceutil: batch 2: insert failed",
+		},
+		{
+			name:    "continue with one error",
+			opts:    []BatchOption{ContinueOnError()},
+			failing: map[int]bool{4: true},
+			batches: [][]int{{1, 2, 3}, {4, 5, 6}, {7}},
+			err:     "sliceutil: batch 1: insert failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batches [][]int
+			err := ProcessInBatches(context.Background(), items, 3, func(_ context.Context, batch []int) error {
+				batches = append(batches, batch)
+				if tt.failing[batch[0]] {
+					return errFail
+				}
+				return nil
+			}, tt.opts...)
+
+			assert.Equal(t, tt.batches, batches, tt.name)
+			if tt.err == "" {
+				assert.NoError(t, err, tt.name)
+				return
+			}
+			assert.EqualError(t, err, tt.err, tt.name)
+			assert.ErrorIs(t, err, errFail, tt.name)
+		})
+	}
+}
+
+func TestProcessInBatchesErrors(t *testing.T) {
+	errFail := errors.New("boom")
+	fail := func(_ context.Context, batch []string) error {
+		if batch[0] == "c" {
+			return errFail
+		}
+		return nil
+	}
+
+	err := ProcessInBatches(context.Background(), []string{"a", "b", "c", "d"}, 2, fail)
+	var be *BatchError
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 1, be.Index)
+	assert.Equal(t, 2, be.Start)
+
+	err = ProcessInBatches(context.Background(), []string{"c", "x", "c"}, 1, fail, ContinueOnError())
+	var multi *errutil.MultiError
+	assert.ErrorAs(t, err, &multi)
+	assert.Equal(t, 2, multi.Len())
+	assert.Equal(t, 2, multi.Errors[1].(*BatchError).Start)
+
+	assert.NoError(t, ProcessInBatches(context.Background(), nil, 10, fail))
+
+	for _, size := range []int{0, -1} {
+		err = ProcessInBatches(context.Background(), []string{"a"}, size, func(context.Context, []string) error {
+			t.Fatal("fn called with an invalid batch size")
+			return nil
+		}, ContinueOnError())
+		assert.ErrorIs(t, err, ErrInvalidChunkSize)
+		assert.NotErrorAs(t, err, &be, "not attributed to a batch")
+	}
+}
+
+func TestProcessInBatchesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := ProcessInBatches(ctx, []int{1, 2, 3, 4}, 1, func(context.Context, []int) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, calls, "no batch starts after cancel")
+
+	errFail := errors.New("boom")
+	ctx, cancel = context.WithCancel(context.Background())
+	err = ProcessInBatches(ctx, []int{1, 2, 3}, 1, func(context.Context, []int) error {
+		cancel()
+		return errFail
+	}, ContinueOnError())
+	assert.ErrorIs(t, err, errFail)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "2 errors occurred:\n\t* sliceutil: batch 0: boom\n\t* context canceled")
+}
@@ -0,0 +1,74 @@
+package sliceutil
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidChunkSize is returned (wrapped) by ChunkFunc, and panicked with by
+// Chunk, when the chunk size is less than 1.
+var ErrInvalidChunkSize = errors.New("invalid chunk size")
+
+// Chunk splits s into consecutive chunks of size elements. The last chunk holds
+// whatever is left and may be shorter.
+//
+// Chunk has no error result, so a size less than 1 is reported by panicking
+// with an error wrapping ErrInvalidChunkSize, as slices.Chunk does. When the
+// size comes from configuration or user input, use ChunkFunc, which returns
+// that error instead.
+//
+// The chunks share s's backing array, so no elements are copied, but each one
+// is capped at its own length: appending to a chunk never overwrites the next.
+//
+// Example:
+//
+//	sliceutil.Chunk([]int{1, 2, 3, 4, 5}, 2) => [[1, 2], [3, 4], [5]]
+func Chunk[T any](s []T, size int) [][]T {
+	if err := checkChunkSize(size); err != nil {
+		panic(err)
+	}
+	if len(s) == 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	_ = ChunkFunc(s, size, func(chunk []T) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	return chunks
+}
+
+// ChunkFunc calls fn with each chunk of s in turn, as Chunk would return them,
+// without allocating the outer slice. It stops at the first error fn returns
+// and returns it. A size less than 1 returns an error wrapping
+// ErrInvalidChunkSize without calling fn.
+//
+// Example:
+//
+//	err := sliceutil.ChunkFunc(ids, 500, func(batch []int64) error {
+//		return db.DeleteUsers(ctx, batch)
+//	})
+func ChunkFunc[T any](s []T, size int, fn func([]T) error) error {
+	if err := checkChunkSize(size); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(s); start += size {
+		end := min(start+size, len(s))
+		if err := fn(s[start:end:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkChunkSize(size int) error {
+	if size < 1 {
+		return fmt.Errorf("sliceutil: %w %d: must be at least 1", ErrInvalidChunkSize, size)
+	}
+
+	return nil
+}
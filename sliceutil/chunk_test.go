@@ -0,0 +1,116 @@
+package sliceutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			size:     2,
+			expected: nil,
+		},
+		{
+			name:     "partial last chunk",
+			s:        []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:     "exact multiple",
+			s:        []int{1, 2, 3, 4},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:     "size larger than slice",
+			s:        []int{1, 2},
+			size:     10,
+			expected: [][]int{{1, 2}},
+		},
+		{
+			name:     "size one",
+			s:        []int{1, 2, 3},
+			size:     1,
+			expected: [][]int{{1}, {2}, {3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Chunk(tt.s, tt.size), tt.name)
+		})
+	}
+
+	s := []int{1, 2, 3, 4}
+	chunks := Chunk(s, 2)
+	_ = append(chunks[0], 99)
+	assert.Equal(t, []int{1, 2, 3, 4}, s, "appending to a chunk doesn't overwrite the next")
+
+	assert.PanicsWithError(t, "sliceutil: invalid chunk size 0: must be at least 1", func() { Chunk(s, 0) })
+	assert.PanicsWithError(t, "sliceutil: invalid chunk size -1: must be at least 1", func() { Chunk([]int{}, -1) })
+}
+
+func TestChunkFunc(t *testing.T) {
+	var got [][]string
+	err := ChunkFunc([]string{"a", "b", "c"}, 2, func(chunk []string) error {
+		got = append(got, chunk)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, got)
+
+	calls := 0
+	err = ChunkFunc([]int{1, 2, 3, 4, 5}, 2, func([]int) error {
+		calls++
+		if calls == 2 {
+			return errors.New("batch failed")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "batch failed")
+	assert.Equal(t, 2, calls, "stops at the first error")
+
+	assert.NoError(t, ChunkFunc(nil, 3, func([]int) error {
+		t.Fatal("called for nil slice")
+		return nil
+	}))
+}
+
+func TestChunkFuncInvalidSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		expected string
+	}{
+		{
+			name:     "zero",
+			size:     0,
+			expected: "sliceutil: invalid chunk size 0: must be at least 1",
+		},
+		{
+			name:     "negative",
+			size:     -3,
+			expected: "sliceutil: invalid chunk size -3: must be at least 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ChunkFunc([]int{1, 2}, tt.size, func([]int) error {
+				t.Fatal("fn called with an invalid size")
+				return nil
+			})
+			assert.EqualError(t, err, tt.expected, tt.name)
+			assert.ErrorIs(t, err, ErrInvalidChunkSize, tt.name)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package sliceutil
+
+import "iter"
+
+// Permutations yields every ordering of the elements of s, len(s)! in all,
+// using Heap's algorithm so each one differs from the last by a single swap.
+// An empty s yields one empty permutation. s is not modified.
+//
+// The yielded slice is reused for the next permutation; use slices.Clone to
+// keep one. Nothing is materialized up front, so breaking out of the loop
+// early costs only the permutations already seen.
+//
+// Example:
+//
+//	for p := range sliceutil.Permutations([]int{1, 2, 3}) {
+//		fmt.Println(p) // [1 2 3], [2 1 3], [3 1 2], [1 3 2], [2 3 1], [3 2 1]
+//	}
+func Permutations[T any](s []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		p := append(make([]T, 0, len(s)), s...)
+		if !yield(p) {
+			return
+		}
+
+		// c[i] counts the swaps made at level i, the iterative form of Heap's
+		// recursion.
+		c := make([]int, len(p))
+		for i := 1; i < len(p); {
+			if c[i] >= i {
+				c[i] = 0
+				i++
+				continue
+			}
+			if i%2 == 0 {
+				p[0], p[i] = p[i], p[0]
+			} else {
+				p[c[i]], p[i] = p[i], p[c[i]]
+			}
+			if !yield(p) {
+				return
+			}
+			c[i]++
+			i = 1
+		}
+	}
+}
+
+// Combinations yields every way to choose k elements of s, keeping their order
+// in s, in lexicographic order of position. There are len(s) choose k of them;
+// k of 0 yields one empty combination, and k outside [0, len(s)] yields none.
+//
+// As with Permutations, the yielded slice is reused for the next combination.
+//
+// Example:
+//
+//	for c := range sliceutil.Combinations([]string{"a", "b", "c", "d"}, 2) {
+//		fmt.Println(c) // [a b], [a c], [a d], [b c], [b d], [c d]
+//	}
+func Combinations[T any](s []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(s)
+		if k < 0 || k > n {
+			return
+		}
+
+		// idx holds the chosen positions in increasing order.
+		idx := make([]int, k)
+		out := make([]T, k)
+		for i := range idx {
+			idx[i] = i
+			out[i] = s[i]
+		}
+
+		for {
+			if !yield(out) {
+				return
+			}
+
+			// Advance the rightmost position that can still move right, and
+			// reset the ones after it to follow on directly.
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			out[i] = s[idx[i]]
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+				out[j] = s[idx[j]]
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package sliceutil
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectClones[T any](seq iter.Seq[[]T]) [][]T {
+	var out [][]T
+	for v := range seq {
+		out = append(out, slices.Clone(v))
+	}
+	return out
+}
+
+func TestPermutations(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		expected [][]int
+	}{
+		{
+			name:     "empty",
+			s:        nil,
+			expected: [][]int{{}},
+		},
+		{
+			name:     "one",
+			s:        []int{1},
+			expected: [][]int{{1}},
+		},
+		{
+			name:     "two",
+			s:        []int{1, 2},
+			expected: [][]int{{1, 2}, {2, 1}},
+		},
+		{
+			name:     "three",
+			s:        []int{1, 2, 3},
+			expected: [][]int{{1, 2, 3}, {2, 1, 3}, {3, 1, 2}, {1, 3, 2}, {2, 3, 1}, {3, 2, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, collectClones(Permutations(tt.s)), tt.name)
+		})
+	}
+
+	s := []int{1, 2, 3, 4, 5}
+	perms := collectClones(Permutations(s))
+	assert.Len(t, perms, 120)
+	seen := map[[5]int]bool{}
+	for _, p := range perms {
+		seen[[5]int(p)] = true
+	}
+	assert.Len(t, seen, 120, "all distinct")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s, "input unchanged")
+
+	n := 0
+	for range Permutations(s) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, n)
+}
+
+func TestCombinations(t *testing.T) {
+	s := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name     string
+		k        int
+		expected [][]string
+	}{
+		{
+			name:     "negative",
+			k:        -1,
+			expected: nil,
+		},
+		{
+			name:     "zero",
+			k:        0,
+			expected: [][]string{{}},
+		},
+		{
+			name:     "one",
+			k:        1,
+			expected: [][]string{{"a"}, {"b"}, {"c"}, {"d"}},
+		},
+		{
+			name:     "two",
+			k:        2,
+			expected: [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+		},
+		{
+			name:     "all",
+			k:        4,
+			expected: [][]string{{"a", "b", "c", "d"}},
+		},
+		{
+			name:     "too many",
+			k:        5,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, collectClones(Combinations(s, tt.k)), tt.name)
+		})
+	}
+
+	assert.Len(t, collectClones(Combinations(make([]int, 10), 3)), 120)
+
+	n := 0
+	for range Combinations(s, 2) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, n)
+}
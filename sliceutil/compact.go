@@ -0,0 +1,36 @@
+package sliceutil
+
+// Compact returns the elements of s that are not the zero value of T, in order,
+// such as the non-empty strings of a split. It returns nil if there are none.
+//
+// Unlike slices.Compact, which collapses runs of equal elements, this drops
+// zero values wherever they are.
+//
+// Example:
+//
+//	sliceutil.Compact([]string{"a", "", "b", ""}) => ["a", "b"]
+func Compact[T comparable](s []T) []T {
+	var zero T
+	return Filter(s, func(v T) bool { return v != zero })
+}
+
+// FilterInPlace is Filter that reuses s's backing array instead of allocating.
+// It returns s shortened to the kept elements; the contents of s beyond that
+// are zeroed so dropped elements can be garbage collected, so s itself should
+// not be used afterwards.
+//
+// Example:
+//
+//	jobs = sliceutil.FilterInPlace(jobs, Job.Pending)
+func FilterInPlace[T any](s []T, keep func(T) bool) []T {
+	n := 0
+	for _, v := range s {
+		if keep(v) {
+			s[n] = v
+			n++
+		}
+	}
+	clear(s[n:])
+
+	return s[:n]
+}
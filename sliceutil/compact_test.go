@@ -0,0 +1,75 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []string
+		expected []string
+	}{
+		{
+			name:     "nil",
+			expected: nil,
+		},
+		{
+			name:     "all zero",
+			s:        []string{"", ""},
+			expected: nil,
+		},
+		{
+			name:     "mixed",
+			s:        []string{"a", "", "b", "", "b"},
+			expected: []string{"a", "b", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Compact(tt.s), tt.name)
+		})
+	}
+
+	type point struct{ X, Y int }
+	assert.Equal(t, []point{{1, 0}}, Compact([]point{{}, {1, 0}, {}}))
+	assert.Nil(t, Compact([]*int{nil, nil}))
+}
+
+func TestFilterInPlace(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name     string
+		s        []int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			expected: nil,
+		},
+		{
+			name:     "some kept",
+			s:        []int{1, 2, 3, 4, 6},
+			expected: []int{2, 4, 6},
+		},
+		{
+			name:     "none kept",
+			s:        []int{1, 3},
+			expected: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FilterInPlace(tt.s, isEven), tt.name)
+		})
+	}
+
+	s := []*int{new(int), nil, new(int)}
+	got := FilterInPlace(s, func(p *int) bool { return p == nil })
+	assert.Len(t, got, 1)
+	assert.Same(t, &s[0], &got[0], "reuses the backing array")
+	assert.Equal(t, []*int{nil, nil}, s[1:], "tail zeroed")
+}
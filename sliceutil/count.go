@@ -0,0 +1,114 @@
+package sliceutil
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/vk4s/goutils/tuple"
+)
+
+// CountBy returns how many elements of s have each key.
+//
+// Example:
+//
+//	sliceutil.CountBy(events, func(e Event) string { return e.Level }) => {"info": 120, "warn": 7, "error": 2}
+func CountBy[T any, K comparable](s []T, key func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, v := range s {
+		counts[key(v)]++
+	}
+
+	return counts
+}
+
+// Counter counts occurrences of keys, for quick histograms. It remembers the
+// order keys were first added in and uses it to break ties, so MostCommon and
+// LeastCommon are deterministic.
+//
+// The zero value is an empty Counter ready to use. A Counter is not safe for
+// concurrent use.
+//
+// Example:
+//
+//	var c sliceutil.Counter[string]
+//	for _, line := range logLines {
+//		c.Add(statusCode(line))
+//	}
+//	c.MostCommon(3) => [(200, 9120), (404, 310), (500, 12)]
+type Counter[K comparable] struct {
+	counts map[K]int
+	keys   []K
+}
+
+// Add counts one occurrence of each of keys.
+func (c *Counter[K]) Add(keys ...K) {
+	for _, k := range keys {
+		c.AddN(k, 1)
+	}
+}
+
+// AddN counts n occurrences of k.
+func (c *Counter[K]) AddN(k K, n int) {
+	if c.counts == nil {
+		c.counts = make(map[K]int)
+	}
+	if _, ok := c.counts[k]; !ok {
+		c.keys = append(c.keys, k)
+	}
+	c.counts[k] += n
+}
+
+// Count returns the number of occurrences of k.
+func (c *Counter[K]) Count(k K) int {
+	return c.counts[k]
+}
+
+// Len returns the number of distinct keys.
+func (c *Counter[K]) Len() int {
+	return len(c.keys)
+}
+
+// Total returns the number of occurrences of all keys together.
+func (c *Counter[K]) Total() int {
+	total := 0
+	for _, n := range c.counts {
+		total += n
+	}
+
+	return total
+}
+
+// Counts returns a copy of the counts.
+func (c *Counter[K]) Counts() map[K]int {
+	return maps.Clone(c.counts)
+}
+
+// MostCommon returns the n keys with the highest counts, highest first, paired
+// with their counts. Keys with equal counts are in the order they were first
+// added. It returns every key if n is at least Len, and nil if n is 0 or less.
+func (c *Counter[K]) MostCommon(n int) []tuple.Pair[K, int] {
+	return c.ranked(n, func(a, b int) int { return cmp.Compare(b, a) })
+}
+
+// LeastCommon is MostCommon with the lowest counts first.
+func (c *Counter[K]) LeastCommon(n int) []tuple.Pair[K, int] {
+	return c.ranked(n, cmp.Compare[int])
+}
+
+func (c *Counter[K]) ranked(n int, compare func(a, b int) int) []tuple.Pair[K, int] {
+	n = min(n, len(c.keys))
+	if n <= 0 {
+		return nil
+	}
+
+	keys := slices.Clone(c.keys)
+	slices.SortStableFunc(keys, func(a, b K) int { return compare(c.counts[a], c.counts[b]) })
+
+	pairs := make([]tuple.Pair[K, int], n)
+	for i, k := range keys[:n] {
+		pairs[i] = tuple.NewPair(k, c.counts[k])
+	}
+
+	return pairs
+}
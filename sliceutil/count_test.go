@@ -0,0 +1,115 @@
+package sliceutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk4s/goutils/tuple"
+)
+
+func TestCountBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []string
+		expected map[int]int
+	}{
+		{
+			name:     "nil",
+			expected: map[int]int{},
+		},
+		{
+			name:     "by length",
+			s:        []string{"a", "bb", "c", "ddd", "ee"},
+			expected: map[int]int{1: 2, 2: 2, 3: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CountBy(tt.s, func(s string) int { return len(s) }), tt.name)
+		})
+	}
+}
+
+func TestCounter(t *testing.T) {
+	var c Counter[string]
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, c.Count("x"))
+	assert.Nil(t, c.MostCommon(3))
+
+	c.Add("404", "200", "500", "200", "404", "200")
+	c.AddN("301", 2)
+
+	assert.Equal(t, 4, c.Len())
+	assert.Equal(t, 8, c.Total())
+	assert.Equal(t, 3, c.Count("200"))
+	assert.Equal(t, map[string]int{"200": 3, "404": 2, "500": 1, "301": 2}, c.Counts())
+
+	counts := c.Counts()
+	counts["200"] = 0
+	assert.Equal(t, 3, c.Count("200"), "Counts returns a copy")
+
+	tests := []struct {
+		name     string
+		fn       func(int) []tuple.Pair[string, int]
+		n        int
+		expected []tuple.Pair[string, int]
+	}{
+		{
+			name:     "most common",
+			fn:       c.MostCommon,
+			n:        2,
+			expected: []tuple.Pair[string, int]{{First: "200", Second: 3}, {First: "404", Second: 2}},
+		},
+		{
+			name: "most common, ties in first-seen order",
+			fn:   c.MostCommon,
+			n:    10,
+			expected: []tuple.Pair[string, int]{
+				{First: "200", Second: 3},
+				{First: "404", Second: 2},
+				{First: "301", Second: 2},
+				{First: "500", Second: 1},
+			},
+		},
+		{
+			name: "least common",
+			fn:   c.LeastCommon,
+			n:    3,
+			expected: []tuple.Pair[string, int]{
+				{First: "500", Second: 1},
+				{First: "404", Second: 2},
+				{First: "301", Second: 2},
+			},
+		},
+		{
+			name:     "zero",
+			fn:       c.LeastCommon,
+			n:        0,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.fn(tt.n), tt.name)
+		})
+	}
+}
+
+func TestCounterExtremeCounts(t *testing.T) {
+	var c Counter[string]
+	c.AddN("max", math.MaxInt)
+	c.AddN("min", math.MinInt)
+	c.AddN("zero", 0)
+
+	assert.Equal(t, []tuple.Pair[string, int]{
+		{First: "max", Second: math.MaxInt},
+		{First: "zero", Second: 0},
+		{First: "min", Second: math.MinInt},
+	}, c.MostCommon(3))
+	assert.Equal(t, []tuple.Pair[string, int]{
+		{First: "min", Second: math.MinInt},
+		{First: "zero", Second: 0},
+		{First: "max", Second: math.MaxInt},
+	}, c.LeastCommon(3))
+}
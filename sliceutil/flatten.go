@@ -0,0 +1,77 @@
+package sliceutil
+
+// Flatten concatenates the slices in s into one. The result is allocated once
+// at its final size. It returns nil if every slice is empty.
+//
+// Example:
+//
+//	sliceutil.Flatten([][]int{{1, 2}, {}, {3}}) => [1, 2, 3]
+func Flatten[T any](s [][]T) []T {
+	n := 0
+	for _, inner := range s {
+		n += len(inner)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	out := make([]T, 0, n)
+	for _, inner := range s {
+		out = append(out, inner...)
+	}
+
+	return out
+}
+
+// FlattenAny flattens a tree of nested []any, as decoded from JSON, up to depth
+// levels. Elements that are []any are replaced by their contents; anything
+// else is kept as is. A negative depth flattens completely, and depth 0
+// returns a copy of s.
+//
+// Example:
+//
+//	s := []any{1, []any{2, []any{3, 4}}, "x"}
+//	sliceutil.FlattenAny(s, 1)  => [1, 2, [3, 4], x]
+//	sliceutil.FlattenAny(s, -1) => [1, 2, 3, 4, x]
+func FlattenAny(s []any, depth int) []any {
+	n := countFlat(s, depth)
+	if n == 0 {
+		return nil
+	}
+
+	return appendFlat(make([]any, 0, n), s, depth)
+}
+
+// countFlat returns the length of FlattenAny(s, depth).
+func countFlat(s []any, depth int) int {
+	if depth == 0 {
+		return len(s)
+	}
+
+	n := 0
+	for _, v := range s {
+		if inner, ok := v.([]any); ok {
+			n += countFlat(inner, depth-1)
+		} else {
+			n++
+		}
+	}
+
+	return n
+}
+
+func appendFlat(dst, s []any, depth int) []any {
+	if depth == 0 {
+		return append(dst, s...)
+	}
+
+	for _, v := range s {
+		if inner, ok := v.([]any); ok {
+			dst = appendFlat(dst, inner, depth-1)
+		} else {
+			dst = append(dst, v)
+		}
+	}
+
+	return dst
+}
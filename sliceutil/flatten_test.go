@@ -0,0 +1,91 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        [][]int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			expected: nil,
+		},
+		{
+			name:     "all empty",
+			s:        [][]int{{}, nil},
+			expected: nil,
+		},
+		{
+			name:     "mixed",
+			s:        [][]int{{1, 2}, {}, {3}},
+			expected: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Flatten(tt.s)
+			assert.Equal(t, tt.expected, got, tt.name)
+			assert.Equal(t, len(got), cap(got), "allocated at its final size")
+		})
+	}
+}
+
+func TestFlattenAny(t *testing.T) {
+	tree := []any{1, []any{2, []any{3, 4}}, "x", []any{}}
+
+	tests := []struct {
+		name     string
+		s        []any
+		depth    int
+		expected []any
+	}{
+		{
+			name:     "nil",
+			depth:    -1,
+			expected: nil,
+		},
+		{
+			name:     "depth 0 copies",
+			s:        tree,
+			depth:    0,
+			expected: tree,
+		},
+		{
+			name:     "one level",
+			s:        tree,
+			depth:    1,
+			expected: []any{1, 2, []any{3, 4}, "x"},
+		},
+		{
+			name:     "two levels",
+			s:        tree,
+			depth:    2,
+			expected: []any{1, 2, 3, 4, "x"},
+		},
+		{
+			name:     "fully",
+			s:        []any{[]any{[]any{[]any{1}}}, 2},
+			depth:    -1,
+			expected: []any{1, 2},
+		},
+		{
+			name:     "other slice types kept",
+			s:        []any{[]int{1, 2}, []string{"a"}},
+			depth:    -1,
+			expected: []any{[]int{1, 2}, []string{"a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlattenAny(tt.s, tt.depth)
+			assert.Equal(t, tt.expected, got, tt.name)
+			assert.Equal(t, len(got), cap(got), "allocated at its final size")
+		})
+	}
+}
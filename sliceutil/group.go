@@ -0,0 +1,79 @@
+package sliceutil
+
+// GroupBy buckets the elements of s by key. Each group keeps the elements in
+// the order they appear in s.
+//
+// Example:
+//
+//	sliceutil.GroupBy(orders, func(o Order) string { return o.Status })
+//	=> {"paid": [o1, o4], "pending": [o2], "refunded": [o3]}
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	_, groups := GroupByOrdered(s, key)
+	return groups
+}
+
+// GroupByOrdered is GroupBy that also returns the keys in the order they first
+// appear in s, for output that should be stable from run to run.
+//
+// Example:
+//
+//	keys, groups := sliceutil.GroupByOrdered(orders, Order.Status)
+//	for _, status := range keys {
+//		fmt.Println(status, len(groups[status]))
+//	}
+func GroupByOrdered[T any, K comparable](s []T, key func(T) K) ([]K, map[K][]T) {
+	var keys []K
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], v)
+	}
+
+	return keys, groups
+}
+
+// IndexOption configures IndexBy.
+type IndexOption func(*indexConfig)
+
+type indexConfig struct {
+	firstWins bool
+}
+
+// FirstWins makes IndexBy keep the first element seen for each key instead of
+// the last.
+func FirstWins() IndexOption {
+	return func(c *indexConfig) {
+		c.firstWins = true
+	}
+}
+
+// IndexBy maps each key to one element of s, for looking records up by a field
+// that is expected to be unique. When keys repeat the last element wins, as
+// with plain map assignment, unless FirstWins is given.
+//
+// Example:
+//
+//	byID := sliceutil.IndexBy(users, func(u User) int64 { return u.ID })
+//	byEmail := sliceutil.IndexBy(users, User.Email, sliceutil.FirstWins())
+func IndexBy[T any, K comparable](s []T, key func(T) K, opts ...IndexOption) map[K]T {
+	var c indexConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	index := make(map[K]T, len(s))
+	for _, v := range s {
+		k := key(v)
+		if c.firstWins {
+			if _, ok := index[k]; ok {
+				continue
+			}
+		}
+		index[k] = v
+	}
+
+	return index
+}
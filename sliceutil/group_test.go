@@ -0,0 +1,87 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID     int
+	Status string
+}
+
+func orderStatus(o order) string { return o.Status }
+
+func TestGroupBy(t *testing.T) {
+	orders := []order{{1, "paid"}, {2, "pending"}, {3, "refunded"}, {4, "paid"}}
+
+	tests := []struct {
+		name     string
+		s        []order
+		keys     []string
+		expected map[string][]order
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			keys:     nil,
+			expected: map[string][]order{},
+		},
+		{
+			name: "several groups",
+			s:    orders,
+			keys: []string{"paid", "pending", "refunded"},
+			expected: map[string][]order{
+				"paid":     {{1, "paid"}, {4, "paid"}},
+				"pending":  {{2, "pending"}},
+				"refunded": {{3, "refunded"}},
+			},
+		},
+		{
+			name:     "one group",
+			s:        orders[:1],
+			keys:     []string{"paid"},
+			expected: map[string][]order{"paid": {{1, "paid"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GroupBy(tt.s, orderStatus), tt.name)
+
+			keys, groups := GroupByOrdered(tt.s, orderStatus)
+			assert.Equal(t, tt.keys, keys, tt.name)
+			assert.Equal(t, tt.expected, groups, tt.name)
+		})
+	}
+}
+
+func TestIndexBy(t *testing.T) {
+	orders := []order{{1, "paid"}, {2, "pending"}, {3, "paid"}}
+
+	tests := []struct {
+		name     string
+		opts     []IndexOption
+		expected map[string]order
+	}{
+		{
+			name:     "last wins",
+			expected: map[string]order{"paid": {3, "paid"}, "pending": {2, "pending"}},
+		},
+		{
+			name:     "first wins",
+			opts:     []IndexOption{FirstWins()},
+			expected: map[string]order{"paid": {1, "paid"}, "pending": {2, "pending"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IndexBy(orders, orderStatus, tt.opts...), tt.name)
+		})
+	}
+
+	byID := IndexBy(orders, func(o order) int { return o.ID })
+	assert.Len(t, byID, 3)
+	assert.Equal(t, "pending", byID[2].Status)
+	assert.Empty(t, IndexBy(nil, orderStatus))
+}
@@ -0,0 +1,34 @@
+package sliceutil
+
+// Paginate returns page number page (counting from 1) of s, with perPage
+// elements per page, along with the total number of elements and of pages.
+//
+// Out-of-range arguments are clamped rather than rejected, so a stale or
+// hand-edited page number still shows something sensible:
+//
+//   - perPage below 1 is treated as 1
+//   - page below 1 is treated as 1
+//   - page past the last one returns the last page
+//   - an empty s has 0 pages and returns no items for any page
+//
+// items shares s's backing array and is capped at its own length.
+//
+// Example:
+//
+//	s := []int{1, 2, 3, 4, 5, 6, 7}
+//	sliceutil.Paginate(s, 2, 3) => [4, 5, 6], 7, 3
+//	sliceutil.Paginate(s, 9, 3) => [7], 7, 3
+func Paginate[T any](s []T, page, perPage int) (items []T, total, pages int) {
+	perPage = max(perPage, 1)
+	total = len(s)
+	pages = (total + perPage - 1) / perPage
+	if pages == 0 {
+		return nil, 0, 0
+	}
+
+	page = min(max(page, 1), pages)
+	start := (page - 1) * perPage
+	end := min(start+perPage, total)
+
+	return s[start:end:end], total, pages
+}
@@ -0,0 +1,106 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name    string
+		s       []int
+		page    int
+		perPage int
+		items   []int
+		total   int
+		pages   int
+	}{
+		{
+			name:    "first page",
+			s:       s,
+			page:    1,
+			perPage: 3,
+			items:   []int{1, 2, 3},
+			total:   7,
+			pages:   3,
+		},
+		{
+			name:    "middle page",
+			s:       s,
+			page:    2,
+			perPage: 3,
+			items:   []int{4, 5, 6},
+			total:   7,
+			pages:   3,
+		},
+		{
+			name:    "partial last page",
+			s:       s,
+			page:    3,
+			perPage: 3,
+			items:   []int{7},
+			total:   7,
+			pages:   3,
+		},
+		{
+			name:    "past the end clamps to last page",
+			s:       s,
+			page:    9,
+			perPage: 3,
+			items:   []int{7},
+			total:   7,
+			pages:   3,
+		},
+		{
+			name:    "page below 1 clamps to first page",
+			s:       s,
+			page:    -2,
+			perPage: 5,
+			items:   []int{1, 2, 3, 4, 5},
+			total:   7,
+			pages:   2,
+		},
+		{
+			name:    "perPage below 1 treated as 1",
+			s:       s,
+			page:    2,
+			perPage: 0,
+			items:   []int{2},
+			total:   7,
+			pages:   7,
+		},
+		{
+			name:    "exact fit",
+			s:       s[:6],
+			page:    2,
+			perPage: 3,
+			items:   []int{4, 5, 6},
+			total:   6,
+			pages:   2,
+		},
+		{
+			name:    "empty",
+			s:       nil,
+			page:    1,
+			perPage: 10,
+			items:   nil,
+			total:   0,
+			pages:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, total, pages := Paginate(tt.s, tt.page, tt.perPage)
+			assert.Equal(t, tt.items, items, tt.name)
+			assert.Equal(t, tt.total, total, tt.name)
+			assert.Equal(t, tt.pages, pages, tt.name)
+		})
+	}
+
+	items, _, _ := Paginate(s, 1, 3)
+	_ = append(items, 99)
+	assert.Equal(t, 4, s[3], "appending to a page doesn't overwrite s")
+}
@@ -0,0 +1,51 @@
+package sliceutil
+
+// Partition splits s into the elements for which pred returns true and the
+// rest, each in their original order.
+//
+// Example:
+//
+//	retry, fail := sliceutil.Partition(results, func(r Result) bool { return r.Retryable() })
+func Partition[T any](s []T, pred func(T) bool) (matched, rest []T) {
+	for _, v := range s {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+
+	return matched, rest
+}
+
+// PartitionBy splits s several ways by key. It returns len(keys)+1 slices: the
+// elements whose key is keys[i] go in slice i, and elements with any other key
+// go in the last one. Order within each slice is kept.
+//
+// Unlike GroupBy, the result has a fixed shape known at the call site, so it
+// can be unpacked by position and a key with no elements still gets a (nil)
+// slice.
+//
+// Example:
+//
+//	parts := sliceutil.PartitionBy(jobs, Job.Outcome, OutcomeRetry, OutcomeFatal)
+//	retry, fatal, done := parts[0], parts[1], parts[2]
+func PartitionBy[T any, K comparable](s []T, key func(T) K, keys ...K) [][]T {
+	index := make(map[K]int, len(keys))
+	for i, k := range keys {
+		if _, ok := index[k]; !ok {
+			index[k] = i
+		}
+	}
+
+	parts := make([][]T, len(keys)+1)
+	for _, v := range s {
+		i, ok := index[key(v)]
+		if !ok {
+			i = len(keys)
+		}
+		parts[i] = append(parts[i], v)
+	}
+
+	return parts
+}
@@ -0,0 +1,100 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartition(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name    string
+		s       []int
+		matched []int
+		rest    []int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:    "mixed",
+			s:       []int{1, 2, 3, 4, 5},
+			matched: []int{2, 4},
+			rest:    []int{1, 3, 5},
+		},
+		{
+			name:    "all match",
+			s:       []int{2, 4},
+			matched: []int{2, 4},
+			rest:    nil,
+		},
+		{
+			name:    "none match",
+			s:       []int{1},
+			matched: nil,
+			rest:    []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, rest := Partition(tt.s, isEven)
+			assert.Equal(t, tt.matched, matched, tt.name)
+			assert.Equal(t, tt.rest, rest, tt.name)
+		})
+	}
+}
+
+func TestPartitionBy(t *testing.T) {
+	type job struct {
+		ID      int
+		Outcome string
+	}
+	outcome := func(j job) string { return j.Outcome }
+	jobs := []job{{1, "retry"}, {2, "done"}, {3, "fatal"}, {4, "retry"}, {5, "skipped"}}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		expected [][]job
+	}{
+		{
+			name:     "no keys",
+			keys:     nil,
+			expected: [][]job{jobs},
+		},
+		{
+			name: "two keys",
+			keys: []string{"retry", "fatal"},
+			expected: [][]job{
+				{{1, "retry"}, {4, "retry"}},
+				{{3, "fatal"}},
+				{{2, "done"}, {5, "skipped"}},
+			},
+		},
+		{
+			name: "key with no elements",
+			keys: []string{"timeout", "done"},
+			expected: [][]job{
+				nil,
+				{{2, "done"}},
+				{{1, "retry"}, {3, "fatal"}, {4, "retry"}, {5, "skipped"}},
+			},
+		},
+		{
+			name: "repeated key goes to first slot",
+			keys: []string{"done", "done"},
+			expected: [][]job{
+				{{2, "done"}},
+				nil,
+				{{1, "retry"}, {3, "fatal"}, {4, "retry"}, {5, "skipped"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PartitionBy(jobs, outcome, tt.keys...), tt.name)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package sliceutil
+
+// Reverse reverses the order of the elements of s in place.
+func Reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Reversed returns a copy of s with the elements in reverse order. A nil s
+// gives a nil result.
+//
+// Example:
+//
+//	sliceutil.Reversed([]int{1, 2, 3}) => [3, 2, 1]
+func Reversed[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+
+	out := make([]T, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+
+	return out
+}
+
+// ReverseRange reverses s[lo:hi] in place, leaving the rest of s alone. It
+// panics if lo and hi are not valid slice bounds for s.
+//
+// Example:
+//
+//	s := []int{1, 2, 3, 4, 5}
+//	sliceutil.ReverseRange(s, 1, 4) // s == [1, 4, 3, 2, 5]
+func ReverseRange[T any](s []T, lo, hi int) {
+	Reverse(s[lo:hi])
+}
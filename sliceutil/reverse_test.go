@@ -0,0 +1,85 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			expected: nil,
+		},
+		{
+			name:     "one",
+			s:        []int{1},
+			expected: []int{1},
+		},
+		{
+			name:     "even length",
+			s:        []int{1, 2, 3, 4},
+			expected: []int{4, 3, 2, 1},
+		},
+		{
+			name:     "odd length",
+			s:        []int{1, 2, 3},
+			expected: []int{3, 2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orig []int
+			if tt.s != nil {
+				orig = append([]int{}, tt.s...)
+			}
+			assert.Equal(t, tt.expected, Reversed(tt.s), tt.name)
+			assert.Equal(t, orig, tt.s, "Reversed leaves s alone")
+
+			Reverse(tt.s)
+			assert.Equal(t, tt.expected, tt.s, tt.name)
+		})
+	}
+}
+
+func TestReverseRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		lo, hi   int
+		expected []int
+	}{
+		{
+			name:     "middle",
+			lo:       1,
+			hi:       4,
+			expected: []int{1, 4, 3, 2, 5},
+		},
+		{
+			name:     "whole slice",
+			lo:       0,
+			hi:       5,
+			expected: []int{5, 4, 3, 2, 1},
+		},
+		{
+			name:     "empty range",
+			lo:       2,
+			hi:       2,
+			expected: []int{1, 2, 3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := []int{1, 2, 3, 4, 5}
+			ReverseRange(s, tt.lo, tt.hi)
+			assert.Equal(t, tt.expected, s, tt.name)
+		})
+	}
+
+	assert.Panics(t, func() { ReverseRange([]int{1, 2}, 1, 3) })
+	assert.Panics(t, func() { ReverseRange([]int{1, 2}, 2, 1) })
+}
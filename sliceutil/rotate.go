@@ -0,0 +1,32 @@
+package sliceutil
+
+// Rotate shifts the elements of s left by k positions in place, moving the
+// first k elements to the end. A negative k shifts right instead, and k is
+// taken modulo len(s), so any value is accepted.
+//
+// It uses the three-reversal trick: reverse s[:k], reverse s[k:], then reverse
+// all of s. That is O(len(s)) with no extra memory.
+//
+// Example:
+//
+//	s := []int{1, 2, 3, 4, 5}
+//	sliceutil.Rotate(s, 2)  // s == [3, 4, 5, 1, 2]
+//	sliceutil.Rotate(s, -2) // s == [1, 2, 3, 4, 5]
+//
+//	// Round-robin: the worker that went first goes last next time.
+//	sliceutil.Rotate(workers, 1)
+func Rotate[T any](s []T, k int) {
+	if len(s) == 0 {
+		return
+	}
+	if k %= len(s); k < 0 {
+		k += len(s)
+	}
+	if k == 0 {
+		return
+	}
+
+	Reverse(s[:k])
+	Reverse(s[k:])
+	Reverse(s)
+}
@@ -0,0 +1,70 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		k        int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			k:        3,
+			expected: nil,
+		},
+		{
+			name:     "zero",
+			s:        []int{1, 2, 3},
+			k:        0,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "left",
+			s:        []int{1, 2, 3, 4, 5},
+			k:        2,
+			expected: []int{3, 4, 5, 1, 2},
+		},
+		{
+			name:     "right",
+			s:        []int{1, 2, 3, 4, 5},
+			k:        -2,
+			expected: []int{4, 5, 1, 2, 3},
+		},
+		{
+			name:     "full turn",
+			s:        []int{1, 2, 3},
+			k:        3,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "more than len",
+			s:        []int{1, 2, 3, 4, 5},
+			k:        12,
+			expected: []int{3, 4, 5, 1, 2},
+		},
+		{
+			name:     "negative more than len",
+			s:        []int{1, 2, 3, 4, 5},
+			k:        -7,
+			expected: []int{4, 5, 1, 2, 3},
+		},
+		{
+			name:     "single element",
+			s:        []int{1},
+			k:        5,
+			expected: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Rotate(tt.s, tt.k)
+			assert.Equal(t, tt.expected, tt.s, tt.name)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package sliceutil
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// BinarySearchBy searches s, sorted by key in ascending order, for an element
+// whose key is target. It returns the position of the first such element, or
+// where one would be inserted to keep s sorted, and whether it was found.
+//
+// Example:
+//
+//	// users sorted by ID
+//	i, ok := sliceutil.BinarySearchBy(users, 42, func(u User) int64 { return u.ID })
+func BinarySearchBy[T any, K cmp.Ordered](s []T, target K, key func(T) K) (int, bool) {
+	i := sort.Search(len(s), func(i int) bool { return key(s[i]) >= target })
+	return i, i < len(s) && key(s[i]) == target
+}
+
+// SortedInsert inserts v into s, which must be sorted in ascending order, and
+// returns the updated slice, still sorted. v goes after any elements equal to
+// it. Like append, it may reuse s's backing array.
+//
+// Example:
+//
+//	s := []int{1, 3, 5}
+//	s = sliceutil.SortedInsert(s, 4) // [1, 3, 4, 5]
+func SortedInsert[T cmp.Ordered](s []T, v T) []T {
+	return SortedInsertBy(s, v, identity[T])
+}
+
+// SortedInsertBy is SortedInsert for s sorted by key.
+func SortedInsertBy[T any, K cmp.Ordered](s []T, v T, key func(T) K) []T {
+	k := key(v)
+	i := sort.Search(len(s), func(i int) bool { return key(s[i]) > k })
+
+	return slices.Insert(s, i, v)
+}
@@ -0,0 +1,113 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinarySearchBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{2, "ann"}, {5, "bob"}, {5, "bo"}, {9, "cy"}}
+	byID := func(u user) int { return u.ID }
+
+	tests := []struct {
+		name     string
+		target   int
+		expected int
+		found    bool
+	}{
+		{
+			name:     "found",
+			target:   9,
+			expected: 3,
+			found:    true,
+		},
+		{
+			name:     "first of duplicates",
+			target:   5,
+			expected: 1,
+			found:    true,
+		},
+		{
+			name:     "before all",
+			target:   1,
+			expected: 0,
+		},
+		{
+			name:     "between",
+			target:   6,
+			expected: 3,
+		},
+		{
+			name:     "after all",
+			target:   10,
+			expected: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, found := BinarySearchBy(users, tt.target, byID)
+			assert.Equal(t, tt.expected, i, tt.name)
+			assert.Equal(t, tt.found, found, tt.name)
+		})
+	}
+
+	i, found := BinarySearchBy(nil, 3, byID)
+	assert.Equal(t, 0, i)
+	assert.False(t, found)
+}
+
+func TestSortedInsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		v        int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			v:        3,
+			expected: []int{3},
+		},
+		{
+			name:     "middle",
+			s:        []int{1, 3, 5},
+			v:        4,
+			expected: []int{1, 3, 4, 5},
+		},
+		{
+			name:     "front",
+			s:        []int{1, 3},
+			v:        0,
+			expected: []int{0, 1, 3},
+		},
+		{
+			name:     "back",
+			s:        []int{1, 3},
+			v:        7,
+			expected: []int{1, 3, 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SortedInsert(tt.s, tt.v), tt.name)
+		})
+	}
+
+	type task struct {
+		Priority int
+		Name     string
+	}
+	byPriority := func(t task) int { return t.Priority }
+
+	var tasks []task
+	for _, tk := range []task{{2, "b"}, {1, "a"}, {2, "c"}, {0, "z"}, {2, "d"}} {
+		tasks = SortedInsertBy(tasks, tk, byPriority)
+	}
+	assert.Equal(t, []task{{0, "z"}, {1, "a"}, {2, "b"}, {2, "c"}, {2, "d"}}, tasks, "equal keys keep insertion order")
+}
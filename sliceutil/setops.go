@@ -0,0 +1,99 @@
+package sliceutil
+
+// Intersect returns the distinct elements of a that are also in b, in the order
+// they first appear in a.
+//
+// Example:
+//
+//	sliceutil.Intersect([]int{3, 1, 2, 3}, []int{2, 3, 4}) => [3, 2]
+func Intersect[T comparable](a, b []T) []T {
+	return IntersectBy(a, b, identity[T])
+}
+
+// Union returns the distinct elements of a followed by those of b that aren't
+// in a, each in first-seen order.
+//
+// Example:
+//
+//	sliceutil.Union([]int{3, 1, 3}, []int{2, 1, 4}) => [3, 1, 2, 4]
+func Union[T comparable](a, b []T) []T {
+	return UnionBy(a, b, identity[T])
+}
+
+// Difference returns the distinct elements of a that are not in b, in the
+// order they first appear in a.
+//
+// Example:
+//
+//	sliceutil.Difference([]int{3, 1, 2, 1}, []int{2}) => [3, 1]
+func Difference[T comparable](a, b []T) []T {
+	return DifferenceBy(a, b, identity[T])
+}
+
+// IntersectBy is Intersect for elements compared by key. Elements are taken
+// from a.
+//
+// Example:
+//
+//	// Users in both lists, compared by ID.
+//	sliceutil.IntersectBy(admins, active, func(u User) int64 { return u.ID })
+func IntersectBy[T any, K comparable](a, b []T, key func(T) K) []T {
+	in := keySet(b, key)
+	return filterDistinct(a, key, func(k K) bool {
+		_, ok := in[k]
+		return ok
+	})
+}
+
+// UnionBy is Union for elements compared by key. For a key in both slices the
+// element from a is kept.
+func UnionBy[T any, K comparable](a, b []T, key func(T) K) []T {
+	seen := make(map[K]struct{}, len(a)+len(b))
+	var out []T
+	for _, s := range [][]T{a, b} {
+		for _, v := range s {
+			k := key(v)
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				out = append(out, v)
+			}
+		}
+	}
+
+	return out
+}
+
+// DifferenceBy is Difference for elements compared by key.
+func DifferenceBy[T any, K comparable](a, b []T, key func(T) K) []T {
+	in := keySet(b, key)
+	return filterDistinct(a, key, func(k K) bool {
+		_, ok := in[k]
+		return !ok
+	})
+}
+
+// keySet returns the set of keys of the elements of s.
+func keySet[T any, K comparable](s []T, key func(T) K) map[K]struct{} {
+	set := make(map[K]struct{}, len(s))
+	for _, v := range s {
+		set[key(v)] = struct{}{}
+	}
+
+	return set
+}
+
+// filterDistinct returns the first element of s for each key that keep accepts.
+func filterDistinct[T any, K comparable](s []T, key func(T) K, keep func(K) bool) []T {
+	seen := make(map[K]struct{})
+	var out []T
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok || !keep(k) {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+
+	return out
+}
@@ -0,0 +1,73 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOps(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       []int
+		intersect  []int
+		union      []int
+		difference []int
+	}{
+		{
+			name: "both empty",
+		},
+		{
+			name:       "b empty",
+			a:          []int{2, 1, 2},
+			intersect:  nil,
+			union:      []int{2, 1},
+			difference: []int{2, 1},
+		},
+		{
+			name:       "a empty",
+			b:          []int{1, 1},
+			intersect:  nil,
+			union:      []int{1},
+			difference: nil,
+		},
+		{
+			name:       "overlap",
+			a:          []int{3, 1, 2, 3},
+			b:          []int{2, 3, 4},
+			intersect:  []int{3, 2},
+			union:      []int{3, 1, 2, 4},
+			difference: []int{1},
+		},
+		{
+			name:       "disjoint",
+			a:          []int{1, 2},
+			b:          []int{3},
+			intersect:  nil,
+			union:      []int{1, 2, 3},
+			difference: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.intersect, Intersect(tt.a, tt.b), "intersect")
+			assert.Equal(t, tt.union, Union(tt.a, tt.b), "union")
+			assert.Equal(t, tt.difference, Difference(tt.a, tt.b), "difference")
+		})
+	}
+}
+
+func TestSetOpsBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	byID := func(u user) int { return u.ID }
+
+	admins := []user{{1, "ann"}, {2, "bob"}, {1, "ann again"}}
+	active := []user{{2, "bob (active)"}, {3, "cy"}}
+
+	assert.Equal(t, []user{{2, "bob"}}, IntersectBy(admins, active, byID), "taken from a")
+	assert.Equal(t, []user{{1, "ann"}, {2, "bob"}, {3, "cy"}}, UnionBy(admins, active, byID), "a wins")
+	assert.Equal(t, []user{{1, "ann"}}, DifferenceBy(admins, active, byID))
+}
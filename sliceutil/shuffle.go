@@ -0,0 +1,58 @@
+package sliceutil
+
+import "math/rand/v2"
+
+// Shuffle randomly reorders s in place with a Fisher–Yates shuffle, so every
+// permutation is equally likely. It uses the math/rand/v2 global source; use
+// ShuffleWith for a reproducible order.
+func Shuffle[T any](s []T) {
+	shuffle(s, len(s), rand.IntN)
+}
+
+// ShuffleWith is Shuffle drawing from r, for seeded shuffles in tests and
+// load generators.
+//
+// Example:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	sliceutil.ShuffleWith(fixtures, r) // same order on every run
+func ShuffleWith[T any](s []T, r *rand.Rand) {
+	shuffle(s, len(s), r.IntN)
+}
+
+// Sample returns n elements of s picked at random without replacement, in
+// random order. It returns all of s, shuffled, if n is at least len(s), and nil
+// if n is 0 or less. s itself is not modified.
+//
+// Example:
+//
+//	sliceutil.Sample([]string{"a", "b", "c", "d"}, 2) => e.g. ["c", "a"]
+func Sample[T any](s []T, n int) []T {
+	return sample(s, n, rand.IntN)
+}
+
+// SampleWith is Sample drawing from r.
+func SampleWith[T any](s []T, n int, r *rand.Rand) []T {
+	return sample(s, n, r.IntN)
+}
+
+func sample[T any](s []T, n int, intN func(int) int) []T {
+	n = min(n, len(s))
+	if n <= 0 {
+		return nil
+	}
+
+	out := append(make([]T, 0, len(s)), s...)
+	shuffle(out, n, intN)
+
+	return out[:n:n]
+}
+
+// shuffle runs the first n steps of a Fisher–Yates shuffle, leaving a uniform
+// random sample of s, in random order, in s[:n].
+func shuffle[T any](s []T, n int, intN func(int) int) {
+	for i := range n {
+		j := i + intN(len(s)-i)
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,91 @@
+package sliceutil
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	Shuffle(s)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, s)
+
+	a := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	b := slices.Clone(a)
+	ShuffleWith(a, rand.New(rand.NewPCG(1, 2)))
+	ShuffleWith(b, rand.New(rand.NewPCG(1, 2)))
+	assert.Equal(t, a, b, "same seed, same order")
+
+	Shuffle([]int(nil))
+	Shuffle([]int{1})
+}
+
+func TestShuffleUniform(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	counts := map[[3]int]int{}
+	const rounds = 60_000
+	for range rounds {
+		s := []int{1, 2, 3}
+		ShuffleWith(s, r)
+		counts[[3]int(s)]++
+	}
+
+	assert.Len(t, counts, 6, "every permutation appears")
+	for perm, n := range counts {
+		assert.InDelta(t, rounds/6, n, rounds/60, "permutation %v", perm)
+	}
+}
+
+func TestSample(t *testing.T) {
+	s := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name string
+		n    int
+		len  int
+	}{
+		{
+			name: "negative",
+			n:    -1,
+			len:  0,
+		},
+		{
+			name: "zero",
+			n:    0,
+			len:  0,
+		},
+		{
+			name: "some",
+			n:    2,
+			len:  2,
+		},
+		{
+			name: "all",
+			n:    4,
+			len:  4,
+		},
+		{
+			name: "more than len",
+			n:    10,
+			len:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sample(s, tt.n)
+			assert.Len(t, got, tt.len, tt.name)
+			assert.Len(t, Unique(got), tt.len, "without replacement")
+			for _, v := range got {
+				assert.Contains(t, s, v, tt.name)
+			}
+		})
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, s, "input unchanged")
+	assert.Nil(t, Sample([]int(nil), 3))
+
+	r1, r2 := rand.New(rand.NewPCG(5, 6)), rand.New(rand.NewPCG(5, 6))
+	assert.Equal(t, SampleWith(s, 3, r1), SampleWith(s, 3, r2), "same seed, same sample")
+}
@@ -0,0 +1,114 @@
+// Package sliceutil provides generic helpers for transforming slices that the
+// standard slices package leaves out.
+//
+// Most helpers leave their input alone and return new slices, so they can be
+// chained freely:
+//
+//	names := sliceutil.Map(sliceutil.Filter(users, User.IsActive), User.Name)
+//
+// The exceptions say so in their names or docs:
+//
+//   - Reverse, ReverseRange, Rotate, Shuffle, ShuffleWith and the ...InPlace
+//     helpers modify s itself. The InPlace helpers return s shortened, and the
+//     original s must not be used afterwards.
+//   - SortedInsert and SortedInsertBy may reuse s's backing array, like append.
+//   - Chunk, ChunkFunc, Windows, Paginate and ProcessInBatches hand out
+//     sub-slices of s rather than copies. Each is capped at its own length, so
+//     appending to one never overwrites s, but writing to its elements does.
+package sliceutil
+
+// Map returns a slice holding fn applied to each element of s, in order. A nil
+// s gives a nil result.
+//
+// Example:
+//
+//	sliceutil.Map([]int{1, 2, 3}, strconv.Itoa) => ["1", "2", "3"]
+func Map[T, U any](s []T, fn func(T) U) []U {
+	if s == nil {
+		return nil
+	}
+
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+
+	return out
+}
+
+// MapErr is like Map for a fn that can fail. It stops at the first error and
+// returns it with a nil slice.
+//
+// Example:
+//
+//	ports, err := sliceutil.MapErr([]string{"80", "443"}, strconv.Atoi) => [80, 443], nil
+func MapErr[T, U any](s []T, fn func(T) (U, error)) ([]U, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	out := make([]U, len(s))
+	for i, v := range s {
+		u, err := fn(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = u
+	}
+
+	return out, nil
+}
+
+// Filter returns the elements of s for which keep returns true, in order. It
+// returns nil if there are none.
+//
+// Example:
+//
+//	sliceutil.Filter([]int{1, 2, 3, 4}, isEven) => [2, 4]
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var out []T
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// Reduce folds s into a single value, calling fn with the running result and
+// each element in order, starting from initial.
+//
+// Example:
+//
+//	sliceutil.Reduce([]int{1, 2, 3}, 0, func(sum, v int) int { return sum + v }) => 6
+func Reduce[T, A any](s []T, initial A, fn func(A, T) A) A {
+	acc := initial
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+
+	return acc
+}
+
+// FlatMap applies fn to each element of s and concatenates the results. It
+// returns nil if every result is empty.
+//
+// Example:
+//
+//	sliceutil.FlatMap(orders, func(o Order) []Item { return o.Items }) => all items, order by order
+func FlatMap[T, U any](s []T, fn func(T) []U) []U {
+	var out []U
+	for _, v := range s {
+		out = append(out, fn(v)...)
+	}
+
+	return out
+}
+
+// ForEach calls fn with the index and value of each element of s, in order.
+func ForEach[T any](s []T, fn func(int, T)) {
+	for i, v := range s {
+		fn(i, v)
+	}
+}
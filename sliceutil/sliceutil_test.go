@@ -0,0 +1,155 @@
+package sliceutil
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		expected []string
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			expected: nil,
+		},
+		{
+			name:     "empty",
+			s:        []int{},
+			expected: []string{},
+		},
+		{
+			name:     "values",
+			s:        []int{1, 2, 3},
+			expected: []string{"1", "2", "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Map(tt.s, strconv.Itoa), tt.name)
+		})
+	}
+}
+
+func TestMapErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []string
+		expected []int
+		err      string
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			expected: nil,
+		},
+		{
+			name:     "values",
+			s:        []string{"80", "443"},
+			expected: []int{80, 443},
+		},
+		{
+			name: "first error",
+			s:    []string{"80", "http", "x"},
+			err:  `strconv.Atoi: parsing "http": invalid syntax`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MapErr(tt.s, strconv.Atoi)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err, tt.name)
+				assert.Nil(t, got, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, tt.expected, got, tt.name)
+		})
+	}
+
+	calls := 0
+	_, err := MapErr([]int{1, 2, 3}, func(v int) (int, error) {
+		calls++
+		if v == 2 {
+			return 0, errors.New("stop")
+		}
+		return v, nil
+	})
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, 2, calls, "stops at the first error")
+}
+
+func TestFilter(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name     string
+		s        []int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			expected: nil,
+		},
+		{
+			name:     "some match",
+			s:        []int{1, 2, 3, 4},
+			expected: []int{2, 4},
+		},
+		{
+			name:     "none match",
+			s:        []int{1, 3},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Filter(tt.s, isEven), tt.name)
+		})
+	}
+
+	s := []int{1, 2, 3, 4}
+	Filter(s, isEven)
+	assert.Equal(t, []int{1, 2, 3, 4}, s, "input unchanged")
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+
+	assert.Equal(t, 6, Reduce([]int{1, 2, 3}, 0, sum))
+	assert.Equal(t, 10, Reduce(nil, 10, sum))
+	assert.Equal(t, "abc", Reduce([]string{"a", "b", "c"}, "", func(acc, v string) string { return acc + v }))
+	assert.Equal(t, 3, Reduce([]string{"a", "bb"}, 0, func(n int, s string) int { return n + len(s) }))
+}
+
+func TestFlatMap(t *testing.T) {
+	repeat := func(v int) []int {
+		out := make([]int, v)
+		for i := range out {
+			out[i] = v
+		}
+		return out
+	}
+
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 3}, FlatMap([]int{1, 2, 3}, repeat))
+	assert.Equal(t, []int{2, 2}, FlatMap([]int{0, 2, 0}, repeat))
+	assert.Nil(t, FlatMap([]int{0, 0}, repeat))
+	assert.Nil(t, FlatMap(nil, repeat))
+}
+
+func TestForEach(t *testing.T) {
+	var got []string
+	ForEach([]string{"a", "b"}, func(i int, v string) {
+		got = append(got, strconv.Itoa(i)+v)
+	})
+	assert.Equal(t, []string{"0a", "1b"}, got)
+
+	ForEach(nil, func(int, string) { t.Fatal("called for nil slice") })
+}
@@ -0,0 +1,109 @@
+package sliceutil
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TopN returns the n greatest elements of s according to less, greatest first,
+// without sorting all of s. It keeps a heap of the best n seen so far, so it
+// runs in O(len(s) log n) time and O(n) extra space. s is not modified.
+//
+// It returns all of s, sorted greatest first, if n is at least len(s), and nil
+// if n is 0 or less. The order of equal elements is unspecified.
+//
+// Example:
+//
+//	// Ten slowest requests.
+//	sliceutil.TopN(reqs, 10, func(a, b Request) bool { return a.Latency < b.Latency })
+func TopN[T any](s []T, n int, less func(a, b T) bool) []T {
+	n = min(n, len(s))
+	if n <= 0 {
+		return nil
+	}
+
+	// h is a min-heap: h[0] is the smallest of the best n so far, the one to
+	// evict when something greater comes along.
+	h := make([]T, 0, n)
+	for _, v := range s {
+		switch {
+		case len(h) < n:
+			h = append(h, v)
+			siftUp(h, len(h)-1, less)
+		case less(h[0], v):
+			h[0] = v
+			siftDown(h, 0, less)
+		}
+	}
+
+	slices.SortFunc(h, func(a, b T) int {
+		switch {
+		case less(b, a):
+			return -1
+		case less(a, b):
+			return 1
+		}
+		return 0
+	})
+
+	return h
+}
+
+func siftUp[T any](h []T, i int, less func(a, b T) bool) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !less(h[i], h[parent]) {
+			return
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+func siftDown[T any](h []T, i int, less func(a, b T) bool) {
+	for {
+		smallest := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < len(h) && less(h[child], h[smallest]) {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+}
+
+// MinBy returns the element of s with the smallest key, the first one if
+// several share it. ok is false if s is empty.
+//
+// Example:
+//
+//	cheapest, ok := sliceutil.MinBy(offers, func(o Offer) int { return o.PriceCents })
+func MinBy[T any, K cmp.Ordered](s []T, key func(T) K) (v T, ok bool) {
+	return extremeBy(s, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy returns the element of s with the largest key, the first one if several
+// share it. ok is false if s is empty.
+func MaxBy[T any, K cmp.Ordered](s []T, key func(T) K) (v T, ok bool) {
+	return extremeBy(s, key, func(a, b K) bool { return a > b })
+}
+
+func extremeBy[T any, K cmp.Ordered](s []T, key func(T) K, better func(a, b K) bool) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	best, bestKey := s[0], key(s[0])
+	for _, v := range s[1:] {
+		if k := key(v); better(k, bestKey) {
+			best, bestKey = v, k
+		}
+	}
+
+	return best, true
+}
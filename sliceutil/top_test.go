@@ -0,0 +1,113 @@
+package sliceutil
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopN(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	tests := []struct {
+		name     string
+		s        []int
+		n        int
+		expected []int
+	}{
+		{
+			name:     "nil",
+			n:        3,
+			expected: nil,
+		},
+		{
+			name:     "zero",
+			s:        []int{1, 2},
+			n:        0,
+			expected: nil,
+		},
+		{
+			name:     "top three",
+			s:        []int{5, 1, 9, 3, 7, 2, 8},
+			n:        3,
+			expected: []int{9, 8, 7},
+		},
+		{
+			name:     "with duplicates",
+			s:        []int{4, 4, 1, 4, 2},
+			n:        2,
+			expected: []int{4, 4},
+		},
+		{
+			name:     "more than len",
+			s:        []int{2, 3, 1},
+			n:        5,
+			expected: []int{3, 2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TopN(tt.s, tt.n, less), tt.name)
+		})
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = r.IntN(500)
+	}
+	orig := slices.Clone(s)
+	sorted := slices.Clone(s)
+	slices.SortFunc(sorted, func(a, b int) int { return b - a })
+
+	assert.Equal(t, sorted[:25], TopN(s, 25, less))
+	assert.Equal(t, orig, s, "input unchanged")
+	assert.Equal(t, []int{1, 2}, TopN([]int{3, 1, 2}, 2, func(a, b int) bool { return a > b }), "reversed less gives the smallest")
+}
+
+func TestMinMaxBy(t *testing.T) {
+	type offer struct {
+		Shop  string
+		Price int
+	}
+	price := func(o offer) int { return o.Price }
+
+	tests := []struct {
+		name string
+		s    []offer
+		min  offer
+		max  offer
+		ok   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "one",
+			s:    []offer{{"a", 5}},
+			min:  offer{"a", 5},
+			max:  offer{"a", 5},
+			ok:   true,
+		},
+		{
+			name: "first of ties",
+			s:    []offer{{"a", 5}, {"b", 3}, {"c", 9}, {"d", 3}, {"e", 9}},
+			min:  offer{"b", 3},
+			max:  offer{"c", 9},
+			ok:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MinBy(tt.s, price)
+			assert.Equal(t, tt.ok, ok, tt.name)
+			assert.Equal(t, tt.min, got, tt.name)
+
+			got, ok = MaxBy(tt.s, price)
+			assert.Equal(t, tt.ok, ok, tt.name)
+			assert.Equal(t, tt.max, got, tt.name)
+		})
+	}
+}
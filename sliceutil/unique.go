@@ -0,0 +1,60 @@
+package sliceutil
+
+// Unique returns the distinct elements of s in the order they first appear, in
+// a new slice. s is not modified.
+//
+// Example:
+//
+//	sliceutil.Unique([]string{"b", "a", "b", "c", "a"}) => ["b", "a", "c"]
+func Unique[T comparable](s []T) []T {
+	return UniqueBy(s, identity[T])
+}
+
+// UniqueBy is Unique for elements compared by key, keeping the first element
+// seen for each key.
+//
+// Example:
+//
+//	sliceutil.UniqueBy(users, func(u User) int64 { return u.ID })
+func UniqueBy[T any, K comparable](s []T, key func(T) K) []T {
+	if s == nil {
+		return nil
+	}
+
+	return UniqueByInPlace(append(make([]T, 0, len(s)), s...), key)
+}
+
+// UniqueInPlace is Unique that modifies s instead of allocating a new slice. It
+// moves the distinct elements to the front of s and returns s shortened to them,
+// sharing s's backing array. The contents of s beyond that are zeroed, so s and
+// any other slice of the same array should not be used afterwards.
+//
+// Example:
+//
+//	tags = sliceutil.UniqueInPlace(tags)
+func UniqueInPlace[T comparable](s []T) []T {
+	return UniqueByInPlace(s, identity[T])
+}
+
+// UniqueByInPlace is UniqueBy that modifies s and returns a shortened slice of
+// it, like UniqueInPlace.
+func UniqueByInPlace[T any, K comparable](s []T, key func(T) K) []T {
+	seen := make(map[K]struct{}, len(s))
+	n := 0
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		s[n] = v
+		n++
+	}
+	clear(s[n:])
+
+	return s[:n]
+}
+
+func identity[T any](v T) T {
+	return v
+}
@@ -0,0 +1,70 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []string
+		expected []string
+	}{
+		{
+			name:     "nil",
+			s:        nil,
+			expected: nil,
+		},
+		{
+			name:     "no duplicates",
+			s:        []string{"a", "b"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "first-seen order",
+			s:        []string{"b", "a", "b", "c", "a"},
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name:     "all the same",
+			s:        []string{"x", "x", "x"},
+			expected: []string{"x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var in []string
+			if tt.s != nil {
+				in = append([]string{}, tt.s...)
+			}
+			assert.Equal(t, tt.expected, Unique(in), tt.name)
+			assert.Equal(t, tt.s, in, "input unchanged")
+
+			assert.Equal(t, tt.expected, UniqueInPlace(in), tt.name)
+		})
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	byID := func(u user) int { return u.ID }
+
+	users := []user{{1, "ann"}, {2, "bob"}, {1, "ann (dup)"}, {3, "cy"}, {2, "bob (dup)"}}
+	expected := []user{{1, "ann"}, {2, "bob"}, {3, "cy"}}
+
+	assert.Equal(t, expected, UniqueBy(users, byID))
+	assert.Equal(t, user{1, "ann (dup)"}, users[2], "input unchanged")
+
+	got := UniqueByInPlace(users, byID)
+	assert.Equal(t, expected, got)
+	assert.Same(t, &users[0], &got[0], "reuses the backing array")
+	assert.Equal(t, []user{{}, {}}, users[3:], "tail zeroed")
+
+	assert.Nil(t, UniqueBy(nil, byID))
+	assert.Empty(t, UniqueByInPlace([]user{}, byID))
+}
@@ -0,0 +1,35 @@
+package sliceutil
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Windows yields the windows of size consecutive elements of s, starting every
+// step elements. With step < size the windows overlap; with step > size
+// elements are skipped. Only full windows are yielded, so a slice shorter than
+// size yields nothing. It panics if size or step is less than 1.
+//
+// Windows are produced lazily and share s's backing array, capped at their own
+// length, so no window is copied.
+//
+// Example:
+//
+//	for w := range sliceutil.Windows([]int{1, 2, 3, 4, 5}, 3, 1) {
+//		fmt.Println(w) // [1 2 3], [2 3 4], [3 4 5]
+//	}
+//
+//	sliceutil.Windows(words, 2, 1) => bigrams
+func Windows[T any](s []T, size, step int) iter.Seq[[]T] {
+	if size < 1 || step < 1 {
+		panic(fmt.Sprintf("sliceutil: window size %d and step %d must be positive", size, step))
+	}
+
+	return func(yield func([]T) bool) {
+		for start := 0; start+size <= len(s); start += step {
+			if !yield(s[start : start+size : start+size]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package sliceutil
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindows(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		size     int
+		step     int
+		expected [][]int
+	}{
+		{
+			name:     "nil",
+			size:     2,
+			step:     1,
+			expected: nil,
+		},
+		{
+			name:     "shorter than window",
+			s:        []int{1, 2},
+			size:     3,
+			step:     1,
+			expected: nil,
+		},
+		{
+			name:     "overlapping",
+			s:        []int{1, 2, 3, 4, 5},
+			size:     3,
+			step:     1,
+			expected: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		},
+		{
+			name:     "step equals size",
+			s:        []int{1, 2, 3, 4, 5},
+			size:     2,
+			step:     2,
+			expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:     "step larger than size",
+			s:        []int{1, 2, 3, 4, 5, 6},
+			size:     1,
+			step:     3,
+			expected: [][]int{{1}, {4}},
+		},
+		{
+			name:     "exact fit",
+			s:        []int{1, 2},
+			size:     2,
+			step:     5,
+			expected: [][]int{{1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slices.Collect(Windows(tt.s, tt.size, tt.step)), tt.name)
+		})
+	}
+}
+
+func TestWindowsEarlyStop(t *testing.T) {
+	var got [][]int
+	for w := range Windows([]int{1, 2, 3, 4}, 2, 1) {
+		got = append(got, w)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, [][]int{{1, 2}, {2, 3}}, got)
+
+	s := []int{1, 2, 3}
+	for w := range Windows(s, 2, 1) {
+		_ = append(w, 99)
+	}
+	assert.Equal(t, []int{1, 2, 3}, s, "appending to a window doesn't overwrite s")
+
+	assert.PanicsWithValue(t, "sliceutil: window size 0 and step 1 must be positive", func() { Windows(s, 0, 1) })
+	assert.Panics(t, func() { Windows(s, 1, 0) })
+}
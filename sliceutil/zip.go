@@ -0,0 +1,61 @@
+package sliceutil
+
+import "github.com/vk4s/goutils/tuple"
+
+// Zip pairs up the elements of a and b by index. If the slices differ in
+// length the extra elements of the longer one are dropped.
+//
+// Example:
+//
+//	sliceutil.Zip([]string{"id", "name"}, []any{7, "ann"}) => [(id, 7), (name, ann)]
+func Zip[A, B any](a []A, b []B) []tuple.Pair[A, B] {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+
+	pairs := make([]tuple.Pair[A, B], n)
+	for i := range n {
+		pairs[i] = tuple.NewPair(a[i], b[i])
+	}
+
+	return pairs
+}
+
+// Unzip splits pairs back into two slices, the reverse of Zip.
+//
+// Example:
+//
+//	keys, values := sliceutil.Unzip(pairs)
+func Unzip[A, B any](pairs []tuple.Pair[A, B]) ([]A, []B) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.Unpack()
+	}
+
+	return a, b
+}
+
+// Pairwise returns each element of s paired with the one after it. A slice
+// with fewer than two elements has no pairs.
+//
+// Example:
+//
+//	sliceutil.Pairwise([]int{1, 4, 9}) => [(1, 4), (4, 9)]
+func Pairwise[T any](s []T) []tuple.Pair[T, T] {
+	if len(s) < 2 {
+		return nil
+	}
+
+	pairs := make([]tuple.Pair[T, T], len(s)-1)
+	for i := range pairs {
+		pairs[i] = tuple.NewPair(s[i], s[i+1])
+	}
+
+	return pairs
+}
@@ -0,0 +1,95 @@
+package sliceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk4s/goutils/tuple"
+)
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []int
+		expected []tuple.Pair[string, int]
+	}{
+		{
+			name:     "nil",
+			expected: nil,
+		},
+		{
+			name: "same length",
+			a:    []string{"a", "b"},
+			b:    []int{1, 2},
+			expected: []tuple.Pair[string, int]{
+				{First: "a", Second: 1},
+				{First: "b", Second: 2},
+			},
+		},
+		{
+			name:     "a longer",
+			a:        []string{"a", "b", "c"},
+			b:        []int{1},
+			expected: []tuple.Pair[string, int]{{First: "a", Second: 1}},
+		},
+		{
+			name:     "b longer",
+			a:        []string{"a"},
+			b:        []int{1, 2},
+			expected: []tuple.Pair[string, int]{{First: "a", Second: 1}},
+		},
+		{
+			name:     "one empty",
+			a:        []string{"a"},
+			b:        []int{},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Zip(tt.a, tt.b), tt.name)
+		})
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := Unzip(Zip([]string{"id", "name"}, []any{7, "ann"}))
+	assert.Equal(t, []string{"id", "name"}, a)
+	assert.Equal(t, []any{7, "ann"}, b)
+
+	a, b = Unzip[string, any](nil)
+	assert.Nil(t, a)
+	assert.Nil(t, b)
+}
+
+func TestPairwise(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []int
+		expected []tuple.Pair[int, int]
+	}{
+		{
+			name:     "nil",
+			expected: nil,
+		},
+		{
+			name:     "one element",
+			s:        []int{1},
+			expected: nil,
+		},
+		{
+			name: "several",
+			s:    []int{1, 4, 9},
+			expected: []tuple.Pair[int, int]{
+				{First: 1, Second: 4},
+				{First: 4, Second: 9},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Pairwise(tt.s), tt.name)
+		})
+	}
+}
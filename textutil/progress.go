@@ -0,0 +1,265 @@
+package textutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	barWidth        = 30
+	refreshInterval = 100 * time.Millisecond
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress draws one or more progress bars and spinners on a terminal.
+//
+// Bars are redrawn in place every 100ms until Stop is called. If the writer is
+// not a terminal (a pipe, a file, a CI log), Progress is disabled: bars still
+// count, but nothing is ever written, so the same code can run interactively and
+// in scripts. Use SetEnabled to override the detection.
+//
+// All methods are safe for concurrent use.
+//
+// Example:
+//
+//	p := textutil.NewProgress(os.Stderr)
+//	defer p.Stop()
+//
+//	bar := p.AddBar("download", resp.ContentLength)
+//	io.Copy(f, io.TeeReader(resp.Body, bar))
+//	bar.Done()
+type Progress struct {
+	w   io.Writer
+	now func() time.Time
+
+	mu      sync.Mutex
+	enabled bool
+	bars    []*Bar
+	lines   int
+	frame   int
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewProgress returns a Progress writing to w, enabled only if w is a terminal.
+func NewProgress(w io.Writer) *Progress {
+	return &Progress{w: w, now: time.Now, enabled: isTerminal(w)}
+}
+
+// isTerminal reports whether w is a character device such as a TTY.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetEnabled forces drawing on or off regardless of whether the writer is a
+// terminal. It must be called before the first bar is added.
+func (p *Progress) SetEnabled(enabled bool) *Progress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.enabled = enabled
+	return p
+}
+
+// Enabled reports whether p draws anything.
+func (p *Progress) Enabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.enabled
+}
+
+// AddBar adds a determinate bar that is complete once total units have been
+// added. A total of 0 or less makes it a spinner, as with AddSpinner.
+func (p *Progress) AddBar(label string, total int64) *Bar {
+	b := &Bar{label: label, start: p.now(), now: p.now}
+	b.total.Store(total)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bars = append(p.bars, b)
+	if p.enabled && p.stop == nil {
+		p.stop = make(chan struct{})
+		p.stopped = make(chan struct{})
+		go p.run(p.stop, p.stopped)
+	}
+
+	return b
+}
+
+// AddSpinner adds an indeterminate indicator for work of unknown size. It
+// shows how many units were added, the rate, and the elapsed time.
+func (p *Progress) AddSpinner(label string) *Bar {
+	return p.AddBar(label, 0)
+}
+
+// Stop draws the final state of all bars and stops redrawing. It is safe to
+// call more than once.
+func (p *Progress) Stop() {
+	p.mu.Lock()
+	stop, stopped := p.stop, p.stopped
+	p.stop = nil
+	p.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-stopped
+}
+
+func (p *Progress) run(stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			p.draw()
+			return
+		case <-ticker.C:
+			p.draw()
+		}
+	}
+}
+
+// draw redraws every bar in place, moving the cursor back up over the previous frame.
+func (p *Progress) draw() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	labelWidth := 0
+	for _, b := range p.bars {
+		labelWidth = max(labelWidth, StringWidth(b.label))
+	}
+
+	var out strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&out, "\x1b[%dA", p.lines)
+	}
+	now := p.now()
+	for _, b := range p.bars {
+		out.WriteString("\r\x1b[2K")
+		out.WriteString(b.render(now, labelWidth, p.frame))
+		out.WriteByte('\n')
+	}
+	p.lines = len(p.bars)
+	p.frame++
+
+	io.WriteString(p.w, out.String())
+}
+
+// Bar tracks the progress of one task in a Progress. It implements io.Writer,
+// counting the bytes written, so it can be used with io.Copy or io.TeeReader.
+type Bar struct {
+	label   string
+	start   time.Time
+	now     func() time.Time
+	total   atomic.Int64
+	current atomic.Int64
+	end     atomic.Pointer[time.Time]
+}
+
+// Add advances the bar by n units.
+func (b *Bar) Add(n int64) {
+	b.current.Add(n)
+}
+
+// Increment advances the bar by one unit.
+func (b *Bar) Increment() {
+	b.current.Add(1)
+}
+
+// SetCurrent sets the number of completed units.
+func (b *Bar) SetCurrent(n int64) {
+	b.current.Store(n)
+}
+
+// SetTotal changes the total, for example once a download's size is known.
+func (b *Bar) SetTotal(total int64) {
+	b.total.Store(total)
+}
+
+// Current returns the number of completed units.
+func (b *Bar) Current() int64 {
+	return b.current.Load()
+}
+
+// Write counts len(data) units and never fails.
+func (b *Bar) Write(data []byte) (int, error) {
+	b.current.Add(int64(len(data)))
+	return len(data), nil
+}
+
+// Done marks the task as finished; a determinate bar is shown as full.
+func (b *Bar) Done() {
+	now := b.now()
+	b.end.CompareAndSwap(nil, &now)
+}
+
+// render formats a single line for the bar as of now.
+func (b *Bar) render(now time.Time, labelWidth, frame int) string {
+	done := b.end.Load() != nil
+	if done {
+		now = *b.end.Load()
+	}
+
+	elapsed := now.Sub(b.start)
+	current, total := b.current.Load(), b.total.Load()
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(current) / elapsed.Seconds()
+	}
+	label := pad(b.label, labelWidth, AlignLeft)
+
+	if total <= 0 {
+		icon := spinnerFrames[frame%len(spinnerFrames)]
+		if done {
+			icon = "✓"
+		}
+		return fmt.Sprintf("%s %s %d (%.1f/s, %s)", icon, label, current, rate, formatDuration(elapsed))
+	}
+
+	if done {
+		current = max(current, total)
+	}
+	fraction := max(0, min(float64(current)/float64(total), 1))
+	filled := int(fraction * barWidth)
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+
+	status := "ETA ?"
+	switch {
+	case done:
+		status = "done in " + formatDuration(elapsed)
+	case rate > 0:
+		remaining := time.Duration(float64(total-current) / rate * float64(time.Second))
+		status = "ETA " + formatDuration(remaining)
+	}
+
+	return fmt.Sprintf("%s [%s] %3d%% %.1f/s %s", label, bar, int(fraction*100), rate, status)
+}
+
+func formatDuration(d time.Duration) string {
+	return max(d, 0).Round(time.Second).String()
+}
@@ -0,0 +1,138 @@
+package textutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a manually advanced clock for deterministic rates and ETAs.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestProgress(out io.Writer) (*Progress, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	p := NewProgress(out)
+	p.now = clock.Now
+	return p, clock
+}
+
+func TestProgressRender(t *testing.T) {
+	var out bytes.Buffer
+	p, clock := newTestProgress(&out)
+
+	bar := p.AddBar("download", 200)
+	spin := p.AddSpinner("scan")
+
+	clock.Advance(10 * time.Second)
+	bar.Add(50)
+	spin.Add(25)
+	p.draw()
+
+	assert.Equal(t, ""+
+		"\r\x1b[2Kdownload [=======>                      ]  25% 5.0/s ETA 30s\n"+
+		"\r\x1b[2K⠋ scan     25 (2.5/s, 10s)\n", out.String())
+
+	out.Reset()
+	clock.Advance(5 * time.Second)
+	bar.Done()
+	spin.Done()
+	p.draw()
+
+	assert.Equal(t, "\x1b[2A"+
+		"\r\x1b[2Kdownload [==============================] 100% 3.3/s done in 15s\n"+
+		"\r\x1b[2K✓ scan     25 (1.7/s, 15s)\n", out.String())
+}
+
+func TestBar(t *testing.T) {
+	p, clock := newTestProgress(io.Discard)
+	bar := p.AddBar("copy", 0)
+
+	n, err := io.Copy(bar, strings.NewReader("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, int64(5), bar.Current())
+
+	bar.Increment()
+	assert.Equal(t, int64(6), bar.Current())
+	bar.SetCurrent(2)
+	bar.SetTotal(4)
+	assert.Contains(t, bar.render(clock.Now(), 4, 0), " 50% 0.0/s ETA ?")
+
+	bar.SetCurrent(-1)
+	assert.Contains(t, bar.render(clock.Now(), 4, 0), "[>                             ]   0%", "negative progress")
+	bar.SetCurrent(2)
+
+	clock.Advance(time.Second)
+	bar.Done()
+	clock.Advance(time.Hour)
+	bar.Done()
+	assert.Contains(t, bar.render(clock.Now(), 4, 0), "done in 1s", "first Done wins")
+}
+
+func TestProgressDisabled(t *testing.T) {
+	var out bytes.Buffer
+	p := NewProgress(&out)
+	assert.False(t, p.Enabled(), "buffers are not terminals")
+
+	bar := p.AddBar("work", 10)
+	bar.Add(10)
+	p.Stop()
+	assert.Empty(t, out.String())
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.False(t, NewProgress(f).Enabled(), "regular files are not terminals")
+}
+
+func TestProgressStop(t *testing.T) {
+	var out syncBuffer
+	p := NewProgress(&out).SetEnabled(true)
+
+	bar := p.AddBar("work", 10)
+	bar.Add(10)
+	bar.Done()
+	p.Stop()
+	p.Stop()
+
+	assert.Contains(t, out.String(), "work [==============================] 100%")
+}
+
+// syncBuffer is a bytes.Buffer that is safe to write from the redraw goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
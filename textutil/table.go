@@ -0,0 +1,292 @@
+// Package textutil provides helpers for text output in command-line tools:
+// width-aware string measurement, tables, and progress indicators.
+package textutil
+
+import (
+	"io"
+	"strings"
+)
+
+// Align is the horizontal alignment of a table column.
+type Align int
+
+// Column alignments.
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Style selects how a Table is drawn.
+type Style int
+
+// Table styles.
+const (
+	// StylePlain separates columns with spaces and draws no borders.
+	StylePlain Style = iota
+	// StyleASCII draws borders with +, - and |.
+	StyleASCII
+	// StyleBox draws borders with Unicode box-drawing characters.
+	StyleBox
+	// StyleMarkdown renders a GitHub-flavored Markdown table.
+	StyleMarkdown
+)
+
+// border holds the characters of a bordered style. Each rule is left, fill,
+// junction, right.
+type border struct {
+	top, mid, bottom [4]string
+	vertical         string
+}
+
+var borders = map[Style]border{
+	StyleASCII: {
+		top:      [4]string{"+", "-", "+", "+"},
+		mid:      [4]string{"+", "-", "+", "+"},
+		bottom:   [4]string{"+", "-", "+", "+"},
+		vertical: "|",
+	},
+	StyleBox: {
+		top:      [4]string{"┌", "─", "┬", "┐"},
+		mid:      [4]string{"├", "─", "┼", "┤"},
+		bottom:   [4]string{"└", "─", "┴", "┘"},
+		vertical: "│",
+	},
+}
+
+// cellReplacer keeps every cell on a single line.
+var cellReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
+// Table renders rows of text as aligned columns.
+//
+// Configure it with the chaining setters, add rows, and write it out with Render
+// or String. Column widths are measured in terminal cells (see StringWidth), so
+// CJK text and emoji line up. Cells wider than a column's maximum width are
+// truncated with "…"; newlines and tabs in cells are replaced with spaces.
+//
+// Example:
+//
+//	t := textutil.NewTable("NAME", "SIZE").
+//		SetAlign(1, textutil.AlignRight).
+//		SetMaxWidth(0, 20)
+//	t.AddRow("a.txt", "12 KB")
+//	t.AddRow("b.txt", "3 MB")
+//	fmt.Print(t)
+//	=> NAME    SIZE
+//	   a.txt  12 KB
+//	   b.txt   3 MB
+type Table struct {
+	header   []string
+	rows     [][]string
+	align    map[int]Align
+	maxWidth map[int]int
+	style    Style
+}
+
+// NewTable returns an empty table with the given column headers. A table
+// without headers is allowed.
+func NewTable(header ...string) *Table {
+	return &Table{
+		header:   header,
+		align:    make(map[int]Align),
+		maxWidth: make(map[int]int),
+	}
+}
+
+// SetStyle sets how the table is drawn. The default is StylePlain.
+func (t *Table) SetStyle(s Style) *Table {
+	t.style = s
+	return t
+}
+
+// SetAlign sets the alignment of column col (counted from 0).
+func (t *Table) SetAlign(col int, a Align) *Table {
+	t.align[col] = a
+	return t
+}
+
+// SetMaxWidth limits column col (counted from 0) to width cells. A width of 0
+// or less removes the limit.
+func (t *Table) SetMaxWidth(col, width int) *Table {
+	if width <= 0 {
+		delete(t.maxWidth, col)
+	} else {
+		t.maxWidth[col] = width
+	}
+	return t
+}
+
+// AddRow appends a row. Rows may have fewer or more cells than there are
+// headers; missing cells are left empty.
+func (t *Table) AddRow(cells ...string) *Table {
+	t.rows = append(t.rows, cells)
+	return t
+}
+
+// Len returns the number of rows, not counting the header.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// String returns the rendered table.
+func (t *Table) String() string {
+	var b strings.Builder
+	t.Render(&b)
+	return b.String()
+}
+
+// Render writes the table to w, one line per row, each ending with a newline.
+func (t *Table) Render(w io.Writer) error {
+	header, rows, widths := t.layout()
+	if len(widths) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	switch t.style {
+	case StyleMarkdown:
+		t.renderMarkdown(&b, header, rows, widths)
+	case StyleASCII, StyleBox:
+		t.renderBordered(&b, borders[t.style], header, rows, widths)
+	default:
+		t.renderPlain(&b, header, rows, widths)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// layout cleans and truncates every cell, pads all rows to the same number of
+// columns, and computes the column widths.
+func (t *Table) layout() (header []string, rows [][]string, widths []int) {
+	cols := len(t.header)
+	for _, r := range t.rows {
+		cols = max(cols, len(r))
+	}
+
+	prepare := func(cells []string) []string {
+		out := make([]string, cols)
+		for i, c := range cells {
+			c = cellReplacer.Replace(c)
+			if t.style == StyleMarkdown {
+				c = strings.ReplaceAll(c, "|", `\|`)
+			}
+			if mw, ok := t.maxWidth[i]; ok {
+				c = Truncate(c, mw, "…")
+			}
+			out[i] = c
+		}
+		return out
+	}
+
+	if len(t.header) > 0 {
+		header = prepare(t.header)
+	}
+	rows = make([][]string, len(t.rows))
+	for i, r := range t.rows {
+		rows[i] = prepare(r)
+	}
+
+	widths = make([]int, cols)
+	for _, r := range append([][]string{header}, rows...) {
+		for i, c := range r {
+			widths[i] = max(widths[i], StringWidth(c))
+		}
+	}
+
+	return header, rows, widths
+}
+
+func (t *Table) renderPlain(b *strings.Builder, header []string, rows [][]string, widths []int) {
+	line := func(cells []string) {
+		var l strings.Builder
+		for i, c := range cells {
+			if i > 0 {
+				l.WriteString("  ")
+			}
+			l.WriteString(pad(c, widths[i], t.align[i]))
+		}
+		b.WriteString(strings.TrimRight(l.String(), " "))
+		b.WriteByte('\n')
+	}
+
+	if header != nil {
+		line(header)
+	}
+	for _, r := range rows {
+		line(r)
+	}
+}
+
+func (t *Table) renderBordered(b *strings.Builder, bd border, header []string, rows [][]string, widths []int) {
+	rule := func(chars [4]string) {
+		b.WriteString(chars[0])
+		for i, w := range widths {
+			if i > 0 {
+				b.WriteString(chars[2])
+			}
+			b.WriteString(strings.Repeat(chars[1], w+2))
+		}
+		b.WriteString(chars[3])
+		b.WriteByte('\n')
+	}
+	line := func(cells []string) {
+		b.WriteString(bd.vertical)
+		for i, c := range cells {
+			b.WriteByte(' ')
+			b.WriteString(pad(c, widths[i], t.align[i]))
+			b.WriteByte(' ')
+			b.WriteString(bd.vertical)
+		}
+		b.WriteByte('\n')
+	}
+
+	rule(bd.top)
+	if header != nil {
+		line(header)
+		rule(bd.mid)
+	}
+	for _, r := range rows {
+		line(r)
+	}
+	rule(bd.bottom)
+}
+
+func (t *Table) renderMarkdown(b *strings.Builder, header []string, rows [][]string, widths []int) {
+	// The delimiter row needs at least three dashes per column.
+	for i := range widths {
+		widths[i] = max(widths[i], 3)
+	}
+	line := func(cells []string) {
+		b.WriteByte('|')
+		for i, c := range cells {
+			b.WriteByte(' ')
+			b.WriteString(pad(c, widths[i], t.align[i]))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+	}
+
+	// Markdown tables always have a header row.
+	if header == nil {
+		header = make([]string, len(widths))
+	}
+	line(header)
+
+	b.WriteByte('|')
+	for i, w := range widths {
+		dashes := strings.Repeat("-", w)
+		switch t.align[i] {
+		case AlignRight:
+			dashes = dashes[1:] + ":"
+		case AlignCenter:
+			dashes = ":" + dashes[2:] + ":"
+		}
+		b.WriteString(" " + dashes + " |")
+	}
+	b.WriteByte('\n')
+
+	for _, r := range rows {
+		line(r)
+	}
+}
@@ -0,0 +1,114 @@
+package textutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleTable() *Table {
+	t := NewTable("NAME", "SIZE", "NOTE").SetAlign(1, AlignRight)
+	t.AddRow("a.txt", "12 KB", "first")
+	t.AddRow("日本.txt", "3 MB")
+	return t
+}
+
+func TestTableStyles(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{
+			name:  "plain",
+			style: StylePlain,
+			expected: "" +
+				"NAME       SIZE  NOTE\n" +
+				"a.txt     12 KB  first\n" +
+				"日本.txt   3 MB\n",
+		},
+		{
+			name:  "ascii",
+			style: StyleASCII,
+			expected: "" +
+				"+----------+-------+-------+\n" +
+				"| NAME     |  SIZE | NOTE  |\n" +
+				"+----------+-------+-------+\n" +
+				"| a.txt    | 12 KB | first |\n" +
+				"| 日本.txt |  3 MB |       |\n" +
+				"+----------+-------+-------+\n",
+		},
+		{
+			name:  "box",
+			style: StyleBox,
+			expected: "" +
+				"┌──────────┬───────┬───────┐\n" +
+				"│ NAME     │  SIZE │ NOTE  │\n" +
+				"├──────────┼───────┼───────┤\n" +
+				"│ a.txt    │ 12 KB │ first │\n" +
+				"│ 日本.txt │  3 MB │       │\n" +
+				"└──────────┴───────┴───────┘\n",
+		},
+		{
+			name:  "markdown",
+			style: StyleMarkdown,
+			expected: "" +
+				"| NAME     |  SIZE | NOTE  |\n" +
+				"| -------- | ----: | ----- |\n" +
+				"| a.txt    | 12 KB | first |\n" +
+				"| 日本.txt |  3 MB |       |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sampleTable().SetStyle(tt.style).String(), tt.name)
+		})
+	}
+}
+
+func TestTableTruncationAndCleanup(t *testing.T) {
+	tbl := NewTable("ID", "DESCRIPTION").
+		SetMaxWidth(1, 10).
+		SetAlign(0, AlignCenter)
+	tbl.AddRow("1", "a very long description")
+	tbl.AddRow("22", "two\nlines\tand tab")
+	tbl.AddRow("333", "short", "extra")
+
+	expected := "" +
+		"ID   DESCRIPTI…\n" +
+		" 1   a very lo…\n" +
+		"22   two lines…\n" +
+		"333  short       extra\n"
+	assert.Equal(t, expected, tbl.String())
+	assert.Equal(t, 3, tbl.Len())
+
+	tbl.SetMaxWidth(1, 0)
+	assert.Contains(t, tbl.String(), "a very long description")
+}
+
+func TestTableMarkdownEscaping(t *testing.T) {
+	tbl := NewTable().SetStyle(StyleMarkdown).SetAlign(0, AlignCenter)
+	tbl.AddRow("a|b")
+
+	expected := "" +
+		"|      |\n" +
+		"| :--: |\n" +
+		`| a\|b |` + "\n"
+	assert.Equal(t, expected, tbl.String())
+}
+
+func TestTableEmpty(t *testing.T) {
+	assert.Equal(t, "", NewTable().String())
+	assert.Equal(t, "A\n", NewTable("A").String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestTableRenderError(t *testing.T) {
+	assert.EqualError(t, sampleTable().Render(failingWriter{}), "write failed")
+}
@@ -0,0 +1,53 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"日本語", 6},
+		{"한국어", 6},
+		{"ｈｉ", 4},
+		{"e\u0301", 1},
+		{"a\u200db", 2},
+		{"\x1b", 0},
+		{"😀", 2},
+		{"→", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StringWidth(tt.input))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		tail     string
+		expected string
+	}{
+		{"fits", "hello", 5, "…", "hello"},
+		{"cut", "hello world", 8, "…", "hello w…"},
+		{"ascii tail", "hello world", 8, "...", "hello..."},
+		{"no tail", "hello", 3, "", "hel"},
+		{"wide not split", "日本語", 4, "…", "日…"},
+		{"tail only", "hello", 1, "…", "…"},
+		{"too narrow", "hello", 0, "…", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Truncate(tt.input, tt.width, tt.tail), tt.name)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package tuple
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPair(t *testing.T) {
+	p := NewPair(1, "one")
+	assert.Equal(t, Pair[int, string]{First: 1, Second: "one"}, p)
+
+	n, s := p.Unpack()
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "one", s)
+
+	assert.Equal(t, NewPair("one", 1), p.Swap())
+	assert.Equal(t, "(1, one)", p.String())
+	assert.Equal(t, "(1, one)", fmt.Sprint(p))
+}
+
+func TestPairComparable(t *testing.T) {
+	seen := map[Pair[string, int]]bool{NewPair("a", 1): true}
+
+	assert.True(t, seen[NewPair("a", 1)])
+	assert.False(t, seen[NewPair("a", 2)])
+}
+
+func TestTriple(t *testing.T) {
+	tr := NewTriple("x", 2, true)
+	assert.Equal(t, Triple[string, int, bool]{First: "x", Second: 2, Third: true}, tr)
+
+	a, b, c := tr.Unpack()
+	assert.Equal(t, "x", a)
+	assert.Equal(t, 2, b)
+	assert.True(t, c)
+	assert.Equal(t, "(x, 2, true)", tr.String())
+}